@@ -0,0 +1,30 @@
+package main
+
+import (
+	"POSTnGETtrain/internal/config"
+	"errors"
+
+	"github.com/spf13/cobra"
+)
+
+// newConfigCommand server config print: действующие настройки после окружения и флагов
+func newConfigCommand(cfg *config.Config) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "config",
+		Short: "Работа с настройками",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE:  func(*cobra.Command, []string) error { return usageError{errors.New("expected print")} },
+	}
+	command.AddCommand(&cobra.Command{
+		Use:   "print",
+		Short: "Показать действующие настройки (пароли скрыты)",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			for _, setting := range cfg.Settings() {
+				cmd.Printf("%s=%s\n", setting.Env, setting.Value)
+			}
+			return nil
+		},
+	})
+	return command
+}
@@ -1,50 +1,103 @@
 package main
 
 import (
-	"POSTnGETtrain/internal/db"
-	"POSTnGETtrain/internal/handlers"
-	"POSTnGETtrain/internal/taskService"
-	"POSTnGETtrain/internal/userService"
-	"POSTnGETtrain/internal/web/tasks"
-	"POSTnGETtrain/internal/web/users"
-	"log"
-
-	"github.com/labstack/echo/v4"
-	"github.com/labstack/echo/v4/middleware"
+	"POSTnGETtrain/internal/config"
+	"POSTnGETtrain/internal/logging"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"github.com/spf13/cobra"
+)
+
+// Коды выхода
+const (
+	exitOK    = 0
+	exitError = 1 // Команда не выполнилась
+	exitUsage = 2 // Неверные аргументы
 )
 
+// usageError Ошибка в аргументах команды: завершается с кодом exitUsage
+type usageError struct{ err error }
+
+func (e usageError) Error() string { return e.err.Error() }
+func (e usageError) Unwrap() error { return e.err }
+
 func main() {
-	database, err := db.InitDB()
-	if err != nil {
-		log.Fatalf("Could not connect to database: %v", err)
-	}
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
 
-	echoServer := echo.New()
+// run Выполняет команду и возвращает код выхода
+func run(args []string, stdout, stderr io.Writer) int {
+	cfg := config.Load()
+	// Логи - JSON в stderr, stdout остается для вывода команд
+	slog.SetDefault(logging.New(stderr, logging.Config{Level: cfg.LogLevel, RedactEmails: cfg.LogRedactEmails}))
 
-	// Middleware
-	echoServer.Use(middleware.CORS())
-	echoServer.Use(middleware.Logger())
+	root := newRootCommand(&cfg)
+	root.SetArgs(args)
+	root.SetOut(stdout)
+	root.SetErr(stderr)
 
-	// Инициализация сервисов задач
-	tskRepo := taskService.NewTaskRepository(database)
-	tskService := taskService.NewTaskService(tskRepo)
-	tskHandler := handlers.NewHandler(tskService)
+	// SIGINT и SIGTERM отменяют контекст команды: сервер завершается штатно
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
 
-	// Инициализация сервисов пользователей
-	usrRepo := userService.NewUserRepository(database)
-	usrService := userService.NewUserService(usrRepo)
-	usrHandler := handlers.NewUserHandler(usrService)
+	cmd, err := root.ExecuteContextC(ctx)
+	if err == nil {
+		return exitOK
+	}
+	fmt.Fprintf(stderr, "Error: %v\n", err)
+	var usage usageError
+	if errors.As(err, &usage) {
+		fmt.Fprintf(stderr, "Run '%s --help' for usage.\n", cmd.CommandPath())
+		return exitUsage
+	}
+	return exitError
+}
 
-	// Регистрация обработчиков OpenAPI
-	taskStrictHandler := tasks.NewStrictHandler(tskHandler, nil)
-	tasks.RegisterHandlers(echoServer, taskStrictHandler)
+// newRootCommand Дерево команд. Настройки читаются из окружения один раз, флаги переопределяют их
+func newRootCommand(cfg *config.Config) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "server",
+		Short: "API задач и пользователей",
+		Long: `API задач и пользователей.
+Без подкоманды запускает API (как server serve). Настройки читаются из переменных окружения,
+действующие значения показывает server config print.`,
+		Args:          usageArgs(cobra.NoArgs),
+		RunE:          func(cmd *cobra.Command, _ []string) error { return serve(cmd.Context(), *cfg) },
+		SilenceErrors: true, // Ошибку печатает run
+		SilenceUsage:  true, // Справка только по --help, а не после каждой ошибки
+	}
+	root.SetFlagErrorFunc(func(_ *cobra.Command, err error) error { return usageError{err} })
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&cfg.Storage, "storage", cfg.Storage, "хранилище данных: database или memory (STORAGE)")
+	flags.StringVar(&cfg.DatabaseURL, "database-url", cfg.DatabaseURL, "адрес БД: postgres://... или sqlite://path (DATABASE_URL)")
+	flags.Lookup("database-url").DefValue = config.RedactDSN(cfg.DatabaseURL) // В --help без пароля
 
-	userStrictHandler := users.NewStrictHandler(usrHandler, nil)
-	users.RegisterHandlers(echoServer, userStrictHandler)
+	root.AddCommand(
+		newServeCommand(cfg),
+		newMigrateCommand(cfg),
+		newSeedCommand(cfg),
+		newSchemaCommand(cfg),
+		newUserCommand(cfg),
+		newConfigCommand(cfg),
+		newVersionCommand(),
+	)
+	return root
+}
 
-	// Запуск сервера
-	err = echoServer.Start("localhost:8080")
-	if err != nil {
-		log.Fatalf("Could not start: %v", err)
+// usageArgs Помечает ошибки проверки позиционных аргументов как ошибки использования
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return usageError{err}
+		}
+		return nil
 	}
 }
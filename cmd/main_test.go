@@ -0,0 +1,91 @@
+package main
+
+import (
+	"POSTnGETtrain/internal/db"
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/password"
+	"bytes"
+	"path/filepath"
+	"slices"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// TestRun Команды выполняются по очереди на одной БД SQLite
+func TestRun(t *testing.T) {
+	dsn := "sqlite://" + filepath.Join(t.TempDir(), "cli.db")
+
+	steps := []struct {
+		name     string
+		args     []string
+		wantCode int
+		wantOut  string
+	}{
+		{name: "версия", args: []string{"version"}, wantOut: "server dev"},
+		{name: "неизвестная команда", args: []string{"deploy"}, wantCode: exitUsage},
+		{name: "неизвестный флаг", args: []string{"version", "--verbose"}, wantCode: exitUsage},
+		{name: "группа без подкоманды", args: []string{"user"}, wantCode: exitUsage},
+		{name: "пароль в настройках скрыт", args: []string{"config", "print", "--database-url", "postgres://app:hunter2@db/app"},
+			wantOut: "DATABASE_URL=postgres://app:xxxxx@db/app"},
+		{name: "схема не применена", args: []string{"user", "create-admin", "--email", "root@example.com"}, wantCode: exitError},
+		{name: "миграции", args: []string{"migrate", "up"}, wantOut: "applied"},
+		{name: "откат на нечисло", args: []string{"migrate", "down", "all"}, wantCode: exitUsage},
+		{name: "схема совпадает с моделями", args: []string{"schema", "check"}, wantOut: "schema matches models"},
+		{name: "админ без email", args: []string{"user", "create-admin"}, wantCode: exitUsage},
+		{name: "слабый пароль", args: []string{"user", "create-admin", "--email", "root@example.com", "--password", "s3cret"},
+			wantCode: exitError},
+		{name: "создание админа", args: []string{"user", "create-admin", "--email", "root@example.com", "--password", "s3cret-passphrase"},
+			wantOut: "created admin root@example.com"},
+		{name: "данные", args: []string{"seed", "--users", "3", "--tasks", "10"}, wantOut: "seeded 3 users and 10 tasks"},
+		{name: "неверные доли", args: []string{"seed", "--done", "2"}, wantCode: exitUsage},
+		{name: "повышение существующего", args: []string{"user", "create-admin", "--email", "root@example.com"},
+			wantOut: "promoted root@example.com"},
+		{name: "сброс пароля", args: []string{"user", "reset-password", "--email", "root@example.com", "--password", "n3w-passphrase"},
+			wantOut: "password reset for root@example.com"},
+		{name: "сброс пароля неизвестному", args: []string{"user", "reset-password", "--email", "nobody@example.com"},
+			wantCode: exitError},
+		{name: "два источника пароля", args: []string{"user", "reset-password", "--email", "root@example.com",
+			"--password", "x", "--password-stdin"}, wantCode: exitUsage},
+	}
+
+	for _, step := range steps {
+		var stdout, stderr bytes.Buffer
+		args := step.args
+		if !slices.Contains(args, "--database-url") {
+			args = append(args, "--database-url", dsn)
+		}
+		code := run(args, &stdout, &stderr)
+		require.Equal(t, step.wantCode, code, "%s: %s\nstdout: %s\nstderr: %s",
+			step.name, strings.Join(step.args, " "), stdout.String(), stderr.String())
+		assert.Contains(t, stdout.String(), step.wantOut, step.name)
+	}
+
+	database, err := db.Open(dsn, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	var admin models.User
+	require.NoError(t, database.Where("email = ?", "root@example.com").First(&admin).Error)
+	assert.True(t, admin.IsAdmin)
+	assert.Equal(t, "n3w-passphrase", admin.Password)
+}
+
+func TestGeneratedPassword(t *testing.T) {
+	dsn := "sqlite://" + filepath.Join(t.TempDir(), "cli.db")
+	require.Equal(t, exitOK, run([]string{"migrate", "up", "--database-url", dsn}, &bytes.Buffer{}, &bytes.Buffer{}))
+
+	var stdout bytes.Buffer
+	code := run([]string{"user", "create-admin", "--email", "root@example.com", "--database-url", dsn}, &stdout, &bytes.Buffer{})
+	require.Equal(t, exitOK, code)
+
+	_, generated, found := strings.Cut(stdout.String(), "password: ")
+	require.True(t, found, stdout.String())
+	generated = strings.TrimSpace(generated)
+	assert.Len(t, generated, 27)
+	// Сгенерированный пароль проходит политику с любыми классами символов
+	strict := password.Policy{MinLength: 20, Require: password.Classes, DisallowEmail: true}
+	assert.NoError(t, strict.Check(generated, "root@example.com"))
+}
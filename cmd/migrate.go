@@ -0,0 +1,167 @@
+package main
+
+import (
+	"POSTnGETtrain/internal/config"
+	"POSTnGETtrain/internal/db"
+	"POSTnGETtrain/internal/migrate"
+	"POSTnGETtrain/migrations"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"strconv"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+)
+
+// newMigrateCommand server migrate up|down|status|force
+func newMigrateCommand(cfg *config.Config) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "migrate",
+		Short: "Управлять миграциями схемы БД",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return usageError{errors.New("expected up, down, status or force")}
+		},
+	}
+
+	command.AddCommand(
+		&cobra.Command{
+			Use:   "up",
+			Short: "Применить все миграции",
+			Args:  usageArgs(cobra.NoArgs),
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				migrator, err := openMigrator(cfg)
+				if err != nil {
+					return err
+				}
+				applied, err := migrator.Up(cmd.Context())
+				for _, m := range applied {
+					cmd.Printf("applied %d_%s\n", m.Version, m.Name)
+				}
+				if err == nil && len(applied) == 0 {
+					cmd.Println("no change")
+				}
+				return err
+			},
+		},
+		&cobra.Command{
+			Use:   "down [N]",
+			Short: "Откатить N последних миграций (по умолчанию 1)",
+			Args:  usageArgs(cobra.MaximumNArgs(1)),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				steps := 1
+				if len(args) > 0 {
+					var err error
+					if steps, err = strconv.Atoi(args[0]); err != nil || steps < 1 {
+						return usageError{fmt.Errorf("N must be a positive number, got %q", args[0])}
+					}
+				}
+				migrator, err := openMigrator(cfg)
+				if err != nil {
+					return err
+				}
+				reverted, err := migrator.Down(cmd.Context(), steps)
+				for _, m := range reverted {
+					cmd.Printf("reverted %d_%s\n", m.Version, m.Name)
+				}
+				return err
+			},
+		},
+		&cobra.Command{
+			Use:   "status",
+			Short: "Показать версию схемы",
+			Args:  usageArgs(cobra.NoArgs),
+			RunE: func(cmd *cobra.Command, _ []string) error {
+				migrator, err := openMigrator(cfg)
+				if err != nil {
+					return err
+				}
+				status, err := migrator.Status(cmd.Context())
+				if err != nil {
+					return err
+				}
+				cmd.Printf("version: %d (latest %d)\n", status.Version, status.Latest)
+				if status.Dirty {
+					cmd.Println("dirty: fix the failed migration and run migrate force")
+				}
+				for _, m := range status.Pending {
+					cmd.Printf("pending %d_%s\n", m.Version, m.Name)
+				}
+				return nil
+			},
+		},
+		&cobra.Command{
+			Use:   "force VERSION",
+			Short: "Записать версию без выполнения миграций",
+			Args:  usageArgs(cobra.ExactArgs(1)),
+			RunE: func(cmd *cobra.Command, args []string) error {
+				version, err := strconv.ParseInt(args[0], 10, 64)
+				if err != nil {
+					return usageError{fmt.Errorf("bad version %q", args[0])}
+				}
+				migrator, err := openMigrator(cfg)
+				if err != nil {
+					return err
+				}
+				if err := migrator.Force(cmd.Context(), version); err != nil {
+					return err
+				}
+				cmd.Printf("forced version %d\n", version)
+				return nil
+			},
+		},
+	)
+	return command
+}
+
+// openMigrator Подключается к БД и читает встроенные миграции
+func openMigrator(cfg *config.Config) (*migrate.Migrator, error) {
+	database, err := db.InitDB(cfg.DatabaseURL)
+	if err != nil {
+		return nil, err
+	}
+	return migrate.New(database, migrations.FS)
+}
+
+// openDatabase Подключается к БД для команды, работающей с данными: схема должна быть актуальной
+func openDatabase(cfg *config.Config) (*gorm.DB, error) {
+	database, err := db.InitDB(cfg.DatabaseURL)
+	if err != nil {
+		return nil, err
+	}
+	if err := ensureSchema(database, cfg.AutoMigrate); err != nil {
+		return nil, err
+	}
+	return database, nil
+}
+
+// ensureSchema Применяет миграции (если включено) и проверяет, что схема не отстает от бинарника
+func ensureSchema(database *gorm.DB, autoMigrate bool) error {
+	migrator, err := migrate.New(database, migrations.FS)
+	if err != nil {
+		return err
+	}
+	ctx := context.Background()
+
+	if autoMigrate {
+		applied, err := migrator.Up(ctx)
+		for _, m := range applied {
+			slog.Info("migration applied", slog.Int64("version", m.Version), slog.String("name", m.Name))
+		}
+		if err != nil {
+			return err
+		}
+	}
+
+	status, err := migrator.Status(ctx)
+	if err != nil {
+		return err
+	}
+	if status.Behind() {
+		return fmt.Errorf("version %d (dirty: %t) is behind %d, run `server migrate up` or set AUTO_MIGRATE=true",
+			status.Version, status.Dirty, status.Latest)
+	}
+	return nil
+}
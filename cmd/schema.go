@@ -0,0 +1,52 @@
+package main
+
+import (
+	"POSTnGETtrain/internal/config"
+	"POSTnGETtrain/internal/db"
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/schemacheck"
+	"errors"
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// newSchemaCommand server schema check: сверяет схему БД с моделями.
+// Завершается с exitError, если найдены расхождения
+func newSchemaCommand(cfg *config.Config) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "schema",
+		Short: "Проверить схему БД",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE:  func(*cobra.Command, []string) error { return usageError{errors.New("expected check")} },
+	}
+	command.AddCommand(&cobra.Command{
+		Use:   "check",
+		Short: "Сверить схему БД с моделями",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			database, err := db.InitDB(cfg.DatabaseURL)
+			if err != nil {
+				return err
+			}
+			// Без лога запросов интроспекции: в выводе только расхождения
+			quiet := database.Session(&gorm.Session{Logger: logger.Discard})
+			issues, err := schemacheck.Check(quiet, models.Stored()...)
+			if err != nil {
+				return err
+			}
+
+			for _, issue := range issues {
+				cmd.Println(issue)
+			}
+			if len(issues) > 0 {
+				return fmt.Errorf("%d schema issue(s) found", len(issues))
+			}
+			cmd.Println("schema matches models")
+			return nil
+		},
+	})
+	return command
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"POSTnGETtrain/internal/config"
+	"POSTnGETtrain/internal/seed"
+	"POSTnGETtrain/internal/taskService"
+	"POSTnGETtrain/internal/userService"
+
+	"github.com/spf13/cobra"
+)
+
+// newSeedCommand server seed: наполняет БД сгенерированными пользователями и задачами
+func newSeedCommand(cfg *config.Config) *cobra.Command {
+	seedCfg := seed.DefaultConfig()
+	command := &cobra.Command{
+		Use:   "seed",
+		Short: "Наполнить БД тестовыми данными",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			// Генерируем до подключения: ошибка в параметрах не должна трогать БД
+			dataset, err := seed.Generate(seedCfg)
+			if err != nil {
+				return usageError{err}
+			}
+
+			database, err := openDatabase(cfg)
+			if err != nil {
+				return err
+			}
+
+			users := userService.NewUserService(userService.NewUserRepository(database))
+			tasks := taskService.NewTaskService(taskService.NewTaskRepository(database))
+			result, err := seed.Load(cmd.Context(), dataset, users, tasks)
+			if err != nil {
+				return err
+			}
+			cmd.Printf("seeded %d users and %d tasks (seed %d)\n", len(result.Users), len(result.Tasks), seedCfg.Seed)
+			return nil
+		},
+	}
+
+	flags := command.Flags()
+	flags.IntVar(&seedCfg.Users, "users", seedCfg.Users, "сколько пользователей создать")
+	flags.IntVar(&seedCfg.Tasks, "tasks", seedCfg.Tasks, "сколько задач создать")
+	flags.Float64Var(&seedCfg.DoneRatio, "done", seedCfg.DoneRatio, "доля выполненных задач")
+	flags.Float64Var(&seedCfg.LongRatio, "long", seedCfg.LongRatio, "доля задач с длинным названием")
+	flags.Float64Var(&seedCfg.UnicodeRatio, "unicode", seedCfg.UnicodeRatio, "доля названий не на латинице")
+	flags.Uint64Var(&seedCfg.Seed, "seed", seedCfg.Seed, "зерно генератора: одинаковое зерно дает одинаковые данные")
+	return command
+}
@@ -0,0 +1,433 @@
+package main
+
+import (
+	"POSTnGETtrain/internal/authService"
+	"POSTnGETtrain/internal/config"
+	"POSTnGETtrain/internal/db"
+	"POSTnGETtrain/internal/docs"
+	"POSTnGETtrain/internal/handlers"
+	"POSTnGETtrain/internal/idempotency"
+	"POSTnGETtrain/internal/logging"
+	"POSTnGETtrain/internal/mailer"
+	"POSTnGETtrain/internal/metrics"
+	"POSTnGETtrain/internal/password"
+	"POSTnGETtrain/internal/ratelimit"
+	"POSTnGETtrain/internal/requestid"
+	"POSTnGETtrain/internal/taskService"
+	"POSTnGETtrain/internal/tlsconfig"
+	"POSTnGETtrain/internal/tracing"
+	"POSTnGETtrain/internal/userService"
+	"POSTnGETtrain/internal/validation"
+	"POSTnGETtrain/internal/web"
+	"POSTnGETtrain/internal/web/auth"
+	"POSTnGETtrain/internal/web/tasks"
+	"POSTnGETtrain/internal/web/users"
+	"POSTnGETtrain/openapi"
+	"POSTnGETtrain/pkg/patch"
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"log/slog"
+	"maps"
+	"net"
+	"net/http"
+	"os"
+	"slices"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+	"github.com/labstack/gommon/bytes"
+	"github.com/spf13/cobra"
+)
+
+// newServeCommand server serve: запуск API. Эта же команда выполняется, если подкоманда не указана
+func newServeCommand(cfg *config.Config) *cobra.Command {
+	return &cobra.Command{
+		Use:   "serve",
+		Short: "Запустить API",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE:  func(cmd *cobra.Command, _ []string) error { return serve(cmd.Context(), *cfg) },
+	}
+}
+
+// serve Запускает HTTP-сервер и останавливает его с отменой ctx
+func serve(ctx context.Context, cfg config.Config) error {
+	// Трассировка настраивается до middleware, которые берут глобальный TracerProvider
+	shutdownTracing, err := tracing.Setup(ctx, tracing.Config{
+		Exporter:    cfg.TracesExporter,
+		Endpoint:    cfg.TracesEndpoint,
+		SampleRatio: cfg.TracesSampleRatio,
+		ServiceName: cfg.ServiceName,
+		Version:     version,
+	}, os.Stdout)
+	if errors.Is(err, tracing.ErrUnknownExporter) {
+		return usageError{err}
+	}
+	if err != nil {
+		return err
+	}
+	defer func() {
+		// Отправляем накопленные спаны перед выходом
+		ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+		defer cancel()
+		if err := shutdownTracing(ctx); err != nil {
+			slog.Error("could not flush traces", slog.String("error", err.Error()))
+		}
+	}()
+
+	echoServer, wait, err := newServer(ctx, cfg)
+	if err != nil {
+		return err
+	}
+	listener, err := net.Listen("tcp", cfg.Address)
+	if err != nil {
+		return fmt.Errorf("could not listen: %w", err)
+	}
+
+	// Запуск сервера
+	slog.Info("server started",
+		slog.String("address", listener.Addr().String()),
+		slog.String("storage", cfg.Storage),
+		slog.Bool("tls", echoServer.Server.TLSConfig != nil),
+		slog.Bool("h2c", cfg.H2C),
+	)
+	go func() {
+		<-ctx.Done()
+		// Даем начатым запросам завершиться
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := echoServer.Shutdown(shutdownCtx); err != nil {
+			slog.Error("could not shut down server", slog.String("error", err.Error()))
+		}
+	}()
+	if err := start(echoServer, listener); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	wait() // Письма, поставленные в очередь до остановки, все равно уходят
+	slog.Info("server stopped")
+	return nil
+}
+
+// start Обслуживает соединения listener: по TLS, если он настроен в newServer, иначе по HTTP
+func start(echoServer *echo.Echo, listener net.Listener) error {
+	if echoServer.Server.TLSConfig != nil {
+		return echoServer.Server.ServeTLS(listener, "", "") // Сертификат отдает GetCertificate
+	}
+	return echoServer.Server.Serve(listener)
+}
+
+// newServer Собирает Echo с хранилищем, middleware и маршрутами API по настройкам.
+// Сертификат TLS перечитывается при замене файлов, пока не отменен ctx.
+// Вторым значением возвращается ожидание фоновых отправок писем после остановки сервера
+func newServer(ctx context.Context, cfg config.Config) (*echo.Echo, func(), error) {
+	limits, err := rateLimits(cfg)
+	if err != nil {
+		return nil, nil, usageError{err}
+	}
+	// Значение проверяется здесь: middleware.BodyLimit паникует на неверном
+	if _, err := bytes.Parse(cfg.BodyLimit); err != nil {
+		return nil, nil, usageError{fmt.Errorf("BODY_LIMIT: %w", err)}
+	}
+	mail, err := mailer.New(mailer.Config{
+		Transport:    cfg.Mailer,
+		From:         cfg.MailFrom,
+		Dir:          cfg.MailDir,
+		SMTPAddr:     cfg.SMTPAddr,
+		SMTPUsername: cfg.SMTPUsername,
+		SMTPPassword: cfg.SMTPPassword,
+	})
+	if err != nil {
+		return nil, nil, usageError{err}
+	}
+	passwords, err := passwordPolicy(cfg)
+	if err != nil {
+		return nil, nil, usageError{err}
+	}
+	policy, err := authService.NewPolicy(cfg.UnverifiedPermissions)
+	if err != nil {
+		return nil, nil, usageError{fmt.Errorf("UNVERIFIED_PERMISSIONS: %w", err)}
+	}
+	identities, err := tlsconfig.ParseIdentities(cfg.TLSClientIdentities)
+	if err != nil {
+		return nil, nil, usageError{fmt.Errorf("TLS_CLIENT_IDENTITIES: %w", err)}
+	}
+	tlsCfg := tlsconfig.Config{
+		CertFile:       cfg.TLSCertFile,
+		KeyFile:        cfg.TLSKeyFile,
+		ClientCAFile:   cfg.TLSClientCAFile,
+		ReloadInterval: cfg.TLSReloadInterval,
+	}
+	var serverTLS *tls.Config
+	if tlsCfg.Enabled() {
+		serverTLS, err = tlsconfig.New(ctx, tlsCfg)
+		if errors.Is(err, tlsconfig.ErrIncompleteKeyPair) {
+			return nil, nil, usageError{err}
+		}
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	// Репозитории выбранного хранилища
+	var (
+		tskRepo     taskService.TaskRepository
+		usrRepo     userService.UserRepository
+		idemStore   idempotency.Store
+		rateStore   ratelimit.Store
+		resetTokens authService.ResetTokenRepository
+		totps       authService.TOTPRepository
+	)
+	switch cfg.Storage {
+	case config.StorageDatabase:
+		database, err := db.InitDB(cfg.DatabaseURL)
+		if err != nil {
+			return nil, nil, err
+		}
+		// Не обслуживаем запросы на схеме старее бинарника
+		if err := ensureSchema(database, cfg.AutoMigrate); err != nil {
+			return nil, nil, fmt.Errorf("database schema is not ready: %w", err)
+		}
+		if err := metrics.InstrumentDB(database); err != nil {
+			return nil, nil, fmt.Errorf("could not instrument database: %w", err)
+		}
+		if err := tracing.InstrumentDB(database); err != nil {
+			return nil, nil, fmt.Errorf("could not instrument database: %w", err)
+		}
+		tskRepo = taskService.NewTaskRepository(database)
+		usrRepo = userService.NewUserRepository(database)
+		idemStore = idempotency.NewGormStore(database)
+		rateStore = ratelimit.NewGormStore(database)
+		resetTokens = authService.NewResetTokenRepository(database)
+		totps = authService.NewTOTPRepository(database)
+	case config.StorageMemory:
+		tskRepo = taskService.NewMemoryTaskRepository()
+		usrRepo = userService.NewMemoryUserRepository(tskRepo)
+		idemStore = idempotency.NewMemoryStore()
+		rateStore = ratelimit.NewMemoryStore()
+		resetTokens = authService.NewMemoryResetTokenRepository()
+		totps = authService.NewMemoryTOTPRepository()
+	default:
+		return nil, nil, usageError{fmt.Errorf("unknown storage %q: expected %s or %s", cfg.Storage, config.StorageDatabase, config.StorageMemory)}
+	}
+
+	echoServer := echo.New()
+	echoServer.Binder = patch.NewBinder() // Тела application/merge-patch+json и application/json-patch+json
+	echoServer.HideBanner = true          // О старте сообщает JSON-лог
+	echoServer.HidePort = true
+	echoServer.HTTPErrorHandler = requestid.ErrorHandler // Тела ошибок с request_id
+	echoServer.IPExtractor = echo.ExtractIPDirect()      // IP клиента для лимитов частоты
+	if cfg.TrustProxy {
+		echoServer.IPExtractor = echo.ExtractIPFromXFFHeader()
+	}
+	echoServer.Server.ReadTimeout = cfg.ReadTimeout
+	echoServer.Server.ReadHeaderTimeout = cfg.ReadHeaderTimeout
+	echoServer.Server.WriteTimeout = cfg.WriteTimeout
+	echoServer.Server.IdleTimeout = cfg.IdleTimeout
+	echoServer.Server.Handler = echoServer
+	echoServer.Server.TLSConfig = serverTLS
+	// HTTP/2 по TLS согласуется через ALPN; без TLS - только если включен H2C
+	echoServer.Server.Protocols = new(http.Protocols)
+	echoServer.Server.Protocols.SetHTTP1(true)
+	echoServer.Server.Protocols.SetHTTP2(true)
+	echoServer.Server.Protocols.SetUnencryptedHTTP2(cfg.H2C)
+
+	// Middleware: спан запроса первым, чтобы журнал и метрики были внутри трассы;
+	// X-Request-ID до всего, что пишет логи и ответы об ошибках (в том числе до
+	// ServerInterfaceWrapper с его ошибками привязки); журнал запросов до остальных,
+	// чтобы в него попадали и их ответы; заголовки безопасности до всего, что может
+	// ответить ошибкой; сервис mTLS до лимитов частоты, которые считаются по нему;
+	// лимиты после CORS, чтобы preflight не расходовал бюджет, а браузер мог прочитать
+	// ответ 429; размер тела до всего, что читает тело
+	echoServer.Use(tracing.Middleware(cfg.ServiceName, metrics.Path))
+	echoServer.Use(requestid.Middleware())
+	echoServer.Use(logging.Middleware(slog.Default()))
+	echoServer.Use(metrics.Middleware())
+	echoServer.Use(middleware.SecureWithConfig(middleware.SecureConfig{
+		ContentTypeNosniff: "nosniff",
+		XFrameOptions:      "DENY",
+		HSTSMaxAge:         int(cfg.HSTSMaxAge.Seconds()), // Только для запросов по HTTPS
+		ReferrerPolicy:     "no-referrer",
+	}))
+	if serverTLS != nil && serverTLS.ClientCAs != nil {
+		echoServer.Use(tlsconfig.Middleware(identities)) // Сервис-клиент по сертификату
+	}
+	if len(cfg.CORSAllowOrigins) > 0 {
+		echoServer.Use(middleware.CORSWithConfig(middleware.CORSConfig{
+			AllowOrigins:  cfg.CORSAllowOrigins,
+			AllowMethods:  cfg.CORSAllowMethods,
+			AllowHeaders:  cfg.CORSAllowHeaders,
+			ExposeHeaders: corsExposeHeaders,
+			MaxAge:        int((10 * time.Minute).Seconds()),
+		}))
+	}
+	echoServer.Use(ratelimit.Middleware(ratelimit.Config{
+		Store: rateStore,
+		Read:  limits.read,
+		Write: limits.write,
+		Routes: map[string]ratelimit.Limit{ // Операции без учетной записи
+			"POST /users":                    limits.auth,
+			"POST /auth/verify-email":        limits.auth,
+			"POST /auth/verify-email/resend": limits.auth,
+			"POST /auth/password/forgot":     limits.auth,
+			"POST /auth/password/reset":      limits.auth,
+			"POST /auth/totp/enroll":         limits.auth,
+			"POST /auth/totp/confirm":        limits.auth,
+			"POST /auth/verify-credentials":  limits.auth,
+		},
+		Skipper: func(c echo.Context) bool {
+			return c.Path() == metrics.Path
+		},
+	}))
+	echoServer.Use(middleware.BodyLimit(cfg.BodyLimit))
+
+	// Проверка запросов по встроенной спецификации openapi.yaml
+	spec, err := openapi.Load()
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not load OpenAPI spec: %w", err)
+	}
+	validator, err := validation.Middleware(spec, validation.Config{ValidateResponses: cfg.ValidateResponses})
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not create request validator: %w", err)
+	}
+	echoServer.Use(validator)
+
+	// Метрики Prometheus
+	echoServer.GET(metrics.Path, echo.WrapHandler(metrics.Handler()))
+
+	// Спецификация и Swagger UI: /openapi.json, /openapi.yaml, /docs
+	mtls := serverTLS != nil && serverTLS.ClientCAs != nil
+	docsCfg := docs.Config{ServerURL: cfg.PublicURL, Extensions: mutualTLSExtension(mtls, identities)}
+	if err := docs.Register(echoServer, spec, docsCfg); err != nil {
+		return nil, nil, fmt.Errorf("could not register API docs: %w", err)
+	}
+
+	// Инициализация сервисов пользователей, подтверждения email, сброса пароля и TOTP
+	usrService := userService.NewTracedUserService(userService.NewUserService(usrRepo, userService.WithPasswordPolicy(passwords)))
+	if cfg.EmailTokenSecret == "" {
+		slog.Warn("EMAIL_TOKEN_SECRET is not set: verification links will stop working after restart")
+	}
+	authSvc, err := authService.NewAuthService(usrService, resetTokens, totps, mail, authService.Config{
+		Secret:    []byte(cfg.EmailTokenSecret),
+		TTL:       cfg.EmailTokenTTL,
+		VerifyURL: cfg.EmailVerifyURL,
+		ResetTTL:  cfg.PasswordResetTTL,
+		ResetURL:  cfg.PasswordResetURL,
+		Issuer:    cfg.TOTPIssuer,
+	})
+	if errors.Is(err, authService.ErrInvalidLinkURL) {
+		return nil, nil, usageError{fmt.Errorf("EMAIL_VERIFY_URL or PASSWORD_RESET_URL: %w", err)}
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	// Новым пользователям и после смены email уходит письмо подтверждения;
+	// смена пароля или email и удаление пользователя с TOTP требуют код из X-TOTP-Code
+	usrHandler := handlers.NewUserHandler(authService.NewTOTPUserService(
+		authService.NewVerifyingUserService(usrService, authSvc), authSvc, totps), cfg.RequireIfMatch)
+	authHandler := handlers.NewAuthHandler(authSvc)
+
+	// Инициализация сервисов задач: изменения задач пользователей с неподтвержденным email ограничены политикой
+	tskService := taskService.NewTracedTaskService(taskService.NewTaskService(tskRepo))
+	tskHandler := handlers.NewHandler(authService.NewPolicyTaskService(tskService, usrService, policy), cfg.RequireIfMatch)
+
+	// Повторы POST-запросов с Idempotency-Key получают сохраненный ответ
+	idempotent := idempotency.Wrap(echoServer, idempotency.Middleware(idempotency.Config{
+		Store:       idemStore,
+		TTL:         cfg.IdempotencyTTL,
+		WaitTimeout: cfg.IdempotencyWait,
+	}))
+
+	// Регистрация обработчиков OpenAPI
+	// Каждая операция выполняется в своем спане
+	taskStrictHandler := tasks.NewStrictHandler(tskHandler, []tasks.StrictMiddlewareFunc{tracing.StrictMiddleware})
+	userStrictHandler := users.NewStrictHandler(usrHandler, []users.StrictMiddlewareFunc{tracing.StrictMiddleware})
+	authStrictHandler := auth.NewStrictHandler(authHandler, []auth.StrictMiddlewareFunc{tracing.StrictMiddleware})
+	web.RegisterHandlers(idempotent, taskStrictHandler, userStrictHandler, authStrictHandler)
+	return echoServer, authSvc.Wait, nil
+}
+
+// corsExposeHeaders Заголовки ответов API, которые может прочитать браузерный клиент
+var corsExposeHeaders = []string{
+	"ETag",
+	requestid.Header,
+	idempotency.HeaderReplayed,
+	echo.HeaderRetryAfter,
+	ratelimit.HeaderLimit,
+	ratelimit.HeaderRemaining,
+	ratelimit.HeaderReset,
+	ratelimit.HeaderPolicy,
+}
+
+// rateLimitConfig Разобранные лимиты частоты запросов из настроек
+type rateLimitConfig struct {
+	read, write, auth ratelimit.Limit
+}
+
+// rateLimits Разбирает RATE_LIMIT_READ, RATE_LIMIT_WRITE и RATE_LIMIT_AUTH
+func rateLimits(cfg config.Config) (rateLimitConfig, error) {
+	var (
+		result rateLimitConfig
+		err    error
+	)
+	if result.read, err = ratelimit.ParseLimit(cfg.RateLimitRead); err != nil {
+		return rateLimitConfig{}, fmt.Errorf("RATE_LIMIT_READ: %w", err)
+	}
+	if result.write, err = ratelimit.ParseLimit(cfg.RateLimitWrite); err != nil {
+		return rateLimitConfig{}, fmt.Errorf("RATE_LIMIT_WRITE: %w", err)
+	}
+	if result.auth, err = ratelimit.ParseLimit(cfg.RateLimitAuth); err != nil {
+		return rateLimitConfig{}, fmt.Errorf("RATE_LIMIT_AUTH: %w", err)
+	}
+	return result, nil
+}
+
+// breachedListOff Значение PASSWORD_BREACHED_LIST, которое отключает проверку утекших паролей
+const breachedListOff = "off"
+
+// passwordPolicy Собирает политику паролей из PASSWORD_*
+func passwordPolicy(cfg config.Config) (password.Policy, error) {
+	classes, err := password.ParseClasses(cfg.PasswordRequire)
+	if err != nil {
+		return password.Policy{}, fmt.Errorf("PASSWORD_REQUIRE: %w", err)
+	}
+	if cfg.PasswordMinLength < 1 || (cfg.PasswordMaxLength > 0 && cfg.PasswordMaxLength < cfg.PasswordMinLength) {
+		return password.Policy{}, fmt.Errorf("PASSWORD_MIN_LENGTH and PASSWORD_MAX_LENGTH: expected 1 <= min <= max, got %d and %d",
+			cfg.PasswordMinLength, cfg.PasswordMaxLength)
+	}
+	policy := password.Policy{
+		MinLength:     cfg.PasswordMinLength,
+		MaxLength:     cfg.PasswordMaxLength,
+		Require:       classes,
+		DisallowEmail: cfg.PasswordDisallowEmail,
+	}
+
+	switch cfg.PasswordBreachedList {
+	case breachedListOff:
+	case "":
+		policy.Breached, err = password.BundledBreachedList()
+	default:
+		policy.Breached, err = password.LoadBreachedList(cfg.PasswordBreachedList)
+	}
+	if err != nil {
+		return password.Policy{}, fmt.Errorf("PASSWORD_BREACHED_LIST: %w", err)
+	}
+	return policy, nil
+}
+
+// mutualTLSExtension Описание клиентских сертификатов сервисов для отдаваемой спецификации, если настроен
+// TLS_CLIENT_CA_FILE. Схема mutualTLS появилась только в OpenAPI 3.1, поэтому для 3.0 это расширение x-mutual-tls
+func mutualTLSExtension(mtls bool, identities map[string]string) map[string]any {
+	if !mtls {
+		return nil
+	}
+	services := slices.Compact(slices.Sorted(maps.Values(identities)))
+	if services == nil {
+		services = []string{}
+	}
+	return map[string]any{"x-mutual-tls": map[string]any{
+		"description": "Client certificate signed by the configured CA (TLS_CLIENT_CA_FILE) for service-to-service calls",
+		"services":    services,
+	}}
+}
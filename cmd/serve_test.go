@@ -0,0 +1,782 @@
+package main
+
+import (
+	"POSTnGETtrain/internal/config"
+	"POSTnGETtrain/internal/mailer"
+	"POSTnGETtrain/internal/tlsconfig/tlstest"
+	"bytes"
+	"crypto/tls"
+	"encoding/json"
+	"errors"
+	"io"
+	"mime/quotedprintable"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"net/mail"
+	"net/url"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer Echo из newServer на хранилище в памяти с настройками по умолчанию и поправками change
+func newTestServer(t *testing.T, change func(cfg *config.Config)) *echo.Echo {
+	t.Helper()
+	e, _ := newTestServerWait(t, change)
+	return e
+}
+
+// newTestServerWait То же, что newTestServer, и ожидание фоновых отправок писем
+func newTestServerWait(t *testing.T, change func(cfg *config.Config)) (*echo.Echo, func()) {
+	t.Helper()
+	cfg := config.Load()
+	cfg.Storage = config.StorageMemory
+	cfg.CORSAllowOrigins = []string{"https://app.example.com"}
+	cfg.Mailer = mailer.TransportLog
+	if change != nil {
+		change(&cfg)
+	}
+	e, wait, err := newServer(t.Context(), cfg)
+	require.NoError(t, err)
+	t.Cleanup(wait)
+	return e, wait
+}
+
+func TestServerCORS(t *testing.T) {
+	e := newTestServer(t, nil)
+
+	tests := []struct {
+		name          string
+		method        string
+		origin        string
+		wantOrigin    string
+		wantStatus    int
+		wantPreflight bool
+	}{
+		{name: "preflight разрешенного источника", method: http.MethodOptions, origin: "https://app.example.com",
+			wantOrigin: "https://app.example.com", wantStatus: http.StatusNoContent, wantPreflight: true},
+		{name: "preflight чужого источника", method: http.MethodOptions, origin: "https://evil.example.com",
+			wantStatus: http.StatusNoContent},
+		{name: "запрос разрешенного источника", method: http.MethodGet, origin: "https://app.example.com",
+			wantOrigin: "https://app.example.com", wantStatus: http.StatusOK},
+		{name: "запрос чужого источника", method: http.MethodGet, origin: "https://evil.example.com",
+			wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, "/tasks", nil)
+			req.Header.Set(echo.HeaderOrigin, tt.origin)
+			if tt.method == http.MethodOptions {
+				req.Header.Set(echo.HeaderAccessControlRequestMethod, http.MethodPatch)
+				req.Header.Set(echo.HeaderAccessControlRequestHeaders, "If-Match, Idempotency-Key")
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, tt.wantOrigin, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+			if tt.wantPreflight {
+				assert.Contains(t, rec.Header().Get(echo.HeaderAccessControlAllowMethods), http.MethodPatch)
+				assert.Contains(t, rec.Header().Get(echo.HeaderAccessControlAllowHeaders), "If-Match")
+				assert.Equal(t, "600", rec.Header().Get(echo.HeaderAccessControlMaxAge))
+			}
+			if tt.method == http.MethodGet && tt.wantOrigin != "" {
+				exposed := rec.Header().Get(echo.HeaderAccessControlExposeHeaders)
+				for _, header := range []string{"ETag", "X-Request-Id", "Retry-After", "RateLimit-Remaining"} {
+					assert.Contains(t, exposed, header)
+				}
+			}
+		})
+	}
+
+	t.Run("без источников CORS выключен", func(t *testing.T) {
+		e := newTestServer(t, func(cfg *config.Config) { cfg.CORSAllowOrigins = nil })
+		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		req.Header.Set(echo.HeaderOrigin, "https://app.example.com")
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		assert.Empty(t, rec.Header().Get(echo.HeaderAccessControlAllowOrigin))
+	})
+}
+
+func TestServerSecurityHeaders(t *testing.T) {
+	e := newTestServer(t, nil)
+
+	tests := []struct {
+		name     string
+		path     string
+		https    bool
+		wantHSTS string
+	}{
+		{name: "HTTP", path: "/tasks"},
+		{name: "HTTPS", path: "/tasks", https: true, wantHSTS: "max-age=31536000; includeSubdomains"},
+		{name: "ответ об ошибке", path: "/tasks/not-a-uuid"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.https {
+				req.TLS = &tls.ConnectionState{}
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, "nosniff", rec.Header().Get(echo.HeaderXContentTypeOptions))
+			assert.Equal(t, "DENY", rec.Header().Get(echo.HeaderXFrameOptions))
+			assert.Equal(t, "no-referrer", rec.Header().Get(echo.HeaderReferrerPolicy))
+			assert.Equal(t, tt.wantHSTS, rec.Header().Get(echo.HeaderStrictTransportSecurity))
+		})
+	}
+}
+
+func TestServerBodyLimit(t *testing.T) {
+	e := newTestServer(t, func(cfg *config.Config) { cfg.BodyLimit = "1K" })
+
+	tests := []struct {
+		name       string
+		body       string
+		wantStatus int
+	}{
+		{name: "в пределах лимита", body: `{"email":"alice@example.com","password":"correct-horse-42"}`, wantStatus: http.StatusCreated},
+		{name: "больше лимита", body: `{"email":"bob@example.com","password":"` + strings.Repeat("a", 2048) + `"}`,
+			wantStatus: http.StatusRequestEntityTooLarge},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantStatus == http.StatusRequestEntityTooLarge {
+				assert.Contains(t, rec.Body.String(), `"request_id"`)
+			}
+		})
+	}
+}
+
+func TestServerRateLimitPerIP(t *testing.T) {
+	e := newTestServer(t, func(cfg *config.Config) { cfg.RateLimitRead = "1/1m" })
+	get := func(remoteAddr, token string) int {
+		req := httptest.NewRequest(http.MethodGet, "/tasks", nil)
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusOK, get("203.0.113.7:40000", "alice-token"))
+	assert.Equal(t, http.StatusTooManyRequests, get("203.0.113.7:40001", "bob-token"), "токен не дает новую корзину")
+	assert.Equal(t, http.StatusOK, get("198.51.100.1:40000", "alice-token"))
+}
+
+func TestServerTimeouts(t *testing.T) {
+	e := newTestServer(t, func(cfg *config.Config) {
+		cfg.ReadTimeout = time.Second
+		cfg.ReadHeaderTimeout = 2 * time.Second
+		cfg.WriteTimeout = 3 * time.Second
+		cfg.IdleTimeout = 4 * time.Second
+	})
+
+	assert.Equal(t, time.Second, e.Server.ReadTimeout)
+	assert.Equal(t, 2*time.Second, e.Server.ReadHeaderTimeout)
+	assert.Equal(t, 3*time.Second, e.Server.WriteTimeout)
+	assert.Equal(t, 4*time.Second, e.Server.IdleTimeout)
+}
+
+// startTestServer Запускает newServer на свободном порту так же, как serve
+func startTestServer(t *testing.T, cfg config.Config) string {
+	t.Helper()
+	e, wait, err := newServer(t.Context(), cfg)
+	require.NoError(t, err)
+	t.Cleanup(wait)
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	go func() { _ = start(e, listener) }()
+	t.Cleanup(func() { _ = e.Close() })
+	return listener.Addr().String()
+}
+
+func TestServerTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := tlstest.NewCA(t)
+	first := ca.Issue(t, "localhost")
+	certFile, keyFile := first.WriteFiles(t, dir)
+
+	cfg := config.Load()
+	cfg.Storage = config.StorageMemory
+	cfg.TLSCertFile, cfg.TLSKeyFile = certFile, keyFile
+	cfg.TLSReloadInterval = 10 * time.Millisecond
+	address := startTestServer(t, cfg)
+
+	// Новое соединение на каждый запрос, чтобы видеть текущий сертификат сервера
+	get := func(t *testing.T) *http.Response {
+		client := &http.Client{Transport: &http.Transport{
+			TLSClientConfig:   &tls.Config{RootCAs: ca.Pool()},
+			ForceAttemptHTTP2: true,
+			DisableKeepAlives: true,
+		}}
+		resp, err := client.Get("https://" + address + "/tasks")
+		require.NoError(t, err)
+		require.NoError(t, resp.Body.Close())
+		return resp
+	}
+
+	resp := get(t)
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, 2, resp.ProtoMajor, "HTTP/2 по ALPN")
+	assert.Equal(t, first.Certificate.Raw, resp.TLS.PeerCertificates[0].Raw)
+
+	// Замена файлов подхватывается без перезапуска
+	second := ca.Issue(t, "localhost")
+	second.WriteFiles(t, dir)
+	later := time.Now().Add(time.Minute)
+	require.NoError(t, os.Chtimes(certFile, later, later))
+	require.NoError(t, os.Chtimes(keyFile, later, later))
+	assert.Eventually(t, func() bool {
+		return bytes.Equal(second.Certificate.Raw, get(t).TLS.PeerCertificates[0].Raw)
+	}, 5*time.Second, 20*time.Millisecond)
+}
+
+func TestServerH2C(t *testing.T) {
+	tests := []struct {
+		name    string
+		h2c     bool
+		wantErr bool
+	}{
+		{name: "включен", h2c: true},
+		{name: "выключен", h2c: false, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Load()
+			cfg.Storage = config.StorageMemory
+			cfg.H2C = tt.h2c
+			address := startTestServer(t, cfg)
+
+			// Клиент, который говорит только HTTP/2 без TLS (prior knowledge)
+			var protocols http.Protocols
+			protocols.SetUnencryptedHTTP2(true)
+			client := &http.Client{Transport: &http.Transport{Protocols: &protocols}}
+			defer client.CloseIdleConnections()
+
+			resp, err := client.Get("http://" + address + "/tasks")
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			require.NoError(t, resp.Body.Close())
+			assert.Equal(t, http.StatusOK, resp.StatusCode)
+			assert.Equal(t, 2, resp.ProtoMajor)
+		})
+	}
+}
+
+func TestServerInvalidSettings(t *testing.T) {
+	tests := []struct {
+		name   string
+		change func(cfg *config.Config)
+	}{
+		{name: "размер тела", change: func(cfg *config.Config) { cfg.BodyLimit = "a lot" }},
+		{name: "лимит частоты", change: func(cfg *config.Config) { cfg.RateLimitWrite = "60 per minute" }},
+		{name: "хранилище", change: func(cfg *config.Config) { cfg.Storage = "redis" }},
+		{name: "сертификат без ключа", change: func(cfg *config.Config) { cfg.TLSCertFile = "cert.pem" }},
+		{name: "сервисы mTLS", change: func(cfg *config.Config) { cfg.TLSClientIdentities = []string{"billing"} }},
+		{name: "транспорт почты", change: func(cfg *config.Config) { cfg.Mailer = "pigeon" }},
+		{name: "отправитель писем", change: func(cfg *config.Config) { cfg.MailFrom = "no-reply" }},
+		{name: "права до подтверждения email", change: func(cfg *config.Config) { cfg.UnverifiedPermissions = []string{"everything"} }},
+		{name: "страница подтверждения", change: func(cfg *config.Config) { cfg.EmailVerifyURL = "/verify-email" }},
+		{name: "классы символов пароля", change: func(cfg *config.Config) { cfg.PasswordRequire = []string{"emoji"} }},
+		{name: "длина пароля", change: func(cfg *config.Config) { cfg.PasswordMinLength, cfg.PasswordMaxLength = 16, 8 }},
+		{name: "список утекших паролей", change: func(cfg *config.Config) { cfg.PasswordBreachedList = "/nonexistent/pwned.txt" }},
+		{name: "страница сброса пароля", change: func(cfg *config.Config) { cfg.PasswordResetURL = "reset-password" }},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Load()
+			cfg.Storage = config.StorageMemory
+			tt.change(&cfg)
+			_, _, err := newServer(t.Context(), cfg)
+			var usage usageError
+			assert.True(t, errors.As(err, &usage), "ошибка в настройках - ошибка использования: %v", err)
+		})
+	}
+}
+
+func TestServerEmailVerification(t *testing.T) {
+	mailDir := t.TempDir()
+	e, wait := newTestServerWait(t, func(cfg *config.Config) {
+		cfg.Mailer = mailer.TransportFile
+		cfg.MailDir = mailDir
+		cfg.EmailVerifyURL = "https://app.example.com/verify-email"
+		cfg.UnverifiedPermissions = nil
+	})
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/users", `{"email":"alice@example.com","password":"correct-horse-42"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var user struct {
+		ID              string     `json:"id"`
+		EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &user))
+	assert.Nil(t, user.EmailVerifiedAt)
+
+	// До подтверждения задачи пользователю не назначаются
+	task := `{"name":"Buy milk","user_id":"` + user.ID + `"}`
+	rec = do(http.MethodPost, "/tasks", task)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"request_id"`)
+
+	// Письмо уходит в фоне и лежит в каталоге, ссылка ведет на страницу подтверждения
+	wait()
+	tokens := mailTokens(t, mailDir, "https://app.example.com/verify-email")
+	require.Len(t, tokens, 1)
+	token := tokens[0]
+
+	rec = do(http.MethodPost, "/auth/verify-email", `{"token":"`+token+`x"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	rec = do(http.MethodPost, "/auth/verify-email", `{"token":"`+token+`"}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+	rec = do(http.MethodGet, "/users/"+user.ID, "")
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &user))
+	assert.NotNil(t, user.EmailVerifiedAt)
+	rec = do(http.MethodPost, "/tasks", task)
+	assert.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	// Повторная отправка отвечает одинаково для любых адресов
+	for _, email := range []string{"alice@example.com", "nobody@example.com"} {
+		rec = do(http.MethodPost, "/auth/verify-email/resend", `{"email":"`+email+`"}`)
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	}
+}
+
+func TestServerPasswordReset(t *testing.T) {
+	mailDir := t.TempDir()
+	e, wait := newTestServerWait(t, func(cfg *config.Config) {
+		cfg.Mailer = mailer.TransportFile
+		cfg.MailDir = mailDir
+		cfg.PasswordResetURL = "https://app.example.com/reset-password"
+	})
+	do := func(method, path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/users", `{"email":"alice@example.com","password":"correct-horse-42"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	// Ответ одинаков для любых адресов, письмо уходит только существующему пользователю
+	for _, email := range []string{"alice@example.com", "alice@example.com", "nobody@example.com"} {
+		rec = do(http.MethodPost, "/auth/password/forgot", `{"email":"`+email+`"}`)
+		assert.Equal(t, http.StatusAccepted, rec.Code)
+		assert.Empty(t, rec.Body.String())
+	}
+	wait() // Письма уходят в фоне
+	tokens := mailTokens(t, mailDir, "https://app.example.com/reset-password")
+	require.Len(t, tokens, 2)
+
+	rec = do(http.MethodPost, "/auth/password/reset", `{"token":"forged","password":"n3w-secret"}`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"request_id"`)
+	rec = do(http.MethodPost, "/auth/password/reset", `{"token":"`+tokens[0]+`","password":"n3w-secret"}`)
+	assert.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+	rec = do(http.MethodPost, "/auth/verify-credentials", `{"email":"alice@example.com","password":"n3w-secret"}`)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// Использованный и все остальные токены больше не действуют
+	for _, token := range tokens {
+		rec = do(http.MethodPost, "/auth/password/reset", `{"token":"`+token+`","password":"other"}`)
+		assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	}
+}
+
+func TestServerUserPasswordHidden(t *testing.T) {
+	e := newTestServer(t, nil)
+	do := func(method, path, contentType, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, contentType)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	verify := func(password string) int {
+		return do(http.MethodPost, "/auth/verify-credentials", echo.MIMEApplicationJSON,
+			`{"email":"alice@example.com","password":"`+password+`"}`).Code
+	}
+
+	rec := do(http.MethodPost, "/users", echo.MIMEApplicationJSON, `{"email":"alice@example.com","password":"correct-horse-42"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), "password")
+	var user struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &user))
+
+	for _, path := range []string{"/users", "/users/" + user.ID} {
+		rec = do(http.MethodGet, path, echo.MIMEApplicationJSON, "")
+		require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+		assert.NotContains(t, rec.Body.String(), "password", path)
+	}
+	rec = do(http.MethodPatch, "/users/"+user.ID, echo.MIMEApplicationJSON, `{"password":"n3w-passphrase"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), "password")
+
+	// JSON Patch не видит текущий пароль: его нельзя проверить операцией test
+	rec = do(http.MethodPatch, "/users/"+user.ID, "application/json-patch+json",
+		`[{"op":"test","path":"/password","value":"n3w-passphrase"},{"op":"add","path":"/email","value":"mallory@example.com"}]`)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code, rec.Body.String())
+	rec = do(http.MethodPatch, "/users/"+user.ID, "application/json-patch+json",
+		`[{"op":"add","path":"/password","value":"other-passphrase"}]`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.NotContains(t, rec.Body.String(), "password")
+	assert.Equal(t, http.StatusOK, verify("other-passphrase"))
+	assert.Equal(t, http.StatusUnauthorized, verify("n3w-passphrase"))
+}
+
+func TestServerUserPreconditions(t *testing.T) {
+	e := newTestServer(t, func(cfg *config.Config) { cfg.RequireIfMatch = true })
+	do := func(method, path, ifMatch, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		if ifMatch != "" {
+			req.Header.Set("If-Match", ifMatch)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := do(http.MethodPost, "/users", "", `{"email":"alice@example.com","password":"correct-horse-42"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var user struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &user))
+
+	// Любой сильный ETag из списка может совпасть с текущей версией
+	rec = do(http.MethodPatch, "/users/"+user.ID, `"5", "6"`, `{"email":"alice@example.org"}`)
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	rec = do(http.MethodPatch, "/users/"+user.ID, `"5", W/"2", "1"`, `{"email":"alice@example.org"}`)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.Equal(t, `"2"`, rec.Header().Get("ETag"))
+	rec = do(http.MethodDelete, "/users/"+user.ID, `"1", "3"`, "")
+	assert.Equal(t, http.StatusPreconditionFailed, rec.Code)
+	rec = do(http.MethodDelete, "/users/"+user.ID, `"1", "2"`, "")
+	assert.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+
+	// Удаленного пользователя нет: 404, а не 500
+	for _, ifMatch := range []string{"*", `"2"`, `"1", "2"`} {
+		rec = do(http.MethodPatch, "/users/"+user.ID, ifMatch, `{"email":"alice@example.net"}`)
+		assert.Equal(t, http.StatusNotFound, rec.Code, ifMatch)
+		rec = do(http.MethodDelete, "/users/"+user.ID, ifMatch, "")
+		assert.Equal(t, http.StatusNotFound, rec.Code, ifMatch)
+	}
+}
+
+func TestServerMailError(t *testing.T) {
+	e, wait := newTestServerWait(t, func(cfg *config.Config) {
+		cfg.Mailer = mailer.TransportSMTP
+		cfg.SMTPAddr = "127.0.0.1:1" // Соединение отклоняется
+	})
+	post := func(path, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	// Письмо подтверждения тоже не уходит, но регистрация от этого не зависит
+	rec := post("/users", `{"email":"alice@example.com","password":"correct-horse-42"}`)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+
+	// Ошибка отправки не отличает существующий адрес от несуществующего
+	for _, path := range []string{"/auth/password/forgot", "/auth/verify-email/resend"} {
+		known := post(path, `{"email":"alice@example.com"}`)
+		unknown := post(path, `{"email":"nobody@example.com"}`)
+		wait()
+		assert.Equal(t, http.StatusAccepted, known.Code, path)
+		assert.Equal(t, unknown.Code, known.Code, path)
+		assert.Equal(t, unknown.Body.String(), known.Body.String(), path)
+	}
+}
+
+// mailTokens Токены из ссылок на страницу page во всех письмах каталога dir
+func mailTokens(t *testing.T, dir, page string) []string {
+	t.Helper()
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	pattern := regexp.MustCompile(regexp.QuoteMeta(page+"?token=") + `\S+`)
+
+	var tokens []string
+	for _, file := range files {
+		data, err := os.ReadFile(file)
+		require.NoError(t, err)
+		msg, err := mail.ReadMessage(bytes.NewReader(data))
+		require.NoError(t, err)
+		body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+		require.NoError(t, err)
+		if link := pattern.Find(body); link != nil {
+			u, err := url.Parse(string(link))
+			require.NoError(t, err)
+			tokens = append(tokens, u.Query().Get("token"))
+		}
+	}
+	return tokens
+}
+
+func TestServerPasswordPolicy(t *testing.T) {
+	e := newTestServer(t, func(cfg *config.Config) {
+		cfg.PasswordRequire = []string{"digit"}
+	})
+	post := func(body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/users", strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	tests := []struct {
+		name       string
+		password   string
+		wantStatus int
+		wantRules  []string
+	}{
+		{name: "надежный пароль", password: "correct-horse-42", wantStatus: http.StatusCreated},
+		{name: "каждое нарушение отдельно", password: "alice", wantStatus: http.StatusUnprocessableEntity,
+			wantRules: []string{"min_length", "digit", "no_email"}},
+		{name: "утекший пароль", password: "password123", wantStatus: http.StatusUnprocessableEntity,
+			wantRules: []string{"not_breached"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := post(`{"email":"alice@example.com","password":"` + tt.password + `"}`)
+			require.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantRules == nil {
+				return
+			}
+			var body struct {
+				RequestID  string `json:"request_id"`
+				Violations []struct {
+					Rule    string `json:"rule"`
+					Message string `json:"message"`
+				} `json:"violations"`
+			}
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.NotEmpty(t, body.RequestID)
+			rules := make([]string, len(body.Violations))
+			for i, violation := range body.Violations {
+				rules[i] = violation.Rule
+				assert.NotEmpty(t, violation.Message)
+			}
+			assert.Equal(t, tt.wantRules, rules)
+		})
+	}
+}
+
+func TestServerTOTP(t *testing.T) {
+	mailDir := t.TempDir()
+	e, wait := newTestServerWait(t, func(cfg *config.Config) {
+		cfg.Mailer = mailer.TransportFile
+		cfg.MailDir = mailDir
+		cfg.PasswordResetURL = "https://app.example.com/reset-password"
+	})
+	do := func(method, path, body string, header http.Header) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		for name, values := range header {
+			req.Header[name] = values
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+	withCode := func(code string) http.Header { return http.Header{"X-Totp-Code": {code}} }
+	credentials := `{"email":"alice@example.com","password":"correct-horse-42"}`
+
+	rec := do(http.MethodPost, "/users", credentials, nil)
+	require.Equal(t, http.StatusCreated, rec.Code, rec.Body.String())
+	var user struct {
+		ID string `json:"id"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &user))
+
+	// Без TOTP пароля достаточно
+	rec = do(http.MethodPost, "/auth/verify-credentials", credentials, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"status":"verified","user_id":"`+user.ID+`"}`, rec.Body.String())
+	rec = do(http.MethodPost, "/auth/verify-credentials", `{"email":"alice@example.com","password":"wrong"}`, nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	rec = do(http.MethodPost, "/auth/totp/enroll", credentials, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var enrollment struct {
+		Secret string `json:"secret"`
+		URI    string `json:"otpauth_uri"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &enrollment))
+	assert.True(t, strings.HasPrefix(enrollment.URI, "otpauth://totp/Tasks:alice@example.com?"), enrollment.URI)
+
+	rec = do(http.MethodPost, "/auth/totp/confirm",
+		`{"email":"alice@example.com","password":"correct-horse-42","code":"000000"}`, nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+	current, err := totp.GenerateCode(enrollment.Secret, time.Now())
+	require.NoError(t, err)
+	rec = do(http.MethodPost, "/auth/totp/confirm",
+		`{"email":"alice@example.com","password":"correct-horse-42","code":"`+current+`"}`, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var recovery struct {
+		Codes []string `json:"recovery_codes"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &recovery))
+	require.NotEmpty(t, recovery.Codes)
+	rec = do(http.MethodPost, "/auth/totp/enroll", credentials, nil)
+	assert.Equal(t, http.StatusConflict, rec.Code)
+
+	// Второй шаг: вызов и код восстановления
+	rec = do(http.MethodPost, "/auth/verify-credentials", credentials, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	var verification struct {
+		Status    string `json:"status"`
+		Challenge string `json:"challenge"`
+	}
+	require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &verification))
+	assert.Equal(t, "totp_required", verification.Status)
+	require.NotEmpty(t, verification.Challenge)
+
+	second := `{"challenge":"` + verification.Challenge + `","code":"` + recovery.Codes[0] + `"}`
+	rec = do(http.MethodPost, "/auth/verify-credentials", second, nil)
+	require.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+	assert.JSONEq(t, `{"status":"verified","user_id":"`+user.ID+`"}`, rec.Body.String())
+	rec = do(http.MethodPost, "/auth/verify-credentials", second, nil)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	rec = do(http.MethodPost, "/auth/verify-credentials", `{"email":"alice@example.com","code":"123456"}`, nil)
+	assert.Equal(t, http.StatusUnprocessableEntity, rec.Code)
+
+	// Смена пароля и удаление требуют свежий код
+	changePassword := `{"password":"n3w-passphrase"}`
+	rec = do(http.MethodPatch, "/users/"+user.ID, changePassword, nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"request_id"`)
+	rec = do(http.MethodPatch, "/users/"+user.ID, changePassword, withCode(current))
+	assert.Equal(t, http.StatusForbidden, rec.Code, "код подтверждения уже использован")
+	rec = do(http.MethodPatch, "/users/"+user.ID, `{"email":"mallory@example.com"}`, nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code, "смена email тоже требует код")
+
+	next, err := totp.GenerateCode(enrollment.Secret, time.Now().Add(30*time.Second))
+	require.NoError(t, err)
+	rec = do(http.MethodPatch, "/users/"+user.ID, changePassword, withCode(next))
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	// Сброс пароля по письму требует код TOTP или код восстановления
+	rec = do(http.MethodPost, "/auth/password/forgot", `{"email":"alice@example.com"}`, nil)
+	require.Equal(t, http.StatusAccepted, rec.Code)
+	wait()
+	tokens := mailTokens(t, mailDir, "https://app.example.com/reset-password")
+	require.Len(t, tokens, 1)
+	rec = do(http.MethodPost, "/auth/password/reset", `{"token":"`+tokens[0]+`","password":"reset-passphrase"}`, nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	assert.Contains(t, rec.Body.String(), `"request_id"`)
+	rec = do(http.MethodPost, "/auth/password/reset",
+		`{"token":"`+tokens[0]+`","password":"reset-passphrase","totp_code":"`+recovery.Codes[1]+`"}`, nil)
+	assert.Equal(t, http.StatusNoContent, rec.Code, rec.Body.String())
+	rec = do(http.MethodPost, "/auth/verify-credentials", `{"email":"alice@example.com","password":"reset-passphrase"}`, nil)
+	assert.Equal(t, http.StatusOK, rec.Code, rec.Body.String())
+
+	rec = do(http.MethodDelete, "/users/"+user.ID, "", nil)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+	rec = do(http.MethodDelete, "/users/"+user.ID, "", withCode(next))
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
+
+func TestServerDocsMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := tlstest.NewCA(t)
+	certFile, keyFile := ca.Issue(t, "localhost").WriteFiles(t, dir)
+	caFile := ca.WriteFile(t, dir)
+
+	tests := []struct {
+		name         string
+		change       func(cfg *config.Config)
+		wantServices []string // nil - расширения нет
+	}{
+		{name: "без mTLS"},
+		{
+			name: "mTLS с сервисами",
+			change: func(cfg *config.Config) {
+				cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile = certFile, keyFile, caFile
+				cfg.TLSClientIdentities = []string{"billing.internal=billing", "reports.internal=reports", "reports-2.internal=reports"}
+			},
+			wantServices: []string{"billing", "reports"},
+		},
+		{
+			name: "mTLS без сопоставления сервисов",
+			change: func(cfg *config.Config) {
+				cfg.TLSCertFile, cfg.TLSKeyFile, cfg.TLSClientCAFile = certFile, keyFile, caFile
+			},
+			wantServices: []string{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newTestServer(t, tt.change)
+			for _, path := range []string{"/openapi.json", "/openapi.yaml"} {
+				rec := httptest.NewRecorder()
+				e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+				require.Equal(t, http.StatusOK, rec.Code)
+
+				// Отдаваемая спецификация остается корректной для OpenAPI 3.0
+				doc, err := openapi3.NewLoader().LoadFromData(rec.Body.Bytes())
+				require.NoError(t, err, path)
+				require.NoError(t, doc.Validate(t.Context()), path)
+				assert.Empty(t, doc.Components.SecuritySchemes, path)
+
+				if tt.wantServices == nil {
+					assert.NotContains(t, doc.Extensions, "x-mutual-tls", path)
+					continue
+				}
+				raw, err := json.Marshal(doc.Extensions["x-mutual-tls"])
+				require.NoError(t, err)
+				var mutualTLS struct {
+					Description string   `json:"description"`
+					Services    []string `json:"services"`
+				}
+				require.NoError(t, json.Unmarshal(raw, &mutualTLS), path)
+				assert.Contains(t, mutualTLS.Description, "TLS_CLIENT_CA_FILE", path)
+				assert.Equal(t, tt.wantServices, mutualTLS.Services, path)
+			}
+		})
+	}
+}
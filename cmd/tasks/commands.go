@@ -0,0 +1,238 @@
+package main
+
+import (
+	"POSTnGETtrain/pkg/client"
+	"POSTnGETtrain/pkg/patch"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// newListCommand tasks ls [--done[=false]] [--user ID]
+func newListCommand(a *app) *cobra.Command {
+	var (
+		done bool
+		user string
+	)
+	command := &cobra.Command{
+		Use:     "ls",
+		Aliases: []string{"list"},
+		Short:   "Показать задачи",
+		Example: `  tasks ls                  все задачи
+  tasks ls --done           только выполненные
+  tasks ls --done=false     только открытые
+  tasks ls --user 42 -o json`,
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			api, err := a.api()
+			if err != nil {
+				return err
+			}
+
+			var tasks []client.Task
+			if user != "" {
+				if tasks, err = api.UserTasks(cmd.Context(), user); err != nil {
+					return err
+				}
+			} else {
+				for task, err := range api.Tasks(cmd.Context()) {
+					if err != nil {
+						return err
+					}
+					tasks = append(tasks, task)
+				}
+			}
+
+			// Фильтра по статусу в API нет: отбираем на клиенте
+			if cmd.Flags().Changed("done") {
+				matching := tasks[:0]
+				for _, task := range tasks {
+					if task.IsDone == done {
+						matching = append(matching, task)
+					}
+				}
+				tasks = matching
+			}
+			return a.printTasks(cmd.OutOrStdout(), tasks)
+		},
+	}
+	command.Flags().BoolVar(&done, "done", false, "только выполненные (--done=false - только открытые)")
+	command.Flags().StringVar(&user, "user", "", "только задачи пользователя с этим ID")
+	return command
+}
+
+// newAddCommand tasks add NAME...
+func newAddCommand(a *app) *cobra.Command {
+	var (
+		done bool
+		user string
+	)
+	command := &cobra.Command{
+		Use:   "add NAME...",
+		Short: "Создать задачу",
+		Example: `  tasks add Buy milk
+  tasks add "Позвонить в банк" --user 42`,
+		Args: usageArgs(cobra.MinimumNArgs(1)),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if user == "" {
+				s, err := a.settings()
+				if err != nil {
+					return err
+				}
+				if user = s.User; user == "" {
+					return usageError{errors.New("no user: pass --user or save one with tasks login --user ID")}
+				}
+			}
+			api, err := a.api()
+			if err != nil {
+				return err
+			}
+
+			task, err := api.CreateTask(cmd.Context(), client.TaskRequest{
+				Name:   strings.Join(args, " "),
+				IsDone: &done,
+				UserID: user,
+			})
+			if err != nil {
+				return err
+			}
+			return a.printTask(cmd.OutOrStdout(), task)
+		},
+	}
+	command.Flags().BoolVar(&done, "done", false, "создать уже выполненной")
+	command.Flags().StringVar(&user, "user", "", "владелец задачи (по умолчанию из tasks login --user)")
+	return command
+}
+
+// newDoneCommand tasks done ID...
+func newDoneCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:               "done ID...",
+		Short:             "Отметить задачи выполненными",
+		Args:              usageArgs(cobra.MinimumNArgs(1)),
+		ValidArgsFunction: a.completeTasks(func(task client.Task) bool { return !task.IsDone }),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			api, err := a.api()
+			if err != nil {
+				return err
+			}
+			updated := make([]client.Task, 0, len(args))
+			for _, id := range args {
+				task, err := a.update(cmd, api, id, client.TaskMergePatch{IsDone: patch.Value(true)})
+				if err != nil {
+					return err
+				}
+				updated = append(updated, task)
+			}
+			return a.printTasks(cmd.OutOrStdout(), updated)
+		},
+	}
+}
+
+// newEditCommand tasks edit ID [--name NAME] [--done[=false]] [--user ID]
+func newEditCommand(a *app) *cobra.Command {
+	var (
+		name string
+		done bool
+		user string
+	)
+	command := &cobra.Command{
+		Use:               "edit ID",
+		Short:             "Изменить задачу",
+		Example:           `  tasks edit 3f2a --name "Buy oat milk" --done=false`,
+		Args:              usageArgs(cobra.ExactArgs(1)),
+		ValidArgsFunction: a.completeTasks(func(client.Task) bool { return true }),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var changes client.TaskMergePatch
+			flags := cmd.Flags()
+			if flags.Changed("name") {
+				changes.Name = patch.Value(name)
+			}
+			if flags.Changed("done") {
+				changes.IsDone = patch.Value(done)
+			}
+			if flags.Changed("user") {
+				changes.UserID = patch.Value(user)
+			}
+			if changes == (client.TaskMergePatch{}) {
+				return usageError{errors.New("nothing to change: pass --name, --done or --user")}
+			}
+
+			api, err := a.api()
+			if err != nil {
+				return err
+			}
+			task, err := a.update(cmd, api, args[0], changes)
+			if err != nil {
+				return err
+			}
+			return a.printTask(cmd.OutOrStdout(), task)
+		},
+	}
+	command.Flags().StringVar(&name, "name", "", "новое название")
+	command.Flags().BoolVar(&done, "done", false, "выполнена ли задача")
+	command.Flags().StringVar(&user, "user", "", "новый владелец")
+	return command
+}
+
+// newRemoveCommand tasks rm ID...
+func newRemoveCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:               "rm ID...",
+		Aliases:           []string{"delete"},
+		Short:             "Удалить задачи",
+		Args:              usageArgs(cobra.MinimumNArgs(1)),
+		ValidArgsFunction: a.completeTasks(func(client.Task) bool { return true }),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			api, err := a.api()
+			if err != nil {
+				return err
+			}
+			for _, id := range args {
+				if err := api.DeleteTask(cmd.Context(), id, ""); err != nil {
+					return fmt.Errorf("%s: %w", id, err)
+				}
+				cmd.Printf("deleted %s\n", id)
+			}
+			return nil
+		},
+	}
+}
+
+// update Читает задачу и применяет изменения с её ETag: чужая правка между чтением и записью не затирается
+func (a *app) update(cmd *cobra.Command, api *client.API, id string, changes client.TaskMergePatch) (client.Task, error) {
+	_, etag, err := api.GetTask(cmd.Context(), id)
+	if err != nil {
+		return client.Task{}, fmt.Errorf("%s: %w", id, err)
+	}
+	task, _, err := api.UpdateTask(cmd.Context(), id, etag, changes)
+	if errors.Is(err, client.ErrPreconditionFailed) {
+		return client.Task{}, fmt.Errorf("%s was changed by someone else, try again: %w", id, err)
+	}
+	if err != nil {
+		return client.Task{}, fmt.Errorf("%s: %w", id, err)
+	}
+	return task, nil
+}
+
+// completeTasks Дополнение ID задач в shell: ID с названием в подсказке
+func (a *app) completeTasks(keep func(client.Task) bool) cobra.CompletionFunc {
+	return func(cmd *cobra.Command, args []string, toComplete string) ([]cobra.Completion, cobra.ShellCompDirective) {
+		api, err := a.api()
+		if err != nil {
+			return nil, cobra.ShellCompDirectiveError
+		}
+		var completions []cobra.Completion
+		for task, err := range api.Tasks(cmd.Context()) {
+			if err != nil {
+				return nil, cobra.ShellCompDirectiveError
+			}
+			if keep(task) && strings.HasPrefix(task.ID, toComplete) {
+				completions = append(completions, cobra.CompletionWithDesc(task.ID, task.Name))
+			}
+		}
+		return completions, cobra.ShellCompDirectiveNoFileComp
+	}
+}
@@ -0,0 +1,127 @@
+// Команда tasks - клиент Tasks API для терминала: список, создание и изменение задач.
+// Адрес сервера и токен хранятся в файле настроек (tasks login), вывод - таблицей или JSON
+package main
+
+import (
+	"POSTnGETtrain/pkg/client"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"os/signal"
+	"time"
+
+	"github.com/spf13/cobra"
+)
+
+// Коды выхода (как у server)
+const (
+	exitOK    = 0
+	exitError = 1 // Команда не выполнилась
+	exitUsage = 2 // Неверные аргументы
+)
+
+// requestTimeout Предел на один HTTP-запрос
+const requestTimeout = 30 * time.Second
+
+// usageError Ошибка в аргументах команды: завершается с кодом exitUsage
+type usageError struct{ err error }
+
+func (e usageError) Error() string { return e.err.Error() }
+func (e usageError) Unwrap() error { return e.err }
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	defer stop()
+	os.Exit(run(ctx, os.Args[1:], os.Stdin, os.Stdout, os.Stderr))
+}
+
+// run Выполняет команду и возвращает код выхода
+func run(ctx context.Context, args []string, stdin io.Reader, stdout, stderr io.Writer) int {
+	root := newRootCommand(&app{})
+	root.SetArgs(args)
+	root.SetIn(stdin)
+	root.SetOut(stdout)
+	root.SetErr(stderr)
+
+	cmd, err := root.ExecuteContextC(ctx)
+	if err == nil {
+		return exitOK
+	}
+	fmt.Fprintf(stderr, "Error: %v\n", err)
+	var usage usageError
+	if errors.As(err, &usage) {
+		fmt.Fprintf(stderr, "Run '%s --help' for usage.\n", cmd.CommandPath())
+		return exitUsage
+	}
+	return exitError
+}
+
+// app Общие флаги и ленивое подключение к API
+type app struct {
+	configPath string // Файл настроек (--config), по умолчанию defaultConfigPath
+	server     string // Адрес API (--server), перекрывает TASKS_SERVER и файл настроек
+	output     string // Формат вывода: table или json
+}
+
+// api Клиент с адресом и токеном из флагов, окружения и файла настроек
+func (a *app) api() (*client.API, error) {
+	s, err := a.settings()
+	if err != nil {
+		return nil, err
+	}
+	opts := []client.Option{client.WithDoer(&http.Client{Timeout: requestTimeout})}
+	if s.Token != "" {
+		opts = append(opts, client.WithToken(s.Token))
+	}
+	return client.New(s.Server, opts...)
+}
+
+// newRootCommand Дерево команд
+func newRootCommand(a *app) *cobra.Command {
+	root := &cobra.Command{
+		Use:   "tasks",
+		Short: "Задачи из терминала",
+		Long: `Задачи из терминала поверх Tasks API.
+Адрес сервера и токен берутся из флагов, переменных TASKS_SERVER и TASKS_TOKEN
+или файла настроек, который записывает tasks login.`,
+		SilenceErrors: true, // Ошибку печатает run
+		SilenceUsage:  true, // Справка только по --help, а не после каждой ошибки
+	}
+	root.SetFlagErrorFunc(func(_ *cobra.Command, err error) error { return usageError{err} })
+
+	flags := root.PersistentFlags()
+	flags.StringVar(&a.configPath, "config", "", "файл настроек (по умолчанию "+defaultConfigPath()+", TASKS_CONFIG)")
+	flags.StringVar(&a.server, "server", "", "адрес API, например http://localhost:8080 (TASKS_SERVER)")
+	flags.StringVarP(&a.output, "output", "o", outputTable, "формат вывода: table или json")
+	_ = root.RegisterFlagCompletionFunc("output", cobra.FixedCompletions([]string{outputTable, outputJSON}, cobra.ShellCompDirectiveNoFileComp))
+	root.PersistentPreRunE = func(*cobra.Command, []string) error {
+		if a.output != outputTable && a.output != outputJSON {
+			return usageError{fmt.Errorf("unknown output %q: expected %s or %s", a.output, outputTable, outputJSON)}
+		}
+		return nil
+	}
+
+	root.AddCommand(
+		newListCommand(a),
+		newAddCommand(a),
+		newDoneCommand(a),
+		newEditCommand(a),
+		newRemoveCommand(a),
+		newLoginCommand(a),
+		newLogoutCommand(a),
+	)
+	return root
+}
+
+// usageArgs Помечает ошибки проверки позиционных аргументов как ошибки использования
+func usageArgs(validate cobra.PositionalArgs) cobra.PositionalArgs {
+	return func(cmd *cobra.Command, args []string) error {
+		if err := validate(cmd, args); err != nil {
+			return usageError{err}
+		}
+		return nil
+	}
+}
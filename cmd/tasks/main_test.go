@@ -0,0 +1,140 @@
+package main
+
+import (
+	"POSTnGETtrain/internal/authService"
+	"POSTnGETtrain/internal/handlers"
+	"POSTnGETtrain/internal/idempotency"
+	"POSTnGETtrain/internal/mailer/mailtest"
+	"POSTnGETtrain/internal/taskService"
+	"POSTnGETtrain/internal/userService"
+	"POSTnGETtrain/internal/validation"
+	"POSTnGETtrain/internal/web"
+	"POSTnGETtrain/internal/web/auth"
+	"POSTnGETtrain/internal/web/tasks"
+	"POSTnGETtrain/internal/web/users"
+	"POSTnGETtrain/openapi"
+	"POSTnGETtrain/pkg/patch"
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer Настоящие обработчики API поверх хранилища в памяти и один пользователь
+func newTestServer(t *testing.T) (*httptest.Server, string) {
+	t.Helper()
+	e := echo.New()
+	e.Binder = patch.NewBinder()
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+	validator, err := validation.Middleware(spec, validation.Config{})
+	require.NoError(t, err)
+	e.Use(validator)
+
+	taskRepo := taskService.NewMemoryTaskRepository()
+	usersSvc := userService.NewUserService(userService.NewMemoryUserRepository(taskRepo))
+	authSvc, err := authService.NewAuthService(usersSvc, authService.NewMemoryResetTokenRepository(), authService.NewMemoryTOTPRepository(), &mailtest.Outbox{}, authService.Config{})
+	require.NoError(t, err)
+	idempotent := idempotency.Wrap(e, idempotency.Middleware(idempotency.Config{Store: idempotency.NewMemoryStore(), TTL: time.Hour}))
+	web.RegisterHandlers(idempotent,
+		tasks.NewStrictHandler(handlers.NewHandler(taskService.NewTaskService(taskRepo), false), nil),
+		users.NewStrictHandler(handlers.NewUserHandler(usersSvc, false), nil),
+		auth.NewStrictHandler(handlers.NewAuthHandler(authSvc), nil))
+
+	user, err := usersSvc.CreateUser(t.Context(), "alice@example.com", "secret")
+	require.NoError(t, err)
+
+	server := httptest.NewServer(e)
+	t.Cleanup(server.Close)
+	return server, user.ID
+}
+
+// cli Запускает tasks с файлом настроек из TASKS_CONFIG
+type cli struct {
+	t      *testing.T
+	config string
+}
+
+func (c cli) run(stdin string, args ...string) (code int, stdout, stderr string) {
+	var out, errOut bytes.Buffer
+	code = run(context.Background(), args, strings.NewReader(stdin), &out, &errOut)
+	return code, out.String(), errOut.String()
+}
+
+// ok Команда должна выполниться успешно
+func (c cli) ok(args ...string) string {
+	c.t.Helper()
+	code, stdout, stderr := c.run("", args...)
+	require.Equal(c.t, exitOK, code, "tasks %s\n%s", strings.Join(args, " "), stderr)
+	return stdout
+}
+
+func TestTasksCLI(t *testing.T) {
+	server, userID := newTestServer(t)
+	c := cli{t: t, config: filepath.Join(t.TempDir(), "tasks", "config.json")}
+	t.Setenv("TASKS_CONFIG", c.config)
+
+	// Без пользователя по умолчанию add не знает владельца
+	code, _, _ := c.run("", "add", "Buy milk", "--server", server.URL)
+	assert.Equal(t, exitUsage, code)
+
+	// login проверяет пользователя и сохраняет настройки только для владельца файла
+	code, _, _ = c.run("", "login", "--server", server.URL, "--user", "missing")
+	assert.Equal(t, exitError, code)
+	code, _, stderr := c.run("s3cret-token\n", "login", "--server", server.URL, "--user", userID, "--token-stdin")
+	require.Equal(t, exitOK, code, stderr)
+	info, err := os.Stat(c.config)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+	saved, err := os.ReadFile(c.config)
+	require.NoError(t, err)
+	assert.Contains(t, string(saved), `"token": "s3cret-token"`)
+
+	var milk, bread struct{ ID string }
+	require.NoError(t, json.Unmarshal([]byte(c.ok("add", "Buy", "milk", "-o", "json")), &milk))
+	require.NoError(t, json.Unmarshal([]byte(c.ok("add", "Купить хлеб", "-o", "json")), &bread))
+
+	c.ok("done", milk.ID)
+	c.ok("edit", bread.ID, "--name", "Купить ржаной хлеб")
+
+	table := c.ok("ls")
+	assert.Contains(t, table, "ID")
+	assert.Regexp(t, milk.ID+`\s+\[x\]\s+Buy milk`, table)
+	assert.Regexp(t, bread.ID+`\s+\[ \]\s+Купить ржаной хлеб`, table)
+
+	var open []struct{ ID, Name string }
+	require.NoError(t, json.Unmarshal([]byte(c.ok("ls", "--done=false", "--user", userID, "-o", "json")), &open))
+	require.Len(t, open, 1)
+	assert.Equal(t, bread.ID, open[0].ID)
+
+	// Дополнение в shell предлагает только открытые задачи для done
+	completions := c.ok("__complete", "done", "")
+	assert.Contains(t, completions, bread.ID+"\tКупить ржаной хлеб")
+	assert.NotContains(t, completions, milk.ID)
+
+	c.ok("rm", milk.ID)
+	code, _, stderr = c.run("", "done", milk.ID)
+	assert.Equal(t, exitError, code)
+	assert.Contains(t, stderr, "404")
+
+	code, _, _ = c.run("", "edit", bread.ID)
+	assert.Equal(t, exitUsage, code, "edit без изменений")
+	code, _, _ = c.run("", "ls", "-o", "yaml")
+	assert.Equal(t, exitUsage, code)
+
+	assert.Contains(t, c.ok("completion", "bash"), "bash completion")
+
+	c.ok("logout")
+	saved, err = os.ReadFile(c.config)
+	require.NoError(t, err)
+	assert.NotContains(t, string(saved), "token")
+}
@@ -0,0 +1,50 @@
+package main
+
+import (
+	"POSTnGETtrain/pkg/client"
+	"encoding/json"
+	"fmt"
+	"io"
+	"text/tabwriter"
+)
+
+// Форматы вывода
+const (
+	outputTable = "table"
+	outputJSON  = "json"
+)
+
+// printTasks Выводит задачи таблицей или JSON-массивом
+func (a *app) printTasks(w io.Writer, tasks []client.Task) error {
+	if a.output == outputJSON {
+		if tasks == nil {
+			tasks = []client.Task{} // [] вместо null
+		}
+		return writeJSON(w, tasks)
+	}
+
+	table := tabwriter.NewWriter(w, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(table, "ID\tDONE\tNAME\tUSER")
+	for _, task := range tasks {
+		done := "[ ]"
+		if task.IsDone {
+			done = "[x]"
+		}
+		fmt.Fprintf(table, "%s\t%s\t%s\t%s\n", task.ID, done, task.Name, task.UserID)
+	}
+	return table.Flush()
+}
+
+// printTask Выводит одну задачу: строкой таблицы или JSON-объектом
+func (a *app) printTask(w io.Writer, task client.Task) error {
+	if a.output == outputJSON {
+		return writeJSON(w, task)
+	}
+	return a.printTasks(w, []client.Task{task})
+}
+
+func writeJSON(w io.Writer, v any) error {
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
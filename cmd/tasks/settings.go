@@ -0,0 +1,184 @@
+package main
+
+import (
+	"POSTnGETtrain/pkg/client"
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// defaultServer Адрес API, если он нигде не задан
+const defaultServer = "http://localhost:8080"
+
+// settings Содержимое файла настроек
+type settings struct {
+	Server string `json:"server,omitempty"`
+	Token  string `json:"token,omitempty"`
+	User   string `json:"user,omitempty"` // Владелец новых задач по умолчанию
+}
+
+// defaultConfigPath Файл настроек: TASKS_CONFIG или <каталог настроек пользователя>/tasks/config.json
+func defaultConfigPath() string {
+	if path := os.Getenv("TASKS_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "tasks.json"
+	}
+	return filepath.Join(dir, "tasks", "config.json")
+}
+
+// path Файл настроек с учетом --config
+func (a *app) path() string {
+	if a.configPath != "" {
+		return a.configPath
+	}
+	return defaultConfigPath()
+}
+
+// stored Читает файл настроек. Отсутствующий файл - пустые настройки
+func (a *app) stored() (settings, error) {
+	var s settings
+	data, err := os.ReadFile(a.path())
+	if errors.Is(err, fs.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return s, err
+	}
+	if err := json.Unmarshal(data, &s); err != nil {
+		return s, fmt.Errorf("bad config %s: %w", a.path(), err)
+	}
+	return s, nil
+}
+
+// settings Действующие настройки: флаги, затем окружение, затем файл
+func (a *app) settings() (settings, error) {
+	s, err := a.stored()
+	if err != nil {
+		return s, err
+	}
+	if server := os.Getenv("TASKS_SERVER"); server != "" {
+		s.Server = server
+	}
+	if token := os.Getenv("TASKS_TOKEN"); token != "" {
+		s.Token = token
+	}
+	if a.server != "" {
+		s.Server = a.server
+	}
+	if s.Server == "" {
+		s.Server = defaultServer
+	}
+	return s, nil
+}
+
+// save Записывает настройки. Файл доступен только владельцу: в нем токен
+func (a *app) save(s settings) error {
+	data, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(a.path()), 0o700); err != nil {
+		return err
+	}
+	return os.WriteFile(a.path(), append(data, '\n'), 0o600)
+}
+
+// newLoginCommand tasks login: проверяет доступ к API и сохраняет адрес, токен и пользователя
+func newLoginCommand(a *app) *cobra.Command {
+	var (
+		token      string
+		tokenStdin bool
+		user       string
+	)
+	command := &cobra.Command{
+		Use:   "login",
+		Short: "Сохранить адрес API, токен и пользователя по умолчанию",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if token != "" && tokenStdin {
+				return usageError{errors.New("use either --token or --token-stdin")}
+			}
+			if tokenStdin {
+				line, _ := bufio.NewReader(cmd.InOrStdin()).ReadString('\n')
+				if token = strings.TrimSpace(line); token == "" {
+					return usageError{errors.New("no token on stdin")}
+				}
+			}
+
+			s, err := a.stored()
+			if err != nil {
+				return err
+			}
+			if a.server != "" {
+				s.Server = a.server
+			}
+			if s.Server == "" {
+				s.Server = defaultServer
+			}
+			if token != "" || tokenStdin {
+				s.Token = token
+			}
+			if user != "" {
+				s.User = user
+			}
+
+			// Проверяем адрес, токен и пользователя до сохранения
+			opts := []client.Option{}
+			if s.Token != "" {
+				opts = append(opts, client.WithToken(s.Token))
+			}
+			api, err := client.New(s.Server, opts...)
+			if err != nil {
+				return err
+			}
+			if s.User != "" {
+				if _, _, err := api.GetUser(cmd.Context(), s.User); err != nil {
+					return fmt.Errorf("user %s: %w", s.User, err)
+				}
+			} else if _, err := api.ListTasks(cmd.Context(), 1, 0); err != nil {
+				return fmt.Errorf("%s: %w", s.Server, err)
+			}
+
+			if err := a.save(s); err != nil {
+				return err
+			}
+			cmd.Printf("logged in to %s, settings saved to %s\n", s.Server, a.path())
+			return nil
+		},
+	}
+	command.Flags().StringVar(&token, "token", "", "токен API (попадает в историю shell, лучше --token-stdin)")
+	command.Flags().BoolVar(&tokenStdin, "token-stdin", false, "прочитать токен из первой строки stdin")
+	command.Flags().StringVar(&user, "user", "", "ID пользователя - владельца новых задач")
+	return command
+}
+
+// newLogoutCommand tasks logout: удаляет токен из файла настроек
+func newLogoutCommand(a *app) *cobra.Command {
+	return &cobra.Command{
+		Use:   "logout",
+		Short: "Удалить сохраненный токен",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			s, err := a.stored()
+			if err != nil {
+				return err
+			}
+			s.Token = ""
+			if err := a.save(s); err != nil {
+				return err
+			}
+			cmd.Println("token removed")
+			return nil
+		},
+	}
+}
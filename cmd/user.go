@@ -0,0 +1,182 @@
+package main
+
+import (
+	"POSTnGETtrain/internal/config"
+	"POSTnGETtrain/internal/userService"
+	"bufio"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+)
+
+// errEmailRequired Команды user требуют --email
+var errEmailRequired = usageError{errors.New("--email is required")}
+
+// passwordFlags Источник пароля: флаг, первая строка stdin или сгенерированный пароль
+type passwordFlags struct {
+	password string
+	stdin    bool
+}
+
+func (p *passwordFlags) register(command *cobra.Command) {
+	command.Flags().StringVar(&p.password, "password", "", "пароль (попадает в историю shell, лучше --password-stdin)")
+	command.Flags().BoolVar(&p.stdin, "password-stdin", false, "прочитать пароль из первой строки stdin")
+}
+
+// resolve Возвращает пароль и признак того, что он сгенерирован (и его нужно показать)
+func (p *passwordFlags) resolve(command *cobra.Command) (string, bool, error) {
+	switch {
+	case p.stdin && p.password != "":
+		return "", false, usageError{errors.New("use either --password or --password-stdin")}
+	case p.stdin:
+		line, err := bufio.NewReader(command.InOrStdin()).ReadString('\n')
+		password := strings.TrimRight(line, "\r\n")
+		if password == "" {
+			return "", false, usageError{fmt.Errorf("no password on stdin: %w", err)}
+		}
+		return password, false, nil
+	case p.password != "":
+		return p.password, false, nil
+	}
+	return generatePassword(), true, nil
+}
+
+// generatePassword Случайный пароль (130 бит) с заглавными и строчными буквами, цифрой и символом,
+// чтобы проходить политику паролей с любыми классами символов
+func generatePassword() string {
+	for {
+		text := rand.Text() // Алфавит base32: A-Z и 2-7
+		if strings.ContainsAny(text, "234567") {
+			return text[:13] + "-" + strings.ToLower(text[13:])
+		}
+	}
+}
+
+// newUserCommand server user create-admin|reset-password
+func newUserCommand(cfg *config.Config) *cobra.Command {
+	command := &cobra.Command{
+		Use:   "user",
+		Short: "Администрирование пользователей",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(*cobra.Command, []string) error {
+			return usageError{errors.New("expected create-admin or reset-password")}
+		},
+	}
+	command.AddCommand(newCreateAdminCommand(cfg), newResetPasswordCommand(cfg))
+	return command
+}
+
+// newCreateAdminCommand server user create-admin --email EMAIL
+func newCreateAdminCommand(cfg *config.Config) *cobra.Command {
+	var (
+		email    string
+		password passwordFlags
+	)
+	command := &cobra.Command{
+		Use:   "create-admin --email EMAIL",
+		Short: "Создать администратора или выдать права существующему пользователю",
+		Long: `Создает пользователя с правами администратора. Если пользователь с таким email
+уже есть, ему выдаются права администратора, а пароль не меняется.
+Без --password и --password-stdin пароль генерируется и печатается один раз.`,
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if email == "" {
+				return errEmailRequired
+			}
+			users, err := openUserService(cfg)
+			if err != nil {
+				return err
+			}
+
+			existing, err := users.GetUserByEmail(cmd.Context(), email)
+			switch {
+			case err == nil:
+				user, err := users.PromoteUser(cmd.Context(), existing.ID)
+				if err != nil {
+					return err
+				}
+				cmd.Printf("promoted %s (id %s) to admin\n", user.Email, user.ID)
+				return nil
+			case !errors.Is(err, userService.ErrUserNotFound):
+				return err
+			}
+
+			secret, generated, err := password.resolve(cmd)
+			if err != nil {
+				return err
+			}
+			user, err := users.CreateAdmin(cmd.Context(), email, secret)
+			if err != nil {
+				return err
+			}
+			cmd.Printf("created admin %s (id %s)\n", user.Email, user.ID)
+			if generated {
+				cmd.Printf("password: %s\n", secret)
+			}
+			return nil
+		},
+	}
+	command.Flags().StringVar(&email, "email", "", "email пользователя (обязательно)")
+	password.register(command)
+	return command
+}
+
+// newResetPasswordCommand server user reset-password --email EMAIL
+func newResetPasswordCommand(cfg *config.Config) *cobra.Command {
+	var (
+		email    string
+		password passwordFlags
+	)
+	command := &cobra.Command{
+		Use:   "reset-password --email EMAIL",
+		Short: "Задать пользователю новый пароль",
+		Long: `Задает пользователю новый пароль.
+Без --password и --password-stdin пароль генерируется и печатается один раз.`,
+		Args: usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			if email == "" {
+				return errEmailRequired
+			}
+			secret, generated, err := password.resolve(cmd)
+			if err != nil {
+				return err
+			}
+			users, err := openUserService(cfg)
+			if err != nil {
+				return err
+			}
+
+			user, err := users.GetUserByEmail(cmd.Context(), email)
+			if err != nil {
+				return fmt.Errorf("%s: %w", email, err)
+			}
+			if _, err := users.ResetPassword(cmd.Context(), user.ID, secret); err != nil {
+				return err
+			}
+			cmd.Printf("password reset for %s (id %s)\n", user.Email, user.ID)
+			if generated {
+				cmd.Printf("password: %s\n", secret)
+			}
+			return nil
+		},
+	}
+	command.Flags().StringVar(&email, "email", "", "email пользователя (обязательно)")
+	password.register(command)
+	return command
+}
+
+// openUserService Сервис пользователей поверх БД из настроек
+func openUserService(cfg *config.Config) (userService.UserService, error) {
+	passwords, err := passwordPolicy(*cfg)
+	if err != nil {
+		return nil, usageError{err}
+	}
+	database, err := openDatabase(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return userService.NewUserService(userService.NewUserRepository(database), userService.WithPasswordPolicy(passwords)), nil
+}
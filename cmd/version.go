@@ -0,0 +1,38 @@
+package main
+
+import (
+	"runtime"
+	"runtime/debug"
+
+	"github.com/spf13/cobra"
+)
+
+// version Версия сборки, задается при сборке: -ldflags "-X main.version=v1.2.3"
+var version = "dev"
+
+// newVersionCommand server version: версия, коммит и версия Go
+func newVersionCommand() *cobra.Command {
+	return &cobra.Command{
+		Use:   "version",
+		Short: "Показать версию",
+		Args:  usageArgs(cobra.NoArgs),
+		RunE: func(cmd *cobra.Command, _ []string) error {
+			commit, modified := "unknown", false
+			if info, ok := debug.ReadBuildInfo(); ok {
+				for _, setting := range info.Settings {
+					switch setting.Key {
+					case "vcs.revision":
+						commit = setting.Value
+					case "vcs.modified":
+						modified = setting.Value == "true"
+					}
+				}
+			}
+			if modified {
+				commit += "-dirty"
+			}
+			cmd.Printf("server %s (commit %s, %s)\n", version, commit, runtime.Version())
+			return nil
+		},
+	}
+}
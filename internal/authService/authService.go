@@ -0,0 +1,399 @@
+// Package authService подтверждает email пользователей по подписанным ссылкам из писем,
+// сбрасывает пароли по одноразовым токенам, проверяет учетные данные со вторым фактором TOTP
+// и ограничивает действия пользователей с неподтвержденным email по настраиваемой политике
+package authService
+
+import (
+	"POSTnGETtrain/internal/logging"
+	"POSTnGETtrain/internal/mailer"
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/userService"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Сроки действия токенов, если они не заданы
+const (
+	DefaultTokenTTL     = 24 * time.Hour  // Подтверждение email
+	DefaultResetTTL     = time.Hour       // Сброс пароля
+	DefaultChallengeTTL = 5 * time.Minute // Ввод кода TOTP после проверки пароля
+)
+
+// Ограничение подбора кодов TOTP, если оно не задано
+const (
+	DefaultTOTPMaxAttempts = 5                // Попыток подряд без принятого кода
+	DefaultTOTPLockout     = 15 * time.Minute // Блокировка после исчерпания попыток
+)
+
+// DefaultIssuer Издатель в приложении-аутентификаторе, если он не задан
+const DefaultIssuer = "Tasks"
+
+// Глобальные ошибки сервиса
+var (
+	ErrInvalidToken      = errors.New("invalid or expired token")               // Токен подделан, испорчен, просрочен или устарел
+	ErrEmailNotVerified  = errors.New("email is not verified")                  // Действие недоступно до подтверждения email
+	ErrUnknownPermission = errors.New("auth: unknown permission")               // В политике указано неизвестное право
+	ErrInvalidLinkURL    = errors.New("auth: link URL must be an absolute URL") // Страница для ссылок из писем задана не абсолютным адресом
+
+	ErrInvalidCredentials  = errors.New("invalid email or password")                                  // Пользователя нет или пароль не совпал
+	ErrTOTPNotEnrolled     = errors.New("two-factor authentication is not enrolled")                  // Секрета TOTP нет или он не подтвержден
+	ErrTOTPAlreadyEnrolled = errors.New("two-factor authentication is already enabled")               // Подключение TOTP уже подтверждено
+	ErrTOTPRequired        = errors.New("a fresh two-factor authentication code is required")         // Действие требует кода TOTP
+	ErrInvalidTOTPCode     = errors.New("invalid or already used two-factor authentication code")     // Код не подошел или уже использован
+	ErrTOTPLocked          = fmt.Errorf("%w: too many attempts, try again later", ErrInvalidTOTPCode) // Попытки ввода кода исчерпаны
+)
+
+// AuthService Подтверждение email, сброс пароля и проверка учетных данных пользователей
+type AuthService interface {
+	// SendVerification Отправляет в фоне письмо со ссылкой подтверждения. Ошибка отправки пишется в лог:
+	// письмо можно запросить повторно
+	SendVerification(ctx context.Context, user *models.User)
+	// ResendVerification Повторно отправляет в фоне письмо на email, если такой пользователь есть и еще
+	// не подтвержден. Ответ и время ответа не зависят от того, существует ли пользователь
+	ResendVerification(ctx context.Context, email string) error
+	// VerifyEmail Подтверждает email по токену из письма
+	VerifyEmail(ctx context.Context, token string) (*models.User, error)
+
+	// ForgotPassword Отправляет в фоне письмо с одноразовым токеном сброса пароля, если пользователь
+	// с таким email существует. Ответ и время ответа не зависят от того, существует ли пользователь
+	ForgotPassword(ctx context.Context, email string) error
+	// ResetPassword Меняет пароль по токену из письма. Пользователю с TOTP нужен еще код TOTP
+	// или код восстановления; неверный код тратит токен. Все выданные пользователю токены перестают действовать
+	ResetPassword(ctx context.Context, token, password, code string) error
+
+	// EnrollTOTP Выдает новый секрет TOTP. Код при входе спрашивается после подтверждения в ConfirmTOTP
+	EnrollTOTP(ctx context.Context, email, password string) (*TOTPEnrollment, error)
+	// ConfirmTOTP Подтверждает подключение первым кодом и возвращает коды восстановления. Они показываются один раз
+	ConfirmTOTP(ctx context.Context, email, password, code string) ([]string, error)
+	// VerifyCredentials Проверяет email и пароль. Пользователю с TOTP вместо подтверждения
+	// выдается вызов, на который нужно ответить кодом в VerifyTOTP
+	VerifyCredentials(ctx context.Context, email, password string) (*Verification, error)
+	// VerifyTOTP Второй шаг проверки: код TOTP или код восстановления к вызову из VerifyCredentials
+	VerifyTOTP(ctx context.Context, challenge, code string) (*models.User, error)
+	// RequireTOTP Проверяет свежий код TOTP или код восстановления пользователя с подключенным TOTP;
+	// без TOTP ничего не проверяет
+	RequireTOTP(ctx context.Context, userID, code string) error
+
+	// Wait Дожидается фоновых отправок писем (при остановке сервера и в тестах)
+	Wait()
+}
+
+// Config Настройки подтверждения email, сброса пароля и TOTP
+type Config struct {
+	Secret          []byte           // Ключ подписи токенов подтверждения и вызовов TOTP; пусто - случайный на время жизни процесса
+	TTL             time.Duration    // Срок действия ссылки подтверждения
+	VerifyURL       string           // Страница подтверждения, к ней добавляется ?token=...; пусто - в письме только токен
+	ResetTTL        time.Duration    // Срок действия токена сброса пароля
+	ResetURL        string           // Страница сброса пароля, к ней добавляется ?token=...; пусто - в письме только токен
+	Issuer          string           // Издатель TOTP, которого показывает приложение-аутентификатор
+	ChallengeTTL    time.Duration    // Сколько ждать код TOTP после проверки пароля
+	TOTPMaxAttempts int              // Сколько неверных кодов TOTP подряд можно ввести до блокировки проверки кодов
+	TOTPLockout     time.Duration    // Срок блокировки; не короче ChallengeTTL, чтобы вызов, на котором подбирали код, успел истечь
+	Now             func() time.Time // Часы (подменяются в тестах)
+}
+
+// authService Реализация AuthService
+type authService struct {
+	users  userService.UserService
+	tokens ResetTokenRepository
+	totps  TOTPRepository
+	mailer mailer.Mailer
+	cfg    Config
+
+	background sync.WaitGroup // Фоновые отправки писем
+}
+
+// NewAuthService Конструктор сервиса. Без секрета ссылки подтверждения перестают работать после перезапуска
+func NewAuthService(users userService.UserService, tokens ResetTokenRepository, totps TOTPRepository, m mailer.Mailer,
+	cfg Config) (AuthService, error) {
+	for _, link := range []string{cfg.VerifyURL, cfg.ResetURL} {
+		if u, err := url.Parse(link); link != "" && (err != nil || !u.IsAbs()) {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidLinkURL, link)
+		}
+	}
+	if len(cfg.Secret) == 0 {
+		cfg.Secret = make([]byte, 32)
+		if _, err := rand.Read(cfg.Secret); err != nil {
+			return nil, fmt.Errorf("auth: could not generate token secret: %w", err)
+		}
+	}
+	if cfg.TTL <= 0 {
+		cfg.TTL = DefaultTokenTTL
+	}
+	if cfg.ResetTTL <= 0 {
+		cfg.ResetTTL = DefaultResetTTL
+	}
+	if cfg.Issuer == "" {
+		cfg.Issuer = DefaultIssuer
+	}
+	if cfg.ChallengeTTL <= 0 {
+		cfg.ChallengeTTL = DefaultChallengeTTL
+	}
+	if cfg.TOTPMaxAttempts <= 0 {
+		cfg.TOTPMaxAttempts = DefaultTOTPMaxAttempts
+	}
+	if cfg.TOTPLockout <= 0 {
+		cfg.TOTPLockout = DefaultTOTPLockout
+	}
+	cfg.TOTPLockout = max(cfg.TOTPLockout, cfg.ChallengeTTL)
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+	return &authService{users: users, tokens: tokens, totps: totps, mailer: m, cfg: cfg}, nil
+}
+
+// SendVerification Отправка письма подтверждения в фоне: регистрация не ждет почтовый сервер.
+// Токен выписывается сразу, в момент регистрации или смены email
+func (s *authService) SendVerification(ctx context.Context, user *models.User) {
+	message, err := s.verificationMessage(user)
+	if err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "could not send verification email",
+			slog.String("user_id", user.ID), slog.String("error", err.Error()))
+		return
+	}
+	userID := user.ID
+	s.inBackground(ctx, "could not send verification email", func(ctx context.Context) error {
+		return s.send(ctx, userID, message)
+	})
+}
+
+// verificationMessage Письмо со ссылкой подтверждения
+func (s *authService) verificationMessage(user *models.User) (mailer.Message, error) {
+	expires := s.cfg.Now().Add(s.cfg.TTL)
+	token, err := signToken(s.cfg.Secret, purposeVerifyEmail, claims{
+		Subject:   user.ID,
+		Email:     user.Email,
+		ExpiresAt: expires.Unix(),
+	})
+	if err != nil {
+		return mailer.Message{}, err
+	}
+	return verificationLetter.message(user.Email, token, link(s.cfg.VerifyURL, token), expires), nil
+}
+
+// send Отправка письма подтверждения пользователю userID
+func (s *authService) send(ctx context.Context, userID string, message mailer.Message) error {
+	if err := s.mailer.Send(ctx, message); err != nil {
+		return fmt.Errorf("user %s: %w", userID, err)
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "verification email sent", slog.String("user_id", userID))
+	return nil
+}
+
+// ResendVerification Повторная отправка письма. Поиск пользователя и отправка идут в фоне, а их ошибки
+// только пишутся в лог: иначе по времени ответа или ошибке было бы видно, что пользователь существует
+func (s *authService) ResendVerification(ctx context.Context, email string) error {
+	s.inBackground(ctx, "could not resend verification email", func(ctx context.Context) error {
+		return s.resendVerification(ctx, email)
+	})
+	return nil
+}
+
+// resendVerification Отправляет письмо подтверждения пользователю с таким email, если он еще не подтвержден
+func (s *authService) resendVerification(ctx context.Context, email string) error {
+	user, err := s.users.GetUserByEmail(ctx, email)
+	if errors.Is(err, userService.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if user.EmailVerified() {
+		return nil
+	}
+	message, err := s.verificationMessage(user)
+	if err != nil {
+		return err
+	}
+	return s.send(ctx, user.ID, message)
+}
+
+// VerifyEmail Подтверждение email по токену. Токен, выписанный на прежний email
+// или удаленного пользователя, считается недействительным
+func (s *authService) VerifyEmail(ctx context.Context, token string) (*models.User, error) {
+	c, err := parseToken(s.cfg.Secret, purposeVerifyEmail, token, s.cfg.Now())
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.users.MarkEmailVerified(ctx, c.Subject, c.Email)
+	switch {
+	case errors.Is(err, userService.ErrUserNotFound), errors.Is(err, userService.ErrEmailChanged):
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	case err != nil:
+		return nil, err
+	}
+	return user, nil
+}
+
+// ForgotPassword Выдача токена сброса пароля. Поиск пользователя, запись токена и отправка идут в фоне,
+// а их ошибки только пишутся в лог: иначе по времени ответа или ошибке было бы видно, что пользователь существует
+func (s *authService) ForgotPassword(ctx context.Context, email string) error {
+	s.inBackground(ctx, "could not send password reset email", func(ctx context.Context) error {
+		return s.sendReset(ctx, email)
+	})
+	return nil
+}
+
+// sendReset Записывает токен сброса и отправляет его пользователю с таким email, если он есть.
+// В БД сохраняется только хэш токена
+func (s *authService) sendReset(ctx context.Context, email string) error {
+	user, err := s.users.GetUserByEmail(ctx, email)
+	if errors.Is(err, userService.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	token := newResetToken()
+	now := s.cfg.Now()
+	record := &models.PasswordResetToken{
+		ID:        uuid.NewString(),
+		UserID:    user.ID,
+		Email:     user.Email,
+		TokenHash: hashToken(token),
+		CreatedAt: now,
+		ExpiresAt: now.Add(s.cfg.ResetTTL),
+	}
+	if err := s.tokens.Create(ctx, record); err != nil {
+		return err
+	}
+	if err := s.mailer.Send(ctx, resetLetter.message(user.Email, token, link(s.cfg.ResetURL, token), record.ExpiresAt)); err != nil {
+		return fmt.Errorf("user %s: %w", user.ID, err)
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "password reset email sent", slog.String("user_id", user.ID))
+	return nil
+}
+
+// inBackground Выполняет send в фоне, записывая ошибку в лог с сообщением message
+func (s *authService) inBackground(ctx context.Context, message string, send func(ctx context.Context) error) {
+	ctx = context.WithoutCancel(ctx) // Ответ уже отправлен, а логгер запроса нужен
+	s.background.Add(1)
+	go func() {
+		defer s.background.Done()
+		if err := send(ctx); err != nil {
+			logging.FromContext(ctx).WarnContext(ctx, message, slog.String("error", err.Error()))
+		}
+	}()
+}
+
+// Wait Дожидается фоновых отправок писем
+func (s *authService) Wait() {
+	s.background.Wait()
+}
+
+// ResetPassword Смена пароля по токену. Пароль проверяется по политике до того, как токен
+// потрачен: отклоненный пароль можно исправить с тем же токеном. Без кода у пользователя с TOTP
+// токен тоже не тратится, а с кодом - тратится до его проверки: на один токен приходится одна
+// попытка угадать код. Токен тратится до смены пароля, поэтому даже при ошибке смены им нельзя
+// воспользоваться повторно
+func (s *authService) ResetPassword(ctx context.Context, token, password, code string) error {
+	hash := hashToken(token)
+	record, err := s.tokens.Find(ctx, hash, s.cfg.Now())
+	if err != nil {
+		return err
+	}
+	if err := s.users.ValidatePassword(ctx, record.Email, password); err != nil {
+		return err
+	}
+	if code == "" {
+		if err := s.RequireTOTP(ctx, record.UserID, ""); err != nil {
+			return err
+		}
+	}
+	if record, err = s.tokens.Consume(ctx, hash, s.cfg.Now()); err != nil {
+		return err
+	}
+	if err := s.RequireTOTP(ctx, record.UserID, code); err != nil {
+		return err
+	}
+
+	// Токен, выданный на прежний email или удаленному пользователю, не действует
+	user, err := s.users.GetUserByID(ctx, record.UserID)
+	if errors.Is(err, userService.ErrUserNotFound) {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	if err != nil {
+		return err
+	}
+	if user.Email != record.Email {
+		return fmt.Errorf("%w: %v", ErrInvalidToken, userService.ErrEmailChanged)
+	}
+	_, err = s.users.ResetPassword(ctx, user.ID, password)
+	return err
+}
+
+// newResetToken Случайный токен сброса пароля (256 бит)
+func newResetToken() string {
+	token := make([]byte, 32)
+	_, _ = rand.Read(token)
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+// hashToken Хэш токена для хранения и поиска в БД. Соль не нужна: токен случайный и длинный
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
+
+// link Ссылка на страницу page с токеном; пусто, если страница не задана
+func link(page, token string) string {
+	if page == "" {
+		return ""
+	}
+	u, _ := url.Parse(page) // Проверен в конструкторе
+	query := u.Query()
+	query.Set("token", token)
+	u.RawQuery = query.Encode()
+	return u.String()
+}
+
+// letter Шаблон письма с одноразовой ссылкой
+type letter struct {
+	subject   string
+	intro     string // Первый абзац
+	operation string // Операция API, которой отправляется токен, если ссылки нет
+	outro     string // Что делать, если письмо пришло по ошибке
+}
+
+// Письма сервиса
+var (
+	verificationLetter = letter{
+		subject:   "Confirm your email address",
+		intro:     "Please confirm your email address.",
+		operation: "POST /auth/verify-email",
+		outro:     "If you did not sign up, ignore this email.",
+	}
+	resetLetter = letter{
+		subject:   "Reset your password",
+		intro:     "We received a request to reset your password.",
+		operation: "POST /auth/password/reset",
+		outro:     "If you did not request it, ignore this email: your password stays the same.",
+	}
+)
+
+// message Письмо со ссылкой, а без ссылки - с токеном для API
+func (l letter) message(to, token, link string, expires time.Time) mailer.Message {
+	var body strings.Builder
+	body.WriteString(l.intro + "\n\n")
+	if link != "" {
+		body.WriteString("Open this link:\n\n" + link + "\n\n")
+	} else {
+		body.WriteString("Send this token to " + l.operation + ":\n\n" + token + "\n\n")
+	}
+	body.WriteString("It is valid until " + expires.UTC().Format("2006-01-02 15:04 MST") + ".\n")
+	body.WriteString(l.outro + "\n")
+	return mailer.Message{To: to, Subject: l.subject, Body: body.String()}
+}
@@ -0,0 +1,628 @@
+package authService
+
+import (
+	"POSTnGETtrain/internal/mailer"
+	"POSTnGETtrain/internal/mailer/mailtest"
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/password"
+	"POSTnGETtrain/internal/taskService"
+	"POSTnGETtrain/internal/userService"
+	"POSTnGETtrain/pkg/patch"
+	"context"
+	"errors"
+	"net/url"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fakeClock Часы, которые двигает тест
+type fakeClock struct{ now time.Time }
+
+func (c *fakeClock) Now() time.Time { return c.now }
+
+// env Сервисы поверх хранилища в памяти
+type env struct {
+	users  userService.UserService // С отправкой писем подтверждения
+	tasks  taskService.TaskService
+	auth   AuthService
+	tokens ResetTokenRepository
+	totps  TOTPRepository
+	outbox *mailtest.Outbox
+	clock  *fakeClock
+}
+
+func newEnv(t *testing.T, verifyURL string) env {
+	t.Helper()
+	taskRepo := taskService.NewMemoryTaskRepository()
+	users := userService.NewUserService(userService.NewMemoryUserRepository(taskRepo))
+	outbox := &mailtest.Outbox{}
+	clock := &fakeClock{now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+	tokens := NewMemoryResetTokenRepository()
+	totps := NewMemoryTOTPRepository()
+	auth, err := NewAuthService(users, tokens, totps, outbox, Config{Secret: []byte("test-secret"), VerifyURL: verifyURL, Now: clock.Now})
+	require.NoError(t, err)
+	return env{
+		users:  settledUserService{UserService: NewVerifyingUserService(users, auth), auth: auth},
+		tasks:  taskService.NewTaskService(taskRepo),
+		auth:   auth,
+		tokens: tokens,
+		totps:  totps,
+		outbox: outbox,
+		clock:  clock,
+	}
+}
+
+// settledUserService Дожидается писем подтверждения после регистрации и смены email,
+// чтобы порядок писем в тестах не зависел от планировщика
+type settledUserService struct {
+	userService.UserService
+	auth AuthService
+}
+
+func (s settledUserService) CreateUser(ctx context.Context, email, password string) (*models.User, error) {
+	defer s.auth.Wait()
+	return s.UserService.CreateUser(ctx, email, password)
+}
+
+func (s settledUserService) UpdateUser(ctx context.Context, id string, version *int64, changes models.UserChanges) (*models.User, error) {
+	defer s.auth.Wait()
+	return s.UserService.UpdateUser(ctx, id, version, changes)
+}
+
+// sent Письма, отправленные к этому моменту, включая фоновые
+func (e env) sent() []mailer.Message {
+	e.auth.Wait()
+	return e.outbox.Messages()
+}
+
+// lastToken Токен из последнего отправленного письма
+func (e env) lastToken(t *testing.T) string {
+	t.Helper()
+	e.auth.Wait()
+	return lastToken(t, e.outbox)
+}
+
+// lastToken Токен из последнего письма: из ссылки или отдельной строкой после инструкции
+func lastToken(t *testing.T, outbox *mailtest.Outbox) string {
+	t.Helper()
+	messages := outbox.Messages()
+	require.NotEmpty(t, messages)
+	lines := strings.Split(messages[len(messages)-1].Body, "\n")
+	for i, line := range lines {
+		if strings.HasPrefix(line, "Open this link") || strings.HasPrefix(line, "Send this token") {
+			value := lines[i+2]
+			if u, err := url.Parse(value); err == nil && u.IsAbs() {
+				return u.Query().Get("token")
+			}
+			return value
+		}
+	}
+	t.Fatalf("no token in message: %q", messages[len(messages)-1].Body)
+	return ""
+}
+
+func TestVerifyEmail(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, e env, user *models.User, token string) string // Возвращает токен для проверки
+		wantErr error
+	}{
+		{
+			name:    "токен из письма",
+			prepare: func(t *testing.T, e env, user *models.User, token string) string { return token },
+		},
+		{
+			name: "повторное подтверждение",
+			prepare: func(t *testing.T, e env, user *models.User, token string) string {
+				_, err := e.auth.VerifyEmail(t.Context(), token)
+				require.NoError(t, err)
+				return token
+			},
+		},
+		{
+			name: "почти истекший токен",
+			prepare: func(t *testing.T, e env, user *models.User, token string) string {
+				e.clock.now = e.clock.now.Add(DefaultTokenTTL - time.Second)
+				return token
+			},
+		},
+		{
+			name: "истекший токен",
+			prepare: func(t *testing.T, e env, user *models.User, token string) string {
+				e.clock.now = e.clock.now.Add(DefaultTokenTTL)
+				return token
+			},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "подделанное содержимое",
+			prepare: func(t *testing.T, e env, user *models.User, token string) string {
+				other, err := signToken([]byte("test-secret"), purposeVerifyEmail,
+					claims{Subject: user.ID, Email: user.Email, ExpiresAt: e.clock.now.Add(time.Hour).Unix()})
+				require.NoError(t, err)
+				payload, _, _ := strings.Cut(other, ".")
+				_, signature, _ := strings.Cut(token, ".")
+				return payload + "." + signature
+			},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "чужой секрет",
+			prepare: func(t *testing.T, e env, user *models.User, token string) string {
+				forged, err := signToken([]byte("other-secret"), purposeVerifyEmail,
+					claims{Subject: user.ID, Email: user.Email, ExpiresAt: e.clock.now.Add(time.Hour).Unix()})
+				require.NoError(t, err)
+				return forged
+			},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "токен другого назначения",
+			prepare: func(t *testing.T, e env, user *models.User, token string) string {
+				other, err := signToken([]byte("test-secret"), "reset_password",
+					claims{Subject: user.ID, Email: user.Email, ExpiresAt: e.clock.now.Add(time.Hour).Unix()})
+				require.NoError(t, err)
+				return other
+			},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name:    "мусор",
+			prepare: func(t *testing.T, e env, user *models.User, token string) string { return "not-a-token" },
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "email сменился после отправки",
+			prepare: func(t *testing.T, e env, user *models.User, token string) string {
+				_, err := e.users.UpdateUser(t.Context(), user.ID, nil, models.UserChanges{Email: patch.Value("alice@example.org")})
+				require.NoError(t, err)
+				return token
+			},
+			wantErr: ErrInvalidToken,
+		},
+		{
+			name: "пользователь удален",
+			prepare: func(t *testing.T, e env, user *models.User, token string) string {
+				require.NoError(t, e.users.DeleteUser(t.Context(), user.ID, nil))
+				return token
+			},
+			wantErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv(t, "")
+			user, err := e.users.CreateUser(t.Context(), "alice@example.com", "secret")
+			require.NoError(t, err)
+			require.Len(t, e.sent(), 1, "письмо при регистрации")
+			assert.Equal(t, "alice@example.com", e.sent()[0].To)
+
+			token := tt.prepare(t, e, user, e.lastToken(t))
+			verified, err := e.auth.VerifyEmail(t.Context(), token)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, verified.EmailVerified())
+
+			stored, err := e.users.GetUserByID(t.Context(), user.ID)
+			require.NoError(t, err)
+			assert.True(t, stored.EmailVerified())
+		})
+	}
+}
+
+func TestVerificationLink(t *testing.T) {
+	e := newEnv(t, "https://app.example.com/verify-email?lang=en")
+	_, err := e.users.CreateUser(t.Context(), "alice@example.com", "secret")
+	require.NoError(t, err)
+
+	body := e.sent()[0].Body
+	assert.Contains(t, body, "https://app.example.com/verify-email?lang=en&token=")
+	assert.Contains(t, body, "valid until 2026-10-20 12:00 UTC")
+
+	_, err = e.auth.VerifyEmail(t.Context(), e.lastToken(t))
+	assert.NoError(t, err)
+
+	_, err = NewAuthService(nil, e.tokens, e.totps, e.outbox, Config{VerifyURL: "/verify-email"})
+	assert.ErrorIs(t, err, ErrInvalidLinkURL)
+	_, err = NewAuthService(nil, e.tokens, e.totps, e.outbox, Config{ResetURL: "reset-password"})
+	assert.ErrorIs(t, err, ErrInvalidLinkURL)
+}
+
+func TestResendVerification(t *testing.T) {
+	tests := []struct {
+		name      string
+		email     string
+		verified  bool
+		sendErr   error
+		wantEmail bool
+	}{
+		{name: "неподтвержденный пользователь", email: "alice@example.com", wantEmail: true},
+		{name: "подтвержденный пользователь", email: "alice@example.com", verified: true},
+		{name: "неизвестный email", email: "bob@example.com"},
+		{name: "ошибка отправки не видна клиенту", email: "alice@example.com", sendErr: errors.New("smtp is down")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv(t, "")
+			user, err := e.users.CreateUser(t.Context(), "alice@example.com", "secret")
+			require.NoError(t, err)
+			if tt.verified {
+				_, err := e.auth.VerifyEmail(t.Context(), e.lastToken(t))
+				require.NoError(t, err)
+			}
+			e.outbox.Err = tt.sendErr
+
+			require.NoError(t, e.auth.ResendVerification(t.Context(), tt.email))
+			if tt.wantEmail {
+				require.Len(t, e.sent(), 2)
+				verified, err := e.auth.VerifyEmail(t.Context(), e.lastToken(t))
+				require.NoError(t, err)
+				assert.Equal(t, user.ID, verified.ID)
+				return
+			}
+			assert.Len(t, e.sent(), 1, "только письмо при регистрации")
+		})
+	}
+}
+
+func TestForgotPassword(t *testing.T) {
+	tests := []struct {
+		name      string
+		email     string
+		sendErr   error
+		wantEmail bool
+	}{
+		{name: "известный email", email: "alice@example.com", wantEmail: true},
+		{name: "неизвестный email", email: "bob@example.com"},
+		{name: "ошибка отправки не видна клиенту", email: "alice@example.com", sendErr: errors.New("smtp is down")},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv(t, "")
+			_, err := e.users.CreateUser(t.Context(), "alice@example.com", "secret")
+			require.NoError(t, err)
+			e.outbox.Err = tt.sendErr
+
+			// Ответ одинаков для любого адреса
+			require.NoError(t, e.auth.ForgotPassword(t.Context(), tt.email))
+			e.auth.Wait()
+			if !tt.wantEmail {
+				assert.Len(t, e.sent(), 1, "только письмо при регистрации")
+				return
+			}
+			messages := e.sent()
+			require.Len(t, messages, 2)
+			assert.Equal(t, "Reset your password", messages[1].Subject)
+			assert.Contains(t, messages[1].Body, "POST /auth/password/reset")
+			assert.Contains(t, messages[1].Body, "valid until 2026-10-19 13:00 UTC")
+		})
+	}
+}
+
+// blockingMailer Отправитель, который ждет, пока тест его отпустит
+type blockingMailer struct {
+	release chan struct{}
+	sent    atomic.Int32
+}
+
+func (m *blockingMailer) Send(context.Context, mailer.Message) error {
+	<-m.release
+	m.sent.Add(1)
+	return nil
+}
+
+func TestForgotPasswordDoesNotWaitForMail(t *testing.T) {
+	users := userService.NewUserService(userService.NewMemoryUserRepository(taskService.NewMemoryTaskRepository()))
+	_, err := users.CreateUser(t.Context(), "alice@example.com", "secret")
+	require.NoError(t, err)
+	m := &blockingMailer{release: make(chan struct{})}
+	auth, err := NewAuthService(users, NewMemoryResetTokenRepository(), NewMemoryTOTPRepository(), m, Config{})
+	require.NoError(t, err)
+
+	// Отправка еще не закончилась, а ответ уже есть - как и для неизвестного email
+	ctx, cancel := context.WithCancel(t.Context())
+	require.NoError(t, auth.ForgotPassword(ctx, "alice@example.com"))
+	require.NoError(t, auth.ForgotPassword(ctx, "bob@example.com"))
+	cancel() // Запрос завершен, а письмо все равно уходит
+	assert.Zero(t, m.sent.Load())
+
+	close(m.release)
+	auth.Wait()
+	assert.Equal(t, int32(1), m.sent.Load())
+}
+
+func TestVerificationDoesNotWaitForMail(t *testing.T) {
+	repo := userService.NewUserService(userService.NewMemoryUserRepository(taskService.NewMemoryTaskRepository()))
+	m := &blockingMailer{release: make(chan struct{})}
+	auth, err := NewAuthService(repo, NewMemoryResetTokenRepository(), NewMemoryTOTPRepository(), m, Config{})
+	require.NoError(t, err)
+	users := NewVerifyingUserService(repo, auth)
+
+	// Регистрация не ждет почтовый сервер, повторная отправка отвечает одинаково для любых адресов
+	ctx, cancel := context.WithCancel(t.Context())
+	_, err = users.CreateUser(ctx, "alice@example.com", "secret")
+	require.NoError(t, err)
+	require.NoError(t, auth.ResendVerification(ctx, "alice@example.com"))
+	require.NoError(t, auth.ResendVerification(ctx, "bob@example.com"))
+	cancel() // Запросы завершены, а письма все равно уходят
+	assert.Zero(t, m.sent.Load())
+
+	close(m.release)
+	auth.Wait()
+	assert.Equal(t, int32(2), m.sent.Load(), "письмо при регистрации и повторное")
+}
+
+func TestResetPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		prepare  func(t *testing.T, e env, user *models.User, token string) string // Возвращает токен для сброса
+		password string
+		wantErr  error
+	}{
+		{name: "успешный сброс", password: "n3w"},
+		{name: "пустой пароль", password: "", wantErr: userService.ErrInvalidUser},
+		{name: "чужой токен", password: "n3w", wantErr: ErrInvalidToken,
+			prepare: func(*testing.T, env, *models.User, string) string { return "forged" }},
+		{name: "токен истек", password: "n3w", wantErr: ErrInvalidToken,
+			prepare: func(_ *testing.T, e env, _ *models.User, token string) string {
+				e.clock.now = e.clock.now.Add(DefaultResetTTL)
+				return token
+			}},
+		{name: "токен уже использован", password: "n3w", wantErr: ErrInvalidToken,
+			prepare: func(t *testing.T, e env, _ *models.User, token string) string {
+				require.NoError(t, e.auth.ResetPassword(t.Context(), token, "first", ""))
+				return token
+			}},
+		{name: "email сменился после запроса", password: "n3w", wantErr: ErrInvalidToken,
+			prepare: func(t *testing.T, e env, user *models.User, token string) string {
+				_, err := e.users.UpdateUser(t.Context(), user.ID, nil, models.UserChanges{Email: patch.Value("alice@example.org")})
+				require.NoError(t, err)
+				return token
+			}},
+		{name: "пользователь удален", password: "n3w", wantErr: ErrInvalidToken,
+			prepare: func(t *testing.T, e env, user *models.User, token string) string {
+				require.NoError(t, e.users.DeleteUser(t.Context(), user.ID, nil))
+				return token
+			}},
+		{name: "сброс отзывает другие токены", password: "n3w", wantErr: ErrInvalidToken,
+			prepare: func(t *testing.T, e env, _ *models.User, token string) string {
+				require.NoError(t, e.auth.ForgotPassword(t.Context(), "alice@example.com"))
+				e.auth.Wait()
+				require.NoError(t, e.auth.ResetPassword(t.Context(), e.lastToken(t), "first", ""))
+				return token
+			}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv(t, "")
+			user, err := e.users.CreateUser(t.Context(), "alice@example.com", "secret")
+			require.NoError(t, err)
+			require.NoError(t, e.auth.ForgotPassword(t.Context(), "alice@example.com"))
+			e.auth.Wait()
+			token := e.lastToken(t)
+			if tt.prepare != nil {
+				token = tt.prepare(t, e, user, token)
+			}
+
+			err = e.auth.ResetPassword(t.Context(), token, tt.password, "")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			updated, err := e.users.GetUserByID(t.Context(), user.ID)
+			require.NoError(t, err)
+			assert.Equal(t, tt.password, updated.Password)
+		})
+	}
+}
+
+func TestResetPasswordPolicy(t *testing.T) {
+	users := userService.NewUserService(userService.NewMemoryUserRepository(taskService.NewMemoryTaskRepository()),
+		userService.WithPasswordPolicy(password.Policy{MinLength: 10, DisallowEmail: true}))
+	outbox := &mailtest.Outbox{}
+	auth, err := NewAuthService(users, NewMemoryResetTokenRepository(), NewMemoryTOTPRepository(), outbox, Config{})
+	require.NoError(t, err)
+	user, err := users.CreateUser(t.Context(), "alice@example.com", "correct-horse")
+	require.NoError(t, err)
+	require.NoError(t, auth.ForgotPassword(t.Context(), "alice@example.com"))
+	auth.Wait()
+	token := lastToken(t, outbox)
+
+	// Токен проверяется раньше пароля: без токена политику не узнать
+	err = auth.ResetPassword(t.Context(), "forged", "x", "")
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// Отклоненный пароль не тратит токен
+	err = auth.ResetPassword(t.Context(), token, "alice-2026-xyz", "")
+	assert.ErrorIs(t, err, userService.ErrInvalidUser)
+	var validationErr *password.ValidationError
+	require.ErrorAs(t, err, &validationErr)
+	assert.Equal(t, password.RuleNoEmail, validationErr.Violations[0].Rule)
+
+	require.NoError(t, auth.ResetPassword(t.Context(), token, "battery-staple", ""))
+	updated, err := users.GetUserByID(t.Context(), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "battery-staple", updated.Password)
+}
+
+func TestResetPasswordLink(t *testing.T) {
+	e := newEnv(t, "")
+	auth, err := NewAuthService(e.users, e.tokens, e.totps, e.outbox, Config{ResetURL: "https://app.example.com/reset", Now: e.clock.Now})
+	require.NoError(t, err)
+	_, err = e.users.CreateUser(t.Context(), "alice@example.com", "secret")
+	require.NoError(t, err)
+
+	require.NoError(t, auth.ForgotPassword(t.Context(), "alice@example.com"))
+	auth.Wait()
+	body := e.sent()[1].Body
+	assert.Contains(t, body, "https://app.example.com/reset?token=")
+	assert.NoError(t, auth.ResetPassword(t.Context(), e.lastToken(t), "n3w", ""))
+}
+
+func TestVerifyingUserService(t *testing.T) {
+	t.Run("ошибка отправки не отменяет регистрацию", func(t *testing.T) {
+		e := newEnv(t, "")
+		e.outbox.Err = errors.New("smtp is down")
+		user, err := e.users.CreateUser(t.Context(), "alice@example.com", "secret")
+		require.NoError(t, err)
+		assert.False(t, user.EmailVerified())
+	})
+
+	t.Run("смена email отправляет новое письмо", func(t *testing.T) {
+		e := newEnv(t, "")
+		user, err := e.users.CreateUser(t.Context(), "alice@example.com", "secret")
+		require.NoError(t, err)
+		_, err = e.auth.VerifyEmail(t.Context(), e.lastToken(t))
+		require.NoError(t, err)
+
+		_, err = e.users.UpdateUser(t.Context(), user.ID, nil, models.UserChanges{Password: patch.Value("n3w")})
+		require.NoError(t, err)
+		assert.Len(t, e.sent(), 1, "смена пароля писем не отправляет")
+
+		updated, err := e.users.UpdateUser(t.Context(), user.ID, nil, models.UserChanges{Email: patch.Value("alice@example.org")})
+		require.NoError(t, err)
+		assert.False(t, updated.EmailVerified())
+		messages := e.sent()
+		require.Len(t, messages, 2)
+		assert.Equal(t, "alice@example.org", messages[1].To)
+	})
+}
+
+func TestNewPolicy(t *testing.T) {
+	policy, err := NewPolicy([]string{"create_tasks", " update_tasks "})
+	require.NoError(t, err)
+	assert.True(t, policy.Allows(&models.User{}, PermissionCreateTasks))
+	assert.True(t, policy.Allows(&models.User{}, PermissionUpdateTasks))
+
+	_, err = NewPolicy([]string{"delete_everything"})
+	assert.ErrorIs(t, err, ErrUnknownPermission)
+}
+
+func TestPolicyTaskService(t *testing.T) {
+	tests := []struct {
+		name        string
+		permissions []string
+		verified    bool
+		action      func(t *testing.T, tasks taskService.TaskService, owner, other models.Task) error
+		wantErr     error
+	}{
+		{
+			name: "неподтвержденный не создает задачи",
+			action: func(t *testing.T, tasks taskService.TaskService, owner, other models.Task) error {
+				_, err := tasks.CreateTask(t.Context(), "Buy milk", false, owner.UserID)
+				return err
+			},
+			wantErr: ErrEmailNotVerified,
+		},
+		{
+			name:        "политика разрешает создание",
+			permissions: []string{"create_tasks"},
+			action: func(t *testing.T, tasks taskService.TaskService, owner, other models.Task) error {
+				_, err := tasks.CreateTask(t.Context(), "Buy milk", false, owner.UserID)
+				return err
+			},
+		},
+		{
+			name:     "подтвержденный создает задачи",
+			verified: true,
+			action: func(t *testing.T, tasks taskService.TaskService, owner, other models.Task) error {
+				_, err := tasks.CreateTask(t.Context(), "Buy milk", false, owner.UserID)
+				return err
+			},
+		},
+		{
+			name:        "неподтвержденный не изменяет свои задачи",
+			permissions: []string{"create_tasks"},
+			action: func(t *testing.T, tasks taskService.TaskService, owner, other models.Task) error {
+				_, err := tasks.UpdateTask(t.Context(), owner.ID, nil, models.TaskChanges{IsDone: patch.Value(true)})
+				return err
+			},
+			wantErr: ErrEmailNotVerified,
+		},
+		{
+			name:        "неподтвержденный не удаляет свои задачи",
+			permissions: []string{"create_tasks"},
+			action: func(t *testing.T, tasks taskService.TaskService, owner, other models.Task) error {
+				return tasks.DeleteTask(t.Context(), owner.ID, nil)
+			},
+			wantErr: ErrEmailNotVerified,
+		},
+		{
+			name:        "политика разрешает изменение",
+			permissions: []string{"update_tasks"},
+			action: func(t *testing.T, tasks taskService.TaskService, owner, other models.Task) error {
+				_, err := tasks.UpdateTask(t.Context(), owner.ID, nil, models.TaskChanges{IsDone: patch.Value(true)})
+				return err
+			},
+		},
+		{
+			name:        "задачу нельзя переназначить на неподтвержденного",
+			permissions: []string{"update_tasks"},
+			action: func(t *testing.T, tasks taskService.TaskService, owner, other models.Task) error {
+				_, err := tasks.UpdateTask(t.Context(), other.ID, nil, models.TaskChanges{UserID: patch.Value(owner.UserID)})
+				return err
+			},
+			wantErr: ErrEmailNotVerified,
+		},
+		{
+			name: "задачи подтвержденных пользователей не ограничены",
+			action: func(t *testing.T, tasks taskService.TaskService, owner, other models.Task) error {
+				return tasks.DeleteTask(t.Context(), other.ID, nil)
+			},
+		},
+		{
+			name: "чтение не ограничено",
+			action: func(t *testing.T, tasks taskService.TaskService, owner, other models.Task) error {
+				_, err := tasks.GetTaskByID(t.Context(), owner.ID)
+				return err
+			},
+		},
+		{
+			name: "отсутствующая задача",
+			action: func(t *testing.T, tasks taskService.TaskService, owner, other models.Task) error {
+				return tasks.DeleteTask(t.Context(), "missing", nil)
+			},
+			wantErr: taskService.ErrTaskNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv(t, "")
+			alice, err := e.users.CreateUser(t.Context(), "alice@example.com", "secret")
+			require.NoError(t, err)
+			if tt.verified {
+				_, err := e.auth.VerifyEmail(t.Context(), e.lastToken(t))
+				require.NoError(t, err)
+			}
+			admin, err := e.users.CreateAdmin(t.Context(), "root@example.com", "secret")
+			require.NoError(t, err)
+			owner, err := e.tasks.CreateTask(t.Context(), "Alice's task", false, alice.ID)
+			require.NoError(t, err)
+			other, err := e.tasks.CreateTask(t.Context(), "Admin's task", false, admin.ID)
+			require.NoError(t, err)
+
+			policy, err := NewPolicy(tt.permissions)
+			require.NoError(t, err)
+			err = tt.action(t, NewPolicyTaskService(e.tasks, e.users, policy), owner, other)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,152 @@
+package authService
+
+import (
+	"POSTnGETtrain/internal/logging"
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/taskService"
+	"POSTnGETtrain/internal/userService"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"slices"
+	"strings"
+)
+
+// Permission Действие, которое политика может разрешить пользователю с неподтвержденным email
+type Permission string
+
+// Права пользователей с неподтвержденным email
+const (
+	PermissionCreateTasks Permission = "create_tasks" // Получать новые задачи (создание и переназначение на пользователя)
+	PermissionUpdateTasks Permission = "update_tasks" // Изменять и удалять свои задачи
+)
+
+// Permissions Все известные права
+var Permissions = []Permission{PermissionCreateTasks, PermissionUpdateTasks}
+
+// Policy Что разрешено пользователям, пока email не подтвержден. Подтвердившим разрешено все
+type Policy struct {
+	unverified map[Permission]bool
+}
+
+// NewPolicy Разбирает список прав пользователей с неподтвержденным email
+func NewPolicy(names []string) (Policy, error) {
+	policy := Policy{unverified: make(map[Permission]bool)}
+	for _, name := range names {
+		permission := Permission(strings.TrimSpace(name))
+		if !slices.Contains(Permissions, permission) {
+			return Policy{}, fmt.Errorf("%w %q: expected one of %v", ErrUnknownPermission, name, Permissions)
+		}
+		policy.unverified[permission] = true
+	}
+	return policy, nil
+}
+
+// Allows Разрешено ли пользователю действие
+func (p Policy) Allows(user *models.User, permission Permission) bool {
+	return user.EmailVerified() || p.unverified[permission]
+}
+
+// policyTaskService Проверяет политику перед изменением задач: владелец задачи
+// с неподтвержденным email может только то, что разрешает политика
+type policyTaskService struct {
+	taskService.TaskService // Чтение задач проходит без проверок
+	users                   userService.UserService
+	policy                  Policy
+}
+
+// NewPolicyTaskService Оборачивает сервис задач проверкой политики. Запрещенное действие
+// возвращает ошибку ErrEmailNotVerified
+func NewPolicyTaskService(tasks taskService.TaskService, users userService.UserService, policy Policy) taskService.TaskService {
+	return &policyTaskService{TaskService: tasks, users: users, policy: policy}
+}
+
+func (s *policyTaskService) CreateTask(ctx context.Context, name string, isDone bool, userID string) (models.Task, error) {
+	if err := s.check(ctx, userID, PermissionCreateTasks); err != nil {
+		return models.Task{}, err
+	}
+	return s.TaskService.CreateTask(ctx, name, isDone, userID)
+}
+
+func (s *policyTaskService) UpdateTask(ctx context.Context, id string, version *int64, changes models.TaskChanges) (models.Task, error) {
+	task, err := s.TaskService.GetTaskByID(ctx, id)
+	if err != nil {
+		return models.Task{}, err
+	}
+	if err := s.check(ctx, task.UserID, PermissionUpdateTasks); err != nil {
+		return models.Task{}, err
+	}
+	// Переназначение - это новая задача для другого пользователя
+	if changes.UserID.Set && !changes.UserID.Null && changes.UserID.Value != task.UserID {
+		if err := s.check(ctx, changes.UserID.Value, PermissionCreateTasks); err != nil {
+			return models.Task{}, err
+		}
+	}
+	return s.TaskService.UpdateTask(ctx, id, version, changes)
+}
+
+func (s *policyTaskService) DeleteTask(ctx context.Context, id string, version *int64) error {
+	task, err := s.TaskService.GetTaskByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if err := s.check(ctx, task.UserID, PermissionUpdateTasks); err != nil {
+		return err
+	}
+	return s.TaskService.DeleteTask(ctx, id, version)
+}
+
+// check Разрешено ли действие пользователю userID. Неизвестного пользователя
+// пропускает: такие задачи обрабатывает сам сервис задач
+func (s *policyTaskService) check(ctx context.Context, userID string, permission Permission) error {
+	if userID == "" {
+		return nil
+	}
+	user, err := s.users.GetUserByID(ctx, userID)
+	if errors.Is(err, userService.ErrUserNotFound) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("auth: could not check policy: %w", err)
+	}
+	if !s.policy.Allows(user, permission) {
+		logging.FromContext(ctx).DebugContext(ctx, "denied by unverified email policy",
+			slog.String("user_id", userID), slog.String("permission", string(permission)))
+		return fmt.Errorf("%w: user %s needs a verified email to %s", ErrEmailNotVerified, userID,
+			strings.ReplaceAll(string(permission), "_", " "))
+	}
+	return nil
+}
+
+// verifyingUserService Отправляет письмо подтверждения новым пользователям и после смены email
+type verifyingUserService struct {
+	userService.UserService
+	auth AuthService
+}
+
+// NewVerifyingUserService Оборачивает сервис пользователей отправкой писем подтверждения.
+// Письмо уходит в фоне: регистрация не ждет почтовый сервер и не отменяется ошибкой отправки
+func NewVerifyingUserService(users userService.UserService, auth AuthService) userService.UserService {
+	return &verifyingUserService{UserService: users, auth: auth}
+}
+
+func (s *verifyingUserService) CreateUser(ctx context.Context, email, password string) (*models.User, error) {
+	user, err := s.UserService.CreateUser(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+	s.auth.SendVerification(ctx, user)
+	return user, nil
+}
+
+func (s *verifyingUserService) UpdateUser(ctx context.Context, id string, version *int64, changes models.UserChanges) (*models.User, error) {
+	user, err := s.UserService.UpdateUser(ctx, id, version, changes)
+	if err != nil {
+		return nil, err
+	}
+	if changes.Email.Set && !user.EmailVerified() {
+		s.auth.SendVerification(ctx, user)
+	}
+	return user, nil
+}
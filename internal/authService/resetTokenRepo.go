@@ -0,0 +1,81 @@
+package authService
+
+import (
+	"POSTnGETtrain/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// ResetTokenRepository Хранилище токенов сброса пароля
+type ResetTokenRepository interface {
+	// Create Сохраняет токен и удаляет истекшие токены всех пользователей
+	Create(ctx context.Context, token *models.PasswordResetToken) error
+	// Find Возвращает действующий токен с хэшем hash, не удаляя его. Если токена нет или он истек - ErrInvalidToken
+	Find(ctx context.Context, hash string, now time.Time) (*models.PasswordResetToken, error)
+	// Consume Удаляет действующий токен с хэшем hash вместе со всеми остальными токенами
+	// его пользователя и возвращает его. Если токена нет или он истек - ErrInvalidToken
+	Consume(ctx context.Context, hash string, now time.Time) (*models.PasswordResetToken, error)
+}
+
+// resetTokenRepository - реализация ResetTokenRepository с использованием GORM
+type resetTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewResetTokenRepository Конструктор хранилища токенов в БД
+func NewResetTokenRepository(db *gorm.DB) ResetTokenRepository {
+	return &resetTokenRepository{db: db}
+}
+
+func (r *resetTokenRepository) Create(ctx context.Context, token *models.PasswordResetToken) error {
+	db := r.db.WithContext(ctx)
+	if err := db.Where("expires_at <= ?", token.CreatedAt).Delete(&models.PasswordResetToken{}).Error; err != nil {
+		return fmt.Errorf("repo: could not purge expired reset tokens: %w", err)
+	}
+	if err := db.Create(token).Error; err != nil {
+		return fmt.Errorf("repo: could not save reset token: %w", err)
+	}
+	return nil
+}
+
+func (r *resetTokenRepository) Find(ctx context.Context, hash string, now time.Time) (*models.PasswordResetToken, error) {
+	var found []models.PasswordResetToken
+	if err := r.db.WithContext(ctx).Where("token_hash = ? AND expires_at > ?", hash, now).Limit(1).Find(&found).Error; err != nil {
+		return nil, fmt.Errorf("repo: could not find reset token: %w", err)
+	}
+	if len(found) == 0 {
+		return nil, ErrInvalidToken
+	}
+	return &found[0], nil
+}
+
+// Consume Удаляет токен в транзакции. Строка токена удаляется первой: из параллельных
+// запросов с одним токеном (или с разными токенами одного пользователя) успешен только один
+func (r *resetTokenRepository) Consume(ctx context.Context, hash string, now time.Time) (*models.PasswordResetToken, error) {
+	var token *models.PasswordResetToken
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var err error
+		if token, err = (&resetTokenRepository{db: tx}).Find(ctx, hash, now); err != nil {
+			return err
+		}
+
+		deleted := tx.Where("id = ?", token.ID).Delete(&models.PasswordResetToken{})
+		if deleted.Error != nil {
+			return fmt.Errorf("repo: could not delete reset token: %w", deleted.Error)
+		}
+		if deleted.RowsAffected == 0 {
+			return ErrInvalidToken // Токен уже использован параллельным запросом
+		}
+		if err := tx.Where("user_id = ?", token.UserID).Delete(&models.PasswordResetToken{}).Error; err != nil {
+			return fmt.Errorf("repo: could not delete reset tokens of user: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return token, nil
+}
@@ -0,0 +1,56 @@
+package authService
+
+import (
+	"POSTnGETtrain/internal/models"
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryResetTokenRepository Реализация ResetTokenRepository в памяти процесса
+type memoryResetTokenRepository struct {
+	mu     sync.Mutex
+	tokens map[string]models.PasswordResetToken // По хэшу токена
+}
+
+// NewMemoryResetTokenRepository Конструктор хранилища токенов в памяти (для локального запуска и тестов)
+func NewMemoryResetTokenRepository() ResetTokenRepository {
+	return &memoryResetTokenRepository{tokens: make(map[string]models.PasswordResetToken)}
+}
+
+func (r *memoryResetTokenRepository) Create(ctx context.Context, token *models.PasswordResetToken) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for hash, stored := range r.tokens {
+		if !token.CreatedAt.Before(stored.ExpiresAt) {
+			delete(r.tokens, hash)
+		}
+	}
+	r.tokens[token.TokenHash] = *token
+	return nil
+}
+
+func (r *memoryResetTokenRepository) Find(ctx context.Context, hash string, now time.Time) (*models.PasswordResetToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token, ok := r.tokens[hash]
+	if !ok || !now.Before(token.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+	return &token, nil
+}
+
+func (r *memoryResetTokenRepository) Consume(ctx context.Context, hash string, now time.Time) (*models.PasswordResetToken, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	token, ok := r.tokens[hash]
+	if !ok || !now.Before(token.ExpiresAt) {
+		return nil, ErrInvalidToken
+	}
+	for other, stored := range r.tokens {
+		if stored.UserID == token.UserID {
+			delete(r.tokens, other)
+		}
+	}
+	return &token, nil
+}
@@ -0,0 +1,91 @@
+package authService
+
+import (
+	"POSTnGETtrain/internal/db/dbtest"
+	"POSTnGETtrain/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestResetTokenRepository Один и тот же набор проверок для всех реализаций ResetTokenRepository
+func TestResetTokenRepository(t *testing.T) {
+	implementations := map[string]func(t *testing.T) ResetTokenRepository{
+		"memory": func(*testing.T) ResetTokenRepository { return NewMemoryResetTokenRepository() },
+		"gorm":   func(t *testing.T) ResetTokenRepository { return NewResetTokenRepository(dbtest.Open(t)) },
+	}
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	newToken := func(userID, hash string, createdAt time.Time) *models.PasswordResetToken {
+		return &models.PasswordResetToken{
+			ID:        uuid.NewString(),
+			UserID:    userID,
+			Email:     userID + "@example.com",
+			TokenHash: hash,
+			CreatedAt: createdAt,
+			ExpiresAt: createdAt.Add(time.Hour),
+		}
+	}
+
+	for name, newRepo := range implementations {
+		t.Run(name, func(t *testing.T) {
+			t.Run("токен используется один раз", func(t *testing.T) {
+				repo := newRepo(t)
+				require.NoError(t, repo.Create(t.Context(), newToken("alice", "hash-1", now)))
+
+				// Поиск токен не тратит
+				found, err := repo.Find(t.Context(), "hash-1", now)
+				require.NoError(t, err)
+				assert.Equal(t, "alice", found.UserID)
+
+				token, err := repo.Consume(t.Context(), "hash-1", now.Add(time.Minute))
+				require.NoError(t, err)
+				assert.Equal(t, "alice", token.UserID)
+				assert.Equal(t, "alice@example.com", token.Email)
+
+				_, err = repo.Consume(t.Context(), "hash-1", now.Add(time.Minute))
+				assert.ErrorIs(t, err, ErrInvalidToken)
+			})
+
+			t.Run("неизвестный и истекший токен", func(t *testing.T) {
+				repo := newRepo(t)
+				require.NoError(t, repo.Create(t.Context(), newToken("alice", "hash-1", now)))
+
+				_, err := repo.Consume(t.Context(), "unknown", now)
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				_, err = repo.Find(t.Context(), "hash-1", now.Add(time.Hour))
+				assert.ErrorIs(t, err, ErrInvalidToken)
+				_, err = repo.Consume(t.Context(), "hash-1", now.Add(time.Hour))
+				assert.ErrorIs(t, err, ErrInvalidToken)
+			})
+
+			t.Run("сброс отзывает остальные токены пользователя", func(t *testing.T) {
+				repo := newRepo(t)
+				require.NoError(t, repo.Create(t.Context(), newToken("alice", "hash-1", now)))
+				require.NoError(t, repo.Create(t.Context(), newToken("alice", "hash-2", now)))
+				require.NoError(t, repo.Create(t.Context(), newToken("bob", "hash-3", now)))
+
+				_, err := repo.Consume(t.Context(), "hash-2", now)
+				require.NoError(t, err)
+				_, err = repo.Consume(t.Context(), "hash-1", now)
+				assert.ErrorIs(t, err, ErrInvalidToken)
+
+				// Токены других пользователей не затронуты
+				_, err = repo.Consume(t.Context(), "hash-3", now)
+				assert.NoError(t, err)
+			})
+
+			t.Run("новый токен удаляет истекшие", func(t *testing.T) {
+				repo := newRepo(t)
+				require.NoError(t, repo.Create(t.Context(), newToken("alice", "hash-1", now)))
+				require.NoError(t, repo.Create(t.Context(), newToken("bob", "hash-2", now.Add(2*time.Hour))))
+
+				// Проверяем через Consume с часами в прошлом: истекший токен уже удален
+				_, err := repo.Consume(t.Context(), "hash-1", now)
+				assert.ErrorIs(t, err, ErrInvalidToken)
+			})
+		})
+	}
+}
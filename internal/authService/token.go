@@ -0,0 +1,63 @@
+package authService
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// purposeVerifyEmail Назначение токена подтверждения email. Входит в подпись, чтобы токен
+// другого назначения с тем же секретом не подошел
+const purposeVerifyEmail = "verify_email"
+
+// claims Содержимое токена
+type claims struct {
+	Subject   string `json:"sub"`   // ID пользователя
+	Email     string `json:"email"` // Адрес, на который ушло письмо
+	ExpiresAt int64  `json:"exp"`   // Срок действия, секунды Unix
+}
+
+// signToken Подписывает токен: base64url(JSON).base64url(HMAC-SHA256)
+func signToken(secret []byte, purpose string, c claims) (string, error) {
+	payload, err := json.Marshal(c)
+	if err != nil {
+		return "", fmt.Errorf("auth: could not encode token: %w", err)
+	}
+	encoded := base64.RawURLEncoding.EncodeToString(payload)
+	return encoded + "." + base64.RawURLEncoding.EncodeToString(mac(secret, purpose, encoded)), nil
+}
+
+// parseToken Проверяет подпись и срок токена
+func parseToken(secret []byte, purpose, token string, now time.Time) (claims, error) {
+	encoded, signature, ok := strings.Cut(token, ".")
+	if !ok {
+		return claims{}, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+	sum, err := base64.RawURLEncoding.DecodeString(signature)
+	if err != nil || !hmac.Equal(sum, mac(secret, purpose, encoded)) {
+		return claims{}, fmt.Errorf("%w: bad signature", ErrInvalidToken)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(encoded)
+	if err != nil {
+		return claims{}, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+	var c claims
+	if err := json.Unmarshal(payload, &c); err != nil || c.Subject == "" {
+		return claims{}, fmt.Errorf("%w: malformed token", ErrInvalidToken)
+	}
+	if !now.Before(time.Unix(c.ExpiresAt, 0)) {
+		return claims{}, fmt.Errorf("%w: token expired", ErrInvalidToken)
+	}
+	return c, nil
+}
+
+// mac Подпись содержимого токена для назначения purpose
+func mac(secret []byte, purpose, encoded string) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(purpose + "." + encoded))
+	return h.Sum(nil)
+}
@@ -0,0 +1,296 @@
+package authService
+
+import (
+	"POSTnGETtrain/internal/logging"
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/userService"
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base32"
+	"errors"
+	"log/slog"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+// purposeTOTPChallenge Назначение вызова, выданного после проверки пароля
+const purposeTOTPChallenge = "totp_challenge"
+
+// Параметры TOTP (RFC 6238 по умолчанию: их понимают все приложения-аутентификаторы)
+const (
+	totpPeriod = 30 // Секунд на один код
+	totpSkew   = 1  // Сколько соседних шагов принимать при расхождении часов
+)
+
+// recoveryCodeCount Сколько кодов восстановления выдается при подключении
+const recoveryCodeCount = 10
+
+// totpOpts Параметры вычисления кодов
+var totpOpts = totp.ValidateOpts{Period: totpPeriod, Digits: otp.DigitsSix, Algorithm: otp.AlgorithmSHA1}
+
+// TOTPEnrollment Новый секрет TOTP для приложения-аутентификатора
+type TOTPEnrollment struct {
+	Secret string // Секрет в base32 для ручного ввода
+	URI    string // otpauth://totp/... для QR-кода
+}
+
+// Verification Результат проверки учетных данных. Пустой Challenge - пользователь подтвержден,
+// иначе нужно ответить на вызов кодом TOTP до ExpiresAt
+type Verification struct {
+	User      *models.User
+	Challenge string
+	ExpiresAt time.Time
+}
+
+// EnrollTOTP Новый секрет заменяет неподтвержденный: подключение можно начать заново
+func (s *authService) EnrollTOTP(ctx context.Context, email, password string) (*TOTPEnrollment, error) {
+	user, err := s.checkCredentials(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.cfg.Issuer,
+		AccountName: user.Email,
+		Period:      totpPeriod,
+		Digits:      totpOpts.Digits,
+		Algorithm:   totpOpts.Algorithm,
+	})
+	if err != nil {
+		return nil, err
+	}
+	err = s.totps.SavePending(ctx, &models.UserTOTP{UserID: user.ID, Secret: key.Secret(), CreatedAt: s.cfg.Now()})
+	if err != nil {
+		return nil, err
+	}
+	return &TOTPEnrollment{Secret: key.Secret(), URI: key.URL()}, nil
+}
+
+// ConfirmTOTP Код подтверждения считается использованным: войти им же нельзя
+func (s *authService) ConfirmTOTP(ctx context.Context, email, password, code string) ([]string, error) {
+	user, err := s.checkCredentials(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+	record, err := s.totps.Get(ctx, user.ID)
+	if err != nil {
+		return nil, err
+	}
+	if record.ConfirmedAt != nil {
+		return nil, ErrTOTPAlreadyEnrolled
+	}
+	step, ok := matchStep(record.Secret, code, s.cfg.Now())
+	if !ok {
+		return nil, ErrInvalidTOTPCode
+	}
+
+	codes := make([]string, recoveryCodeCount)
+	stored := make([]models.TOTPRecoveryCode, recoveryCodeCount)
+	for i := range codes {
+		codes[i] = newRecoveryCode()
+		stored[i] = models.TOTPRecoveryCode{
+			ID:        uuid.NewString(),
+			UserID:    user.ID,
+			CodeHash:  hashToken(normalizeRecoveryCode(codes[i])),
+			CreatedAt: s.cfg.Now(),
+		}
+	}
+	if err := s.totps.Confirm(ctx, user.ID, record.Secret, step, s.cfg.Now(), stored); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "totp enabled", slog.String("user_id", user.ID))
+	return codes, nil
+}
+
+// VerifyCredentials Вызов подписан тем же ключом, что и ссылки из писем, но с другим назначением
+func (s *authService) VerifyCredentials(ctx context.Context, email, password string) (*Verification, error) {
+	user, err := s.checkCredentials(ctx, email, password)
+	if err != nil {
+		return nil, err
+	}
+	record, err := s.totps.Get(ctx, user.ID)
+	if errors.Is(err, ErrTOTPNotEnrolled) || (err == nil && record.ConfirmedAt == nil) {
+		return &Verification{User: user}, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	expires := s.cfg.Now().Add(s.cfg.ChallengeTTL)
+	challenge, err := signToken(s.cfg.Secret, purposeTOTPChallenge, claims{
+		Subject:   user.ID,
+		Email:     user.Email,
+		ExpiresAt: expires.Unix(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &Verification{User: user, Challenge: challenge, ExpiresAt: expires}, nil
+}
+
+// VerifyTOTP Вместо кода TOTP принимается код восстановления; каждый из них действует один раз
+func (s *authService) VerifyTOTP(ctx context.Context, challenge, code string) (*models.User, error) {
+	c, err := parseToken(s.cfg.Secret, purposeTOTPChallenge, challenge, s.cfg.Now())
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.users.GetUserByID(ctx, c.Subject)
+	if errors.Is(err, userService.ErrUserNotFound) {
+		return nil, ErrInvalidToken
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Email != c.Email {
+		return nil, ErrInvalidToken
+	}
+	record, err := s.totps.Get(ctx, user.ID)
+	if errors.Is(err, ErrTOTPNotEnrolled) {
+		return nil, ErrInvalidToken // TOTP отключили после выдачи вызова
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := s.useCode(ctx, record, code); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// RequireTOTP Пустой код у пользователя с TOTP - ErrTOTPRequired, неверный или повторный - ErrInvalidTOTPCode,
+// после исчерпания попыток - ErrTOTPLocked.
+// Код восстановления тоже подходит: без него потерявший аутентификатор не смог бы сбросить пароль
+func (s *authService) RequireTOTP(ctx context.Context, userID, code string) error {
+	record, err := s.totps.Get(ctx, userID)
+	if errors.Is(err, ErrTOTPNotEnrolled) || (err == nil && record.ConfirmedAt == nil) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	if code == "" {
+		return ErrTOTPRequired
+	}
+	return s.useCode(ctx, record, code)
+}
+
+// checkCredentials Пользователь с такими email и паролем. Неизвестный email и неверный пароль
+// неотличимы для клиента
+func (s *authService) checkCredentials(ctx context.Context, email, password string) (*models.User, error) {
+	user, err := s.users.GetUserByEmail(ctx, email)
+	if errors.Is(err, userService.ErrUserNotFound) {
+		return nil, ErrInvalidCredentials
+	}
+	if err != nil {
+		return nil, err
+	}
+	if subtle.ConstantTimeCompare([]byte(user.Password), []byte(password)) != 1 {
+		logging.FromContext(ctx).InfoContext(ctx, "invalid credentials", slog.String("user_id", user.ID))
+		return nil, ErrInvalidCredentials
+	}
+	return user, nil
+}
+
+// useCode Тратит код TOTP (шаг запоминается, и код нельзя применить повторно) или код восстановления
+func (s *authService) useCode(ctx context.Context, record *models.UserTOTP, code string) error {
+	err := s.totps.CountAttempt(ctx, record.UserID, s.cfg.Now(), s.cfg.TOTPMaxAttempts, s.cfg.TOTPLockout)
+	if errors.Is(err, ErrTOTPLocked) {
+		logging.FromContext(ctx).WarnContext(ctx, "totp attempts exhausted", slog.String("user_id", record.UserID))
+	}
+	if err != nil {
+		return err
+	}
+	if step, ok := matchStep(record.Secret, code, s.cfg.Now()); ok {
+		return s.totps.UseStep(ctx, record.UserID, step)
+	}
+	if err := s.totps.UseRecoveryCode(ctx, record.UserID, hashToken(normalizeRecoveryCode(code))); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).WarnContext(ctx, "totp recovery code used", slog.String("user_id", record.UserID))
+	return nil
+}
+
+// matchStep Шаг времени, для которого код подходит: текущий или соседний
+func matchStep(secret, code string, now time.Time) (int64, bool) {
+	current := now.Unix() / totpPeriod
+	for _, step := range []int64{current, current - totpSkew, current + totpSkew} {
+		expected, err := totp.GenerateCodeCustom(secret, time.Unix(step*totpPeriod, 0), totpOpts)
+		if err == nil && subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+	return 0, false
+}
+
+// newRecoveryCode Случайный код восстановления вида xxxxx-xxxxx (50 бит)
+func newRecoveryCode() string {
+	raw := make([]byte, 10)
+	_, _ = rand.Read(raw)
+	text := strings.ToLower(base32.StdEncoding.EncodeToString(raw))[:10]
+	return text[:5] + "-" + text[5:]
+}
+
+// normalizeRecoveryCode Код восстановления без регистра, дефисов и пробелов: так его удобнее вводить
+func normalizeRecoveryCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToLower(code))
+}
+
+// totpCodeKey Ключ кода TOTP из запроса в контексте
+type totpCodeKey struct{}
+
+// WithTOTPCode Возвращает контекст с кодом TOTP, который клиент прислал для подтверждения действия
+func WithTOTPCode(ctx context.Context, code string) context.Context {
+	return context.WithValue(ctx, totpCodeKey{}, code)
+}
+
+// TOTPCodeFromContext Код TOTP из запроса; пусто, если его не прислали
+func TOTPCodeFromContext(ctx context.Context) string {
+	code, _ := ctx.Value(totpCodeKey{}).(string)
+	return code
+}
+
+// totpUserService Требует свежий код TOTP для смены пароля или email и удаления пользователя с подключенным TOTP.
+// Смена email без кода позволила бы перевести письмо сброса пароля на свой адрес
+type totpUserService struct {
+	userService.UserService
+	auth  AuthService
+	totps TOTPRepository
+}
+
+// NewTOTPUserService Оборачивает сервис пользователей проверкой кода TOTP из контекста (WithTOTPCode)
+// перед чувствительными действиями. После удаления пользователя удаляется и его секрет
+func NewTOTPUserService(users userService.UserService, auth AuthService, totps TOTPRepository) userService.UserService {
+	return &totpUserService{UserService: users, auth: auth, totps: totps}
+}
+
+func (s *totpUserService) UpdateUser(ctx context.Context, id string, version *int64, changes models.UserChanges) (*models.User, error) {
+	if changes.Password.Set || changes.Email.Set {
+		if err := s.auth.RequireTOTP(ctx, id, TOTPCodeFromContext(ctx)); err != nil {
+			return nil, err
+		}
+	}
+	return s.UserService.UpdateUser(ctx, id, version, changes)
+}
+
+func (s *totpUserService) DeleteUser(ctx context.Context, id string, version *int64) error {
+	if err := s.auth.RequireTOTP(ctx, id, TOTPCodeFromContext(ctx)); err != nil {
+		return err
+	}
+	if err := s.UserService.DeleteUser(ctx, id, version); err != nil {
+		return err
+	}
+	if err := s.totps.Delete(ctx, id); err != nil {
+		logging.FromContext(ctx).WarnContext(ctx, "could not delete totp of deleted user",
+			slog.String("user_id", id), slog.String("error", err.Error()))
+	}
+	return nil
+}
@@ -0,0 +1,172 @@
+package authService
+
+import (
+	"POSTnGETtrain/internal/models"
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// TOTPRepository Хранилище секретов TOTP и кодов восстановления
+type TOTPRepository interface {
+	// Get Возвращает секрет пользователя (подтвержденный или нет). Если его нет - ErrTOTPNotEnrolled
+	Get(ctx context.Context, userID string) (*models.UserTOTP, error)
+	// SavePending Сохраняет новый неподтвержденный секрет вместо прежнего неподтвержденного.
+	// Если подключение уже подтверждено - ErrTOTPAlreadyEnrolled
+	SavePending(ctx context.Context, totp *models.UserTOTP) error
+	// Confirm Подтверждает секрет secret кодом шага step и заменяет коды восстановления.
+	// Если секрет сменился или уже подтвержден - ErrTOTPNotEnrolled
+	Confirm(ctx context.Context, userID, secret string, step int64, at time.Time, codes []models.TOTPRecoveryCode) error
+	// CountAttempt Засчитывает попытку ввода кода до его проверки, в момент at. Если подряд уже было
+	// maxAttempts попыток без принятого кода и с последней прошло меньше lockout - ErrTOTPLocked.
+	// Без подтвержденного подключения - ErrInvalidTOTPCode
+	CountAttempt(ctx context.Context, userID string, at time.Time, maxAttempts int, lockout time.Duration) error
+	// UseStep Запоминает шаг принятого кода и обнуляет счетчик попыток.
+	// Код того же или более раннего шага - ErrInvalidTOTPCode
+	UseStep(ctx context.Context, userID string, step int64) error
+	// UseRecoveryCode Удаляет код восстановления с хэшем hash и обнуляет счетчик попыток.
+	// Если кода нет - ErrInvalidTOTPCode
+	UseRecoveryCode(ctx context.Context, userID, hash string) error
+	// Delete Удаляет секрет и коды восстановления пользователя
+	Delete(ctx context.Context, userID string) error
+}
+
+// totpRepository - реализация TOTPRepository с использованием GORM
+type totpRepository struct {
+	db *gorm.DB
+}
+
+// NewTOTPRepository Конструктор хранилища TOTP в БД
+func NewTOTPRepository(db *gorm.DB) TOTPRepository {
+	return &totpRepository{db: db}
+}
+
+func (r *totpRepository) Get(ctx context.Context, userID string) (*models.UserTOTP, error) {
+	var found []models.UserTOTP
+	if err := r.db.WithContext(ctx).Where("user_id = ?", userID).Limit(1).Find(&found).Error; err != nil {
+		return nil, fmt.Errorf("repo: could not find totp: %w", err)
+	}
+	if len(found) == 0 {
+		return nil, ErrTOTPNotEnrolled
+	}
+	return &found[0], nil
+}
+
+func (r *totpRepository) SavePending(ctx context.Context, totp *models.UserTOTP) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var confirmed int64
+		if err := tx.Model(&models.UserTOTP{}).
+			Where("user_id = ? AND confirmed_at IS NOT NULL", totp.UserID).Count(&confirmed).Error; err != nil {
+			return fmt.Errorf("repo: could not find totp: %w", err)
+		}
+		if confirmed > 0 {
+			return ErrTOTPAlreadyEnrolled
+		}
+		if err := tx.Where("user_id = ?", totp.UserID).Delete(&models.UserTOTP{}).Error; err != nil {
+			return fmt.Errorf("repo: could not delete pending totp: %w", err)
+		}
+		if err := tx.Create(totp).Error; err != nil {
+			return fmt.Errorf("repo: could not save totp: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *totpRepository) Confirm(ctx context.Context, userID, secret string, step int64, at time.Time, codes []models.TOTPRecoveryCode) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		updated := tx.Model(&models.UserTOTP{}).
+			Where("user_id = ? AND secret = ? AND confirmed_at IS NULL", userID, secret).
+			Updates(map[string]any{"confirmed_at": at, "last_used_step": step})
+		if updated.Error != nil {
+			return fmt.Errorf("repo: could not confirm totp: %w", updated.Error)
+		}
+		if updated.RowsAffected == 0 {
+			return ErrTOTPNotEnrolled // Параллельный запрос подтвердил или заменил секрет
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.TOTPRecoveryCode{}).Error; err != nil {
+			return fmt.Errorf("repo: could not delete recovery codes: %w", err)
+		}
+		if len(codes) == 0 {
+			return nil
+		}
+		if err := tx.Create(&codes).Error; err != nil {
+			return fmt.Errorf("repo: could not save recovery codes: %w", err)
+		}
+		return nil
+	})
+}
+
+// CountAttempt Условные обновления: параллельные запросы не могут вместе сделать больше maxAttempts попыток
+func (r *totpRepository) CountAttempt(ctx context.Context, userID string, at time.Time, maxAttempts int, lockout time.Duration) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		// Блокировка истекла - счет начинается заново
+		err := tx.Model(&models.UserTOTP{}).
+			Where("user_id = ? AND failed_attempts >= ? AND last_attempt_at <= ?", userID, maxAttempts, at.Add(-lockout)).
+			Update("failed_attempts", 0).Error
+		if err != nil {
+			return fmt.Errorf("repo: could not reset totp attempts: %w", err)
+		}
+		counted := tx.Model(&models.UserTOTP{}).
+			Where("user_id = ? AND confirmed_at IS NOT NULL AND failed_attempts < ?", userID, maxAttempts).
+			Updates(map[string]any{"failed_attempts": gorm.Expr("failed_attempts + 1"), "last_attempt_at": at})
+		if counted.Error != nil {
+			return fmt.Errorf("repo: could not count totp attempt: %w", counted.Error)
+		}
+		if counted.RowsAffected == 1 {
+			return nil
+		}
+		var confirmed int64
+		if err := tx.Model(&models.UserTOTP{}).
+			Where("user_id = ? AND confirmed_at IS NOT NULL", userID).Count(&confirmed).Error; err != nil {
+			return fmt.Errorf("repo: could not find totp: %w", err)
+		}
+		if confirmed == 0 {
+			return ErrInvalidTOTPCode
+		}
+		return ErrTOTPLocked
+	})
+}
+
+// UseStep Условное обновление: из параллельных запросов с одним кодом успешен только один
+func (r *totpRepository) UseStep(ctx context.Context, userID string, step int64) error {
+	updated := r.db.WithContext(ctx).Model(&models.UserTOTP{}).
+		Where("user_id = ? AND confirmed_at IS NOT NULL AND last_used_step < ?", userID, step).
+		Updates(map[string]any{"last_used_step": step, "failed_attempts": 0})
+	if updated.Error != nil {
+		return fmt.Errorf("repo: could not use totp code: %w", updated.Error)
+	}
+	if updated.RowsAffected == 0 {
+		return ErrInvalidTOTPCode
+	}
+	return nil
+}
+
+func (r *totpRepository) UseRecoveryCode(ctx context.Context, userID, hash string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		deleted := tx.Where("user_id = ? AND code_hash = ?", userID, hash).Delete(&models.TOTPRecoveryCode{})
+		if deleted.Error != nil {
+			return fmt.Errorf("repo: could not use recovery code: %w", deleted.Error)
+		}
+		if deleted.RowsAffected == 0 {
+			return ErrInvalidTOTPCode
+		}
+		if err := tx.Model(&models.UserTOTP{}).Where("user_id = ?", userID).Update("failed_attempts", 0).Error; err != nil {
+			return fmt.Errorf("repo: could not reset totp attempts: %w", err)
+		}
+		return nil
+	})
+}
+
+func (r *totpRepository) Delete(ctx context.Context, userID string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&models.TOTPRecoveryCode{}).Error; err != nil {
+			return fmt.Errorf("repo: could not delete recovery codes: %w", err)
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&models.UserTOTP{}).Error; err != nil {
+			return fmt.Errorf("repo: could not delete totp: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,122 @@
+package authService
+
+import (
+	"POSTnGETtrain/internal/models"
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryTOTPRepository Реализация TOTPRepository в памяти процесса
+type memoryTOTPRepository struct {
+	mu    sync.Mutex
+	totps map[string]models.UserTOTP         // По пользователю
+	codes map[string]models.TOTPRecoveryCode // По хэшу кода
+}
+
+// NewMemoryTOTPRepository Конструктор хранилища TOTP в памяти (для локального запуска и тестов)
+func NewMemoryTOTPRepository() TOTPRepository {
+	return &memoryTOTPRepository{
+		totps: make(map[string]models.UserTOTP),
+		codes: make(map[string]models.TOTPRecoveryCode),
+	}
+}
+
+func (r *memoryTOTPRepository) Get(ctx context.Context, userID string) (*models.UserTOTP, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	totp, ok := r.totps[userID]
+	if !ok {
+		return nil, ErrTOTPNotEnrolled
+	}
+	return &totp, nil
+}
+
+func (r *memoryTOTPRepository) SavePending(ctx context.Context, totp *models.UserTOTP) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if stored, ok := r.totps[totp.UserID]; ok && stored.ConfirmedAt != nil {
+		return ErrTOTPAlreadyEnrolled
+	}
+	r.totps[totp.UserID] = *totp
+	return nil
+}
+
+func (r *memoryTOTPRepository) Confirm(ctx context.Context, userID, secret string, step int64, at time.Time, codes []models.TOTPRecoveryCode) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	totp, ok := r.totps[userID]
+	if !ok || totp.Secret != secret || totp.ConfirmedAt != nil {
+		return ErrTOTPNotEnrolled
+	}
+	totp.ConfirmedAt, totp.LastUsedStep = &at, step
+	r.totps[userID] = totp
+	r.deleteCodes(userID)
+	for _, code := range codes {
+		r.codes[code.CodeHash] = code
+	}
+	return nil
+}
+
+func (r *memoryTOTPRepository) CountAttempt(ctx context.Context, userID string, at time.Time, maxAttempts int, lockout time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	totp, ok := r.totps[userID]
+	if !ok || totp.ConfirmedAt == nil {
+		return ErrInvalidTOTPCode
+	}
+	if totp.FailedAttempts >= maxAttempts && !totp.LastAttemptAt.After(at.Add(-lockout)) {
+		totp.FailedAttempts = 0 // Блокировка истекла
+	}
+	if totp.FailedAttempts >= maxAttempts {
+		return ErrTOTPLocked
+	}
+	totp.FailedAttempts++
+	totp.LastAttemptAt = &at
+	r.totps[userID] = totp
+	return nil
+}
+
+func (r *memoryTOTPRepository) UseStep(ctx context.Context, userID string, step int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	totp, ok := r.totps[userID]
+	if !ok || totp.ConfirmedAt == nil || totp.LastUsedStep >= step {
+		return ErrInvalidTOTPCode
+	}
+	totp.LastUsedStep, totp.FailedAttempts = step, 0
+	r.totps[userID] = totp
+	return nil
+}
+
+func (r *memoryTOTPRepository) UseRecoveryCode(ctx context.Context, userID, hash string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	code, ok := r.codes[hash]
+	if !ok || code.UserID != userID {
+		return ErrInvalidTOTPCode
+	}
+	delete(r.codes, hash)
+	if totp, ok := r.totps[userID]; ok {
+		totp.FailedAttempts = 0
+		r.totps[userID] = totp
+	}
+	return nil
+}
+
+func (r *memoryTOTPRepository) Delete(ctx context.Context, userID string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.totps, userID)
+	r.deleteCodes(userID)
+	return nil
+}
+
+// deleteCodes Удаляет коды восстановления пользователя (вызывается под r.mu)
+func (r *memoryTOTPRepository) deleteCodes(userID string) {
+	for hash, code := range r.codes {
+		if code.UserID == userID {
+			delete(r.codes, hash)
+		}
+	}
+}
@@ -0,0 +1,134 @@
+package authService
+
+import (
+	"POSTnGETtrain/internal/db/dbtest"
+	"POSTnGETtrain/internal/models"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTOTPRepository Один и тот же набор проверок для всех реализаций TOTPRepository
+func TestTOTPRepository(t *testing.T) {
+	implementations := map[string]func(t *testing.T) TOTPRepository{
+		"memory": func(*testing.T) TOTPRepository { return NewMemoryTOTPRepository() },
+		"gorm":   func(t *testing.T) TOTPRepository { return NewTOTPRepository(dbtest.Open(t)) },
+	}
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+	pending := func(userID, secret string) *models.UserTOTP {
+		return &models.UserTOTP{UserID: userID, Secret: secret, CreatedAt: now}
+	}
+	codes := func(userID string, hashes ...string) []models.TOTPRecoveryCode {
+		result := make([]models.TOTPRecoveryCode, len(hashes))
+		for i, hash := range hashes {
+			result[i] = models.TOTPRecoveryCode{ID: uuid.NewString(), UserID: userID, CodeHash: hash, CreatedAt: now}
+		}
+		return result
+	}
+
+	for name, newRepo := range implementations {
+		t.Run(name, func(t *testing.T) {
+			t.Run("подключение заменяет неподтвержденный секрет", func(t *testing.T) {
+				repo := newRepo(t)
+				_, err := repo.Get(t.Context(), "alice")
+				assert.ErrorIs(t, err, ErrTOTPNotEnrolled)
+
+				require.NoError(t, repo.SavePending(t.Context(), pending("alice", "SECRET1")))
+				require.NoError(t, repo.SavePending(t.Context(), pending("alice", "SECRET2")))
+				found, err := repo.Get(t.Context(), "alice")
+				require.NoError(t, err)
+				assert.Equal(t, "SECRET2", found.Secret)
+				assert.Nil(t, found.ConfirmedAt)
+
+				// Подтверждение устаревшего секрета не проходит
+				err = repo.Confirm(t.Context(), "alice", "SECRET1", 100, now, codes("alice", "hash-1"))
+				assert.ErrorIs(t, err, ErrTOTPNotEnrolled)
+
+				require.NoError(t, repo.Confirm(t.Context(), "alice", "SECRET2", 100, now, codes("alice", "hash-1")))
+				found, err = repo.Get(t.Context(), "alice")
+				require.NoError(t, err)
+				require.NotNil(t, found.ConfirmedAt)
+				assert.Equal(t, int64(100), found.LastUsedStep)
+
+				err = repo.SavePending(t.Context(), pending("alice", "SECRET3"))
+				assert.ErrorIs(t, err, ErrTOTPAlreadyEnrolled)
+				err = repo.Confirm(t.Context(), "alice", "SECRET2", 101, now, codes("alice", "hash-2"))
+				assert.ErrorIs(t, err, ErrTOTPNotEnrolled)
+			})
+
+			t.Run("шаг кода используется один раз", func(t *testing.T) {
+				repo := newRepo(t)
+				require.NoError(t, repo.SavePending(t.Context(), pending("alice", "SECRET")))
+				// До подтверждения коды не принимаются
+				assert.ErrorIs(t, repo.UseStep(t.Context(), "alice", 101), ErrInvalidTOTPCode)
+
+				require.NoError(t, repo.Confirm(t.Context(), "alice", "SECRET", 100, now, nil))
+				assert.ErrorIs(t, repo.UseStep(t.Context(), "alice", 100), ErrInvalidTOTPCode)
+				require.NoError(t, repo.UseStep(t.Context(), "alice", 102))
+				assert.ErrorIs(t, repo.UseStep(t.Context(), "alice", 102), ErrInvalidTOTPCode)
+				assert.ErrorIs(t, repo.UseStep(t.Context(), "alice", 101), ErrInvalidTOTPCode)
+				assert.ErrorIs(t, repo.UseStep(t.Context(), "bob", 200), ErrInvalidTOTPCode)
+			})
+
+			t.Run("код восстановления используется один раз", func(t *testing.T) {
+				repo := newRepo(t)
+				require.NoError(t, repo.SavePending(t.Context(), pending("alice", "SECRET")))
+				require.NoError(t, repo.Confirm(t.Context(), "alice", "SECRET", 100, now, codes("alice", "hash-1", "hash-2")))
+				require.NoError(t, repo.SavePending(t.Context(), pending("bob", "SECRET")))
+				require.NoError(t, repo.Confirm(t.Context(), "bob", "SECRET", 100, now, codes("bob", "hash-3")))
+
+				require.NoError(t, repo.UseRecoveryCode(t.Context(), "alice", "hash-1"))
+				assert.ErrorIs(t, repo.UseRecoveryCode(t.Context(), "alice", "hash-1"), ErrInvalidTOTPCode)
+				// Чужой код не подходит
+				assert.ErrorIs(t, repo.UseRecoveryCode(t.Context(), "alice", "hash-3"), ErrInvalidTOTPCode)
+				assert.NoError(t, repo.UseRecoveryCode(t.Context(), "alice", "hash-2"))
+			})
+
+			t.Run("попытки ввода кода ограничены", func(t *testing.T) {
+				repo := newRepo(t)
+				require.NoError(t, repo.SavePending(t.Context(), pending("alice", "SECRET")))
+				// До подтверждения попытки не засчитываются
+				assert.ErrorIs(t, repo.CountAttempt(t.Context(), "alice", now, 2, time.Minute), ErrInvalidTOTPCode)
+				require.NoError(t, repo.Confirm(t.Context(), "alice", "SECRET", 100, now, codes("alice", "hash-1")))
+
+				require.NoError(t, repo.CountAttempt(t.Context(), "alice", now, 2, time.Minute))
+				require.NoError(t, repo.CountAttempt(t.Context(), "alice", now, 2, time.Minute))
+				assert.ErrorIs(t, repo.CountAttempt(t.Context(), "alice", now.Add(59*time.Second), 2, time.Minute), ErrTOTPLocked)
+				found, err := repo.Get(t.Context(), "alice")
+				require.NoError(t, err)
+				assert.Equal(t, 2, found.FailedAttempts)
+				// Блокировка отсчитывается от последней засчитанной попытки, отклоненные ее не продлевают
+				require.NoError(t, repo.CountAttempt(t.Context(), "alice", now.Add(time.Minute), 2, time.Minute))
+
+				// Принятый код обнуляет счетчик
+				require.NoError(t, repo.UseStep(t.Context(), "alice", 101))
+				found, err = repo.Get(t.Context(), "alice")
+				require.NoError(t, err)
+				assert.Equal(t, 0, found.FailedAttempts)
+				require.NoError(t, repo.CountAttempt(t.Context(), "alice", now.Add(time.Minute), 2, time.Minute))
+				require.NoError(t, repo.UseRecoveryCode(t.Context(), "alice", "hash-1"))
+				found, err = repo.Get(t.Context(), "alice")
+				require.NoError(t, err)
+				assert.Equal(t, 0, found.FailedAttempts)
+
+				assert.ErrorIs(t, repo.CountAttempt(t.Context(), "bob", now, 2, time.Minute), ErrInvalidTOTPCode)
+			})
+
+			t.Run("удаление", func(t *testing.T) {
+				repo := newRepo(t)
+				require.NoError(t, repo.SavePending(t.Context(), pending("alice", "SECRET")))
+				require.NoError(t, repo.Confirm(t.Context(), "alice", "SECRET", 100, now, codes("alice", "hash-1")))
+
+				require.NoError(t, repo.Delete(t.Context(), "alice"))
+				_, err := repo.Get(t.Context(), "alice")
+				assert.ErrorIs(t, err, ErrTOTPNotEnrolled)
+				assert.ErrorIs(t, repo.UseRecoveryCode(t.Context(), "alice", "hash-1"), ErrInvalidTOTPCode)
+				// Пользователя без TOTP удалять можно
+				assert.NoError(t, repo.Delete(t.Context(), "bob"))
+			})
+		})
+	}
+}
@@ -0,0 +1,367 @@
+package authService
+
+import (
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/pkg/patch"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pquerna/otp/totp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// code Код TOTP для секрета в момент now
+func code(t *testing.T, secret string, now time.Time) string {
+	t.Helper()
+	value, err := totp.GenerateCode(secret, now)
+	require.NoError(t, err)
+	return value
+}
+
+// enrolled Пользователь с подключенным TOTP: секрет и коды восстановления.
+// Часы сдвигаются на следующий шаг, чтобы код подтверждения не мешал проверкам
+func enrolled(t *testing.T, e env, email string) (*models.User, string, []string) {
+	t.Helper()
+	user, err := e.users.CreateUser(t.Context(), email, "secret")
+	require.NoError(t, err)
+	enrollment, err := e.auth.EnrollTOTP(t.Context(), email, "secret")
+	require.NoError(t, err)
+	recovery, err := e.auth.ConfirmTOTP(t.Context(), email, "secret", code(t, enrollment.Secret, e.clock.now))
+	require.NoError(t, err)
+	e.clock.now = e.clock.now.Add(totpPeriod * time.Second)
+	return user, enrollment.Secret, recovery
+}
+
+func TestEnrollTOTP(t *testing.T) {
+	e := newEnv(t, "")
+	_, err := e.users.CreateUser(t.Context(), "alice@example.com", "secret")
+	require.NoError(t, err)
+
+	_, err = e.auth.EnrollTOTP(t.Context(), "alice@example.com", "wrong")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = e.auth.EnrollTOTP(t.Context(), "bob@example.com", "secret")
+	assert.ErrorIs(t, err, ErrInvalidCredentials)
+	_, err = e.auth.ConfirmTOTP(t.Context(), "alice@example.com", "secret", "123456")
+	assert.ErrorIs(t, err, ErrTOTPNotEnrolled)
+
+	enrollment, err := e.auth.EnrollTOTP(t.Context(), "alice@example.com", "secret")
+	require.NoError(t, err)
+	uri, err := url.Parse(enrollment.URI)
+	require.NoError(t, err)
+	assert.Equal(t, "otpauth", uri.Scheme)
+	assert.Equal(t, "totp", uri.Host)
+	assert.Equal(t, "/Tasks:alice@example.com", uri.Path)
+	assert.Equal(t, enrollment.Secret, uri.Query().Get("secret"))
+
+	// До подтверждения вход без кода
+	verification, err := e.auth.VerifyCredentials(t.Context(), "alice@example.com", "secret")
+	require.NoError(t, err)
+	assert.Empty(t, verification.Challenge)
+
+	// Повторное подключение заменяет секрет: код старого не подходит
+	first := enrollment.Secret
+	enrollment, err = e.auth.EnrollTOTP(t.Context(), "alice@example.com", "secret")
+	require.NoError(t, err)
+	assert.NotEqual(t, first, enrollment.Secret)
+	_, err = e.auth.ConfirmTOTP(t.Context(), "alice@example.com", "secret", code(t, first, e.clock.now))
+	assert.ErrorIs(t, err, ErrInvalidTOTPCode)
+
+	recovery, err := e.auth.ConfirmTOTP(t.Context(), "alice@example.com", "secret", code(t, enrollment.Secret, e.clock.now))
+	require.NoError(t, err)
+	assert.Len(t, recovery, recoveryCodeCount)
+	for _, value := range recovery {
+		assert.Regexp(t, `^[a-z2-7]{5}-[a-z2-7]{5}$`, value)
+	}
+
+	_, err = e.auth.EnrollTOTP(t.Context(), "alice@example.com", "secret")
+	assert.ErrorIs(t, err, ErrTOTPAlreadyEnrolled)
+	_, err = e.auth.ConfirmTOTP(t.Context(), "alice@example.com", "secret", code(t, enrollment.Secret, e.clock.now))
+	assert.ErrorIs(t, err, ErrTOTPAlreadyEnrolled)
+}
+
+func TestVerifyCredentials(t *testing.T) {
+	t.Run("без TOTP достаточно пароля", func(t *testing.T) {
+		e := newEnv(t, "")
+		user, err := e.users.CreateUser(t.Context(), "alice@example.com", "secret")
+		require.NoError(t, err)
+
+		verification, err := e.auth.VerifyCredentials(t.Context(), "alice@example.com", "secret")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, verification.User.ID)
+		assert.Empty(t, verification.Challenge)
+
+		_, err = e.auth.VerifyCredentials(t.Context(), "alice@example.com", "wrong")
+		assert.ErrorIs(t, err, ErrInvalidCredentials)
+	})
+
+	tests := []struct {
+		name    string
+		code    func(t *testing.T, e env, secret string, recovery []string) string
+		wait    time.Duration // Сколько прошло между паролем и кодом
+		wantErr error
+	}{
+		{
+			name: "текущий код",
+			code: func(t *testing.T, e env, secret string, _ []string) string { return code(t, secret, e.clock.now) },
+		},
+		{
+			name: "код предыдущего шага при расхождении часов",
+			code: func(t *testing.T, e env, secret string, _ []string) string {
+				return code(t, secret, e.clock.now.Add(-totpPeriod*time.Second))
+			},
+			wait: totpPeriod * time.Second,
+		},
+		{
+			name: "код следующего шага при расхождении часов",
+			code: func(t *testing.T, e env, secret string, _ []string) string {
+				return code(t, secret, e.clock.now.Add(totpPeriod*time.Second))
+			},
+		},
+		{
+			name: "код двумя шагами позже",
+			code: func(t *testing.T, e env, secret string, _ []string) string {
+				return code(t, secret, e.clock.now.Add(2*totpPeriod*time.Second))
+			},
+			wantErr: ErrInvalidTOTPCode,
+		},
+		{
+			// Шаг подтверждения еще в пределах расхождения часов, но уже использован
+			name: "код подтверждения повторно не принимается",
+			code: func(t *testing.T, e env, secret string, _ []string) string {
+				return code(t, secret, e.clock.now.Add(-totpPeriod*time.Second))
+			},
+			wantErr: ErrInvalidTOTPCode,
+		},
+		{
+			name: "код восстановления без учета регистра и дефиса",
+			code: func(_ *testing.T, _ env, _ string, recovery []string) string {
+				return strings.ToUpper(strings.ReplaceAll(recovery[0], "-", ""))
+			},
+		},
+		{
+			name:    "неизвестный код",
+			code:    func(*testing.T, env, string, []string) string { return "000000" },
+			wantErr: ErrInvalidTOTPCode,
+		},
+		{
+			name:    "истекший вызов",
+			code:    func(t *testing.T, e env, secret string, _ []string) string { return code(t, secret, e.clock.now) },
+			wait:    DefaultChallengeTTL + time.Second,
+			wantErr: ErrInvalidToken,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv(t, "")
+			user, secret, recovery := enrolled(t, e, "alice@example.com")
+
+			verification, err := e.auth.VerifyCredentials(t.Context(), "alice@example.com", "secret")
+			require.NoError(t, err)
+			require.NotEmpty(t, verification.Challenge)
+			assert.Equal(t, e.clock.now.Add(DefaultChallengeTTL), verification.ExpiresAt)
+
+			e.clock.now = e.clock.now.Add(tt.wait)
+			verified, err := e.auth.VerifyTOTP(t.Context(), verification.Challenge, tt.code(t, e, secret, recovery))
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, user.ID, verified.ID)
+		})
+	}
+
+	t.Run("каждый код принимается один раз", func(t *testing.T) {
+		e := newEnv(t, "")
+		_, secret, recovery := enrolled(t, e, "alice@example.com")
+		verification, err := e.auth.VerifyCredentials(t.Context(), "alice@example.com", "secret")
+		require.NoError(t, err)
+
+		current := code(t, secret, e.clock.now)
+		_, err = e.auth.VerifyTOTP(t.Context(), verification.Challenge, current)
+		require.NoError(t, err)
+		_, err = e.auth.VerifyTOTP(t.Context(), verification.Challenge, current)
+		assert.ErrorIs(t, err, ErrInvalidTOTPCode)
+		// Код более раннего шага после принятого тоже отклоняется
+		_, err = e.auth.VerifyTOTP(t.Context(), verification.Challenge, code(t, secret, e.clock.now.Add(-totpPeriod*time.Second)))
+		assert.ErrorIs(t, err, ErrInvalidTOTPCode)
+
+		_, err = e.auth.VerifyTOTP(t.Context(), verification.Challenge, recovery[1])
+		require.NoError(t, err)
+		_, err = e.auth.VerifyTOTP(t.Context(), verification.Challenge, recovery[1])
+		assert.ErrorIs(t, err, ErrInvalidTOTPCode)
+	})
+
+	t.Run("вызов другого назначения не подходит", func(t *testing.T) {
+		e := newEnv(t, "")
+		_, secret, _ := enrolled(t, e, "alice@example.com")
+		_, err := e.auth.VerifyTOTP(t.Context(), e.lastToken(t), code(t, secret, e.clock.now))
+		assert.ErrorIs(t, err, ErrInvalidToken)
+	})
+}
+
+func TestTOTPUserService(t *testing.T) {
+	tests := []struct {
+		name    string
+		code    func(t *testing.T, e env, secret string, recovery []string) string
+		wantErr error
+	}{
+		{
+			name: "свежий код",
+			code: func(t *testing.T, e env, secret string, _ []string) string { return code(t, secret, e.clock.now) },
+		},
+		{
+			name:    "без кода",
+			code:    func(*testing.T, env, string, []string) string { return "" },
+			wantErr: ErrTOTPRequired,
+		},
+		{
+			name: "использованный код",
+			code: func(t *testing.T, e env, secret string, _ []string) string {
+				return code(t, secret, e.clock.now.Add(-totpPeriod*time.Second))
+			},
+			wantErr: ErrInvalidTOTPCode,
+		},
+		{
+			name: "код восстановления",
+			code: func(_ *testing.T, _ env, _ string, recovery []string) string { return recovery[0] },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e := newEnv(t, "")
+			users := NewTOTPUserService(e.users, e.auth, e.totps)
+			user, secret, recovery := enrolled(t, e, "alice@example.com")
+
+			ctx := WithTOTPCode(t.Context(), tt.code(t, e, secret, recovery))
+			_, err := users.UpdateUser(ctx, user.ID, nil, models.UserChanges{Password: patch.Value("n3w")})
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				assert.ErrorIs(t, users.DeleteUser(ctx, user.ID, nil), tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			// Тот же код для удаления уже использован
+			assert.ErrorIs(t, users.DeleteUser(ctx, user.ID, nil), ErrInvalidTOTPCode)
+			e.clock.now = e.clock.now.Add(totpPeriod * time.Second)
+			require.NoError(t, users.DeleteUser(WithTOTPCode(t.Context(), code(t, secret, e.clock.now)), user.ID, nil))
+			_, err = e.totps.Get(t.Context(), user.ID)
+			assert.ErrorIs(t, err, ErrTOTPNotEnrolled)
+		})
+	}
+
+	t.Run("смена email требует код", func(t *testing.T) {
+		e := newEnv(t, "")
+		users := NewTOTPUserService(e.users, e.auth, e.totps)
+		user, secret, _ := enrolled(t, e, "alice@example.com")
+		changes := models.UserChanges{Email: patch.Value("alice@example.org")}
+
+		_, err := users.UpdateUser(t.Context(), user.ID, nil, changes)
+		assert.ErrorIs(t, err, ErrTOTPRequired)
+		_, err = users.UpdateUser(WithTOTPCode(t.Context(), "000000"), user.ID, nil, changes)
+		assert.ErrorIs(t, err, ErrInvalidTOTPCode)
+		current, err := e.users.GetUserByID(t.Context(), user.ID)
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.com", current.Email)
+
+		updated, err := users.UpdateUser(WithTOTPCode(t.Context(), code(t, secret, e.clock.now)), user.ID, nil, changes)
+		require.NoError(t, err)
+		assert.Equal(t, "alice@example.org", updated.Email)
+	})
+
+	t.Run("без TOTP код не нужен", func(t *testing.T) {
+		e := newEnv(t, "")
+		users := NewTOTPUserService(e.users, e.auth, e.totps)
+		user, err := users.CreateUser(t.Context(), "alice@example.com", "secret")
+		require.NoError(t, err)
+		_, err = users.UpdateUser(t.Context(), user.ID, nil, models.UserChanges{Password: patch.Value("n3w")})
+		require.NoError(t, err)
+		assert.NoError(t, users.DeleteUser(t.Context(), user.ID, nil))
+	})
+}
+
+// TestResetPasswordRequiresTOTP Доступа к почте недостаточно: без кода TOTP нельзя ни перевести
+// письмо сброса на свой адрес, ни сбросить пароль по нему
+func TestResetPasswordRequiresTOTP(t *testing.T) {
+	e := newEnv(t, "")
+	users := NewTOTPUserService(e.users, e.auth, e.totps)
+	user, secret, recovery := enrolled(t, e, "alice@example.com")
+
+	// Смена email на адрес злоумышленника без кода не проходит, и письмо уходит владельцу
+	_, err := users.UpdateUser(t.Context(), user.ID, nil, models.UserChanges{Email: patch.Value("mallory@example.com")})
+	require.ErrorIs(t, err, ErrTOTPRequired)
+	require.NoError(t, e.auth.ForgotPassword(t.Context(), "mallory@example.com"))
+	require.NoError(t, e.auth.ForgotPassword(t.Context(), "alice@example.com"))
+	e.auth.Wait()
+	messages := e.sent()
+	require.Len(t, messages, 2, "письмо подтверждения при регистрации и письмо сброса")
+	assert.Equal(t, "alice@example.com", messages[1].To)
+	token := e.lastToken(t)
+
+	// Даже с токеном из письма пароль без кода не меняется. Без кода токен не тратится,
+	// а неверный код тратит его: на один токен приходится одна попытка угадать код
+	err = e.auth.ResetPassword(t.Context(), token, "n3w-secret", "")
+	assert.ErrorIs(t, err, ErrTOTPRequired)
+	err = e.auth.ResetPassword(t.Context(), token, "n3w-secret", "000000")
+	assert.ErrorIs(t, err, ErrInvalidTOTPCode)
+	err = e.auth.ResetPassword(t.Context(), token, "n3w-secret", recovery[0])
+	assert.ErrorIs(t, err, ErrInvalidToken)
+	current, err := e.users.GetUserByID(t.Context(), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", current.Password)
+
+	// Потерявший аутентификатор сбрасывает пароль кодом восстановления
+	require.NoError(t, e.auth.ForgotPassword(t.Context(), "alice@example.com"))
+	e.auth.Wait()
+	require.NoError(t, e.auth.ResetPassword(t.Context(), e.lastToken(t), "n3w-secret", recovery[0]))
+	current, err = e.users.GetUserByID(t.Context(), user.ID)
+	require.NoError(t, err)
+	assert.Equal(t, "n3w-secret", current.Password)
+
+	// Свежий код TOTP тоже подходит
+	require.NoError(t, e.auth.ForgotPassword(t.Context(), "alice@example.com"))
+	e.auth.Wait()
+	require.NoError(t, e.auth.ResetPassword(t.Context(), e.lastToken(t), "other-secret", code(t, secret, e.clock.now)))
+}
+
+// TestTOTPAttemptsLimited После DefaultTOTPMaxAttempts неверных кодов подряд не принимается и верный:
+// проверка кодов заблокирована, пока не истечет DefaultTOTPLockout
+func TestTOTPAttemptsLimited(t *testing.T) {
+	e := newEnv(t, "")
+	user, secret, recovery := enrolled(t, e, "alice@example.com")
+	verification, err := e.auth.VerifyCredentials(t.Context(), "alice@example.com", "secret")
+	require.NoError(t, err)
+
+	for range DefaultTOTPMaxAttempts {
+		_, err := e.auth.VerifyTOTP(t.Context(), verification.Challenge, "000000")
+		require.ErrorIs(t, err, ErrInvalidTOTPCode)
+		require.NotErrorIs(t, err, ErrTOTPLocked)
+	}
+	_, err = e.auth.VerifyTOTP(t.Context(), verification.Challenge, code(t, secret, e.clock.now))
+	assert.ErrorIs(t, err, ErrTOTPLocked)
+	// Блокировка общая для всех проверок кода пользователя, коды восстановления тоже не принимаются
+	assert.ErrorIs(t, e.auth.RequireTOTP(t.Context(), user.ID, recovery[0]), ErrTOTPLocked)
+
+	// Вызов, на котором подбирали код, истекает раньше блокировки
+	e.clock.now = e.clock.now.Add(DefaultTOTPLockout)
+	_, err = e.auth.VerifyTOTP(t.Context(), verification.Challenge, code(t, secret, e.clock.now))
+	assert.ErrorIs(t, err, ErrInvalidToken)
+
+	// После блокировки верный код снова принимается и обнуляет счетчик
+	verification, err = e.auth.VerifyCredentials(t.Context(), "alice@example.com", "secret")
+	require.NoError(t, err)
+	_, err = e.auth.VerifyTOTP(t.Context(), verification.Challenge, "000000")
+	require.ErrorIs(t, err, ErrInvalidTOTPCode)
+	verified, err := e.auth.VerifyTOTP(t.Context(), verification.Challenge, code(t, secret, e.clock.now))
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, verified.ID)
+	record, err := e.totps.Get(t.Context(), user.ID)
+	require.NoError(t, err)
+	assert.Zero(t, record.FailedAttempts)
+}
@@ -1,28 +1,82 @@
 package db
 
 import (
-	"log"
+	"POSTnGETtrain/internal/logging"
+	"fmt"
+	"log/slog"
+	"strings"
+	"time"
 
+	"github.com/glebarez/sqlite"
 	"gorm.io/driver/postgres"
 	"gorm.io/gorm"
 )
 
+// Поддерживаемые СУБД
+const (
+	DialectPostgres = "postgres"
+	DialectSQLite   = "sqlite"
+)
+
+// sqlitePragmas Внешние ключи (как в Postgres) и ожидание блокировки вместо ошибки SQLITE_BUSY
+const sqlitePragmas = "_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)"
+
+// slowQueryThreshold Запросы дольше этого попадают в лог как медленные
+const slowQueryThreshold = 200 * time.Millisecond
+
 // Глобальная переменная для связи с БД через GORM
 var db *gorm.DB
 
-// InitDB Инициализация БД с подключением db к БД
-func InitDB() (*gorm.DB, error) {
+// Dialect Определяет СУБД по схеме DSN: sqlite://path и file:path - SQLite,
+// всё остальное (postgres://..., "host=... user=...") - Postgres
+func Dialect(dsn string) string {
+	if strings.HasPrefix(dsn, "sqlite:") || strings.HasPrefix(dsn, "file:") {
+		return DialectSQLite
+	}
+	return DialectPostgres
+}
+
+// Open Подключается к Postgres или к файлу SQLite в зависимости от DSN
+func Open(dsn string, config *gorm.Config) (*gorm.DB, error) {
+	if Dialect(dsn) == DialectPostgres {
+		return gorm.Open(postgres.Open(dsn), config)
+	}
 
-	// Параметры подключения
-	dsn := "host=localhost user=postgres password=yourpassword dbname=postgres port=5432 sslmode=disable"
+	database, err := gorm.Open(sqlite.Open(sqliteDSN(dsn)), config)
+	if err != nil {
+		return nil, err
+	}
+	// SQLite допускает одного писателя за раз: одно соединение убирает гонки за блокировку
+	sqlDB, err := database.DB()
+	if err != nil {
+		return nil, err
+	}
+	sqlDB.SetMaxOpenConns(1)
+	return database, nil
+}
+
+// sqliteDSN Переводит sqlite://path?params в формат драйвера (file:path?params) и добавляет pragma
+func sqliteDSN(dsn string) string {
+	dsn = strings.TrimPrefix(dsn, "sqlite://")
+	dsn = strings.TrimPrefix(dsn, "sqlite:")
+	if !strings.HasPrefix(dsn, "file:") {
+		dsn = "file:" + dsn
+	}
+	if strings.Contains(dsn, "?") {
+		return dsn + "&" + sqlitePragmas
+	}
+	return dsn + "?" + sqlitePragmas
+}
 
+// InitDB Инициализация БД с подключением db к БД по DSN
+func InitDB(dsn string) (*gorm.DB, error) {
 	// Подключение к БД
 	var err error
-	db, err = gorm.Open(postgres.Open(dsn), &gorm.Config{})
+	db, err = Open(dsn, &gorm.Config{Logger: logging.NewGormLogger(slowQueryThreshold)})
 	if err != nil {
-		log.Fatalf("Could not connect to database: %v", err)
+		return nil, fmt.Errorf("db: could not connect to %s: %w", Dialect(dsn), err)
 	}
 
-	log.Println("Database connected successfully")
+	slog.Info("database connected", slog.String("dialect", Dialect(dsn)))
 	return db, nil
 }
@@ -0,0 +1,78 @@
+// Package dbtest открывает настоящую БД для тестов репозиториев на GORM.
+// По умолчанию это файл SQLite во временном каталоге теста; если задан
+// TEST_DATABASE_DSN, тесты идут на указанной БД (например, Postgres в CI)
+package dbtest
+
+import (
+	"POSTnGETtrain/internal/db"
+	"POSTnGETtrain/internal/migrate"
+	"POSTnGETtrain/migrations"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// EnvDSN Переменная окружения с адресом тестовой БД
+const EnvDSN = "TEST_DATABASE_DSN"
+
+// Open Подключается к тестовой БД и применяет к ней все миграции.
+// Каждый тест получает пустую БД (отдельный файл SQLite или отдельную схему Postgres)
+func Open(t testing.TB) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv(EnvDSN)
+	if dsn == "" {
+		dsn = "sqlite://" + filepath.Join(t.TempDir(), "test.db")
+	}
+
+	database, err := db.Open(dsn, &gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	if err != nil {
+		t.Fatalf("dbtest: could not connect: %v", err)
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		t.Fatalf("dbtest: %v", err)
+	}
+	t.Cleanup(func() { _ = sqlDB.Close() })
+
+	if db.Dialect(dsn) == db.DialectPostgres {
+		isolateSchema(t, database)
+	}
+	migrator, err := migrate.New(database, migrations.FS)
+	if err != nil {
+		t.Fatalf("dbtest: %v", err)
+	}
+	if _, err := migrator.Up(context.Background()); err != nil {
+		t.Fatalf("dbtest: %v", err)
+	}
+	return database
+}
+
+// isolateSchema Создает для теста отдельную схему Postgres и удаляет её после теста
+func isolateSchema(t testing.TB, database *gorm.DB) {
+	t.Helper()
+
+	// Одно соединение, чтобы search_path действовал на все запросы теста
+	sqlDB, err := database.DB()
+	if err != nil {
+		t.Fatalf("dbtest: %v", err)
+	}
+	sqlDB.SetMaxOpenConns(1)
+
+	schema := "test_" + uuid.NewString()[:8]
+	steps := []string{
+		fmt.Sprintf("CREATE SCHEMA %q", schema),
+		fmt.Sprintf("SET search_path TO %q", schema),
+	}
+	for _, step := range steps {
+		if err := database.Exec(step).Error; err != nil {
+			t.Fatalf("dbtest: %s: %v", step, err)
+		}
+	}
+	t.Cleanup(func() { database.Exec(fmt.Sprintf("DROP SCHEMA %q CASCADE", schema)) })
+}
@@ -0,0 +1,109 @@
+// Package docs отдаёт спецификацию API и встроенный в бинарник Swagger UI,
+// чтобы клиенты видели ровно ту спецификацию, которая задеплоена
+package docs
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/swaggest/swgui/v5emb"
+	"gopkg.in/yaml.v3"
+)
+
+// Пути, по которым доступна документация
+const (
+	PathJSON = "/openapi.json"
+	PathYAML = "/openapi.yaml"
+	PathUI   = "/docs"
+)
+
+// Config Что подставить в отдаваемую спецификацию
+type Config struct {
+	ServerURL       string                   // Публичный адрес API (пустой - оставить servers из файла)
+	SecuritySchemes openapi3.SecuritySchemes // Схемы авторизации, включенные на сервере
+	// Extensions Поля x-... в корне спецификации: то, чего нет в OpenAPI 3.0 (например, схемы mutualTLS)
+	Extensions map[string]any
+}
+
+// Register Добавляет в Echo маршруты /openapi.json, /openapi.yaml и /docs
+func Register(e *echo.Echo, spec *openapi3.T, cfg Config) error {
+	doc, err := Document(spec, cfg)
+	if err != nil {
+		return err
+	}
+
+	// Спецификация не меняется во время работы - сериализуем её один раз
+	rawJSON, err := json.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("docs: could not render json: %w", err)
+	}
+	rawYAML, err := yaml.Marshal(doc)
+	if err != nil {
+		return fmt.Errorf("docs: could not render yaml: %w", err)
+	}
+
+	e.GET(PathJSON, func(c echo.Context) error {
+		return c.Blob(http.StatusOK, echo.MIMEApplicationJSON, rawJSON)
+	})
+	e.GET(PathYAML, func(c echo.Context) error {
+		return c.Blob(http.StatusOK, "application/yaml", rawYAML)
+	})
+
+	// Swagger UI со статикой из бинарника (без CDN)
+	ui := echo.WrapHandler(v5emb.New(doc.Info.Title, PathJSON, PathUI+"/"))
+	e.GET(PathUI, func(c echo.Context) error {
+		return c.Redirect(http.StatusMovedPermanently, PathUI+"/")
+	})
+	e.GET(PathUI+"/*", ui)
+	return nil
+}
+
+// Document Возвращает копию спецификации с адресом сервера, схемами авторизации и расширениями
+// из конфигурации. Результат проверяется: клиентам не отдается спецификация, которую не разберут генераторы
+func Document(spec *openapi3.T, cfg Config) (*openapi3.T, error) {
+	// Глубокая копия, чтобы не менять спецификацию, по которой работает валидация
+	raw, err := json.Marshal(spec)
+	if err != nil {
+		return nil, fmt.Errorf("docs: could not copy spec: %w", err)
+	}
+	doc, err := openapi3.NewLoader().LoadFromData(raw)
+	if err != nil {
+		return nil, fmt.Errorf("docs: could not copy spec: %w", err)
+	}
+
+	if cfg.ServerURL != "" {
+		doc.Servers = openapi3.Servers{{URL: strings.TrimSuffix(cfg.ServerURL, "/")}}
+	}
+
+	if len(cfg.SecuritySchemes) > 0 {
+		if doc.Components == nil {
+			doc.Components = &openapi3.Components{}
+		}
+		if doc.Components.SecuritySchemes == nil {
+			doc.Components.SecuritySchemes = openapi3.SecuritySchemes{}
+		}
+		for name, scheme := range cfg.SecuritySchemes {
+			doc.Components.SecuritySchemes[name] = scheme
+		}
+	}
+
+	for name, value := range cfg.Extensions {
+		if !strings.HasPrefix(name, "x-") {
+			return nil, fmt.Errorf("docs: extension %q must start with x-", name)
+		}
+		if doc.Extensions == nil {
+			doc.Extensions = map[string]any{}
+		}
+		doc.Extensions[name] = value
+	}
+
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("docs: invalid document: %w", err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,132 @@
+package docs
+
+import (
+	"POSTnGETtrain/openapi"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func newDocsServer(t *testing.T, cfg Config) *echo.Echo {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	e := echo.New()
+	require.NoError(t, Register(e, spec, cfg))
+	return e
+}
+
+func get(e *echo.Echo, path string) *httptest.ResponseRecorder {
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, path, nil))
+	return rec
+}
+
+func TestRegister(t *testing.T) {
+	e := newDocsServer(t, Config{
+		ServerURL: "https://api.example.com/",
+		SecuritySchemes: openapi3.SecuritySchemes{
+			"bearerAuth": &openapi3.SecuritySchemeRef{Value: openapi3.NewJWTSecurityScheme()},
+		},
+	})
+
+	t.Run("json", func(t *testing.T) {
+		rec := get(e, PathJSON)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var doc openapi3.T
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &doc))
+		assert.Equal(t, "https://api.example.com", doc.Servers[0].URL)
+		assert.Contains(t, doc.Components.SecuritySchemes, "bearerAuth")
+		assert.NotNil(t, doc.Paths.Find("/tasks"))
+	})
+
+	t.Run("yaml", func(t *testing.T) {
+		rec := get(e, PathYAML)
+		require.Equal(t, http.StatusOK, rec.Code)
+
+		var doc map[string]any
+		require.NoError(t, yaml.Unmarshal(rec.Body.Bytes(), &doc))
+		assert.Equal(t, "3.0.0", doc["openapi"])
+	})
+
+	t.Run("swagger ui без CDN", func(t *testing.T) {
+		assert.Equal(t, http.StatusMovedPermanently, get(e, PathUI).Code)
+
+		rec := get(e, PathUI+"/")
+		require.Equal(t, http.StatusOK, rec.Code)
+		assert.Contains(t, rec.Body.String(), PathJSON)
+		assert.NotContains(t, rec.Body.String(), "cdn")
+		assert.NotContains(t, rec.Body.String(), "unpkg")
+
+		assert.Equal(t, http.StatusOK, get(e, PathUI+"/swagger-ui-bundle.js").Code)
+	})
+}
+
+func TestDocumentKeepsSourceSpec(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	_, err = Document(spec, Config{ServerURL: "https://api.example.com"})
+	require.NoError(t, err)
+	assert.Equal(t, "http://localhost:8080", spec.Servers[0].URL)
+}
+
+func TestDocumentValidates(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr string
+	}{
+		{
+			name: "схема OpenAPI 3.0 и расширение",
+			cfg: Config{
+				SecuritySchemes: openapi3.SecuritySchemes{
+					"bearerAuth": &openapi3.SecuritySchemeRef{Value: openapi3.NewJWTSecurityScheme()},
+				},
+				Extensions: map[string]any{"x-mutual-tls": map[string]any{"services": []string{"billing"}}},
+			},
+		},
+		{
+			name: "схема только из OpenAPI 3.1",
+			cfg: Config{SecuritySchemes: openapi3.SecuritySchemes{
+				"mutualTLS": &openapi3.SecuritySchemeRef{Value: openapi3.NewSecurityScheme().WithType("mutualTLS")},
+			}},
+			wantErr: `security scheme "mutualTLS"`,
+		},
+		{
+			name:    "расширение без префикса x-",
+			cfg:     Config{Extensions: map[string]any{"mutual-tls": true}},
+			wantErr: "must start with x-",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			doc, err := Document(spec, tt.cfg)
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+
+			// Отданный документ снова разбирается и проходит проверку
+			raw, err := json.Marshal(doc)
+			require.NoError(t, err)
+			loaded, err := openapi3.NewLoader().LoadFromData(raw)
+			require.NoError(t, err)
+			require.NoError(t, loaded.Validate(t.Context()))
+			assert.Contains(t, loaded.Extensions, "x-mutual-tls")
+		})
+	}
+}
@@ -0,0 +1,154 @@
+package handlers
+
+import (
+	"POSTnGETtrain/internal/authService"
+	"POSTnGETtrain/internal/requestid"
+	"POSTnGETtrain/internal/userService"
+	"POSTnGETtrain/internal/web/api"
+	"POSTnGETtrain/internal/web/auth"
+	"context"
+	"errors"
+	"fmt"
+)
+
+// AuthHandler Обработчики подтверждения email, сброса пароля и двухфакторной аутентификации
+type AuthHandler struct {
+	service authService.AuthService
+}
+
+// NewAuthHandler Конструктор обработчиков подтверждения email, сброса пароля и двухфакторной аутентификации
+func NewAuthHandler(s authService.AuthService) *AuthHandler {
+	return &AuthHandler{service: s}
+}
+
+// PostAuthVerifyEmail Подтверждение email по токену из письма
+func (h *AuthHandler) PostAuthVerifyEmail(ctx context.Context, request auth.PostAuthVerifyEmailRequestObject) (
+	auth.PostAuthVerifyEmailResponseObject, error) {
+	user, err := h.service.VerifyEmail(ctx, request.Body.Token)
+	if errors.Is(err, authService.ErrInvalidToken) {
+		return auth.PostAuthVerifyEmail422JSONResponse(requestid.Error(ctx, authService.ErrInvalidToken.Error())), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("handler: could not verify email: %w", err)
+	}
+	annotateUser(ctx, user.ID)
+	return auth.PostAuthVerifyEmail204Response{}, nil
+}
+
+// PostAuthVerifyEmailResend Повторная отправка письма подтверждения.
+// Ответ одинаков для любых адресов, чтобы по нему нельзя было найти зарегистрированных пользователей
+func (h *AuthHandler) PostAuthVerifyEmailResend(ctx context.Context, request auth.PostAuthVerifyEmailResendRequestObject) (
+	auth.PostAuthVerifyEmailResendResponseObject, error) {
+	if err := h.service.ResendVerification(ctx, request.Body.Email); err != nil {
+		return nil, fmt.Errorf("handler: could not resend verification email: %w", err)
+	}
+	return auth.PostAuthVerifyEmailResend202Response{}, nil
+}
+
+// PostAuthPasswordForgot Отправка письма для сброса пароля.
+// Ответ одинаков для любых адресов, чтобы по нему нельзя было найти зарегистрированных пользователей
+func (h *AuthHandler) PostAuthPasswordForgot(ctx context.Context, request auth.PostAuthPasswordForgotRequestObject) (
+	auth.PostAuthPasswordForgotResponseObject, error) {
+	if err := h.service.ForgotPassword(ctx, request.Body.Email); err != nil {
+		return nil, fmt.Errorf("handler: could not send password reset email: %w", err)
+	}
+	return auth.PostAuthPasswordForgot202Response{}, nil
+}
+
+// PostAuthPasswordReset Смена пароля по токену из письма; пользователю с TOTP нужен еще код
+func (h *AuthHandler) PostAuthPasswordReset(ctx context.Context, request auth.PostAuthPasswordResetRequestObject) (
+	auth.PostAuthPasswordResetResponseObject, error) {
+	var code string
+	if request.Body.TOTPCode != nil {
+		code = *request.Body.TOTPCode
+	}
+	err := h.service.ResetPassword(ctx, request.Body.Token, request.Body.Password, code)
+	if errors.Is(err, authService.ErrInvalidToken) {
+		return auth.PostAuthPasswordReset422JSONResponse(requestid.Error(ctx, authService.ErrInvalidToken.Error())), nil
+	}
+	if isTOTPError(err) {
+		return auth.PostAuthPasswordReset403JSONResponse(requestid.Error(ctx, err.Error())), nil
+	}
+	if errors.Is(err, userService.ErrInvalidUser) {
+		return auth.PostAuthPasswordReset422JSONResponse(invalidUser(ctx, err)), nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("handler: could not reset password: %w", err)
+	}
+	return auth.PostAuthPasswordReset204Response{}, nil
+}
+
+// PostAuthTotpEnroll Новый секрет TOTP для приложения-аутентификатора
+func (h *AuthHandler) PostAuthTotpEnroll(ctx context.Context, request auth.PostAuthTotpEnrollRequestObject) (
+	auth.PostAuthTotpEnrollResponseObject, error) {
+	enrollment, err := h.service.EnrollTOTP(ctx, request.Body.Email, request.Body.Password)
+	switch {
+	case errors.Is(err, authService.ErrInvalidCredentials):
+		return auth.PostAuthTotpEnroll401JSONResponse(requestid.Error(ctx, err.Error())), nil
+	case errors.Is(err, authService.ErrTOTPAlreadyEnrolled):
+		return auth.PostAuthTotpEnroll409JSONResponse(requestid.Error(ctx, err.Error())), nil
+	case err != nil:
+		return nil, fmt.Errorf("handler: could not enroll totp: %w", err)
+	}
+	return auth.PostAuthTotpEnroll200JSONResponse{Secret: enrollment.Secret, OtpauthURI: enrollment.URI}, nil
+}
+
+// PostAuthTotpConfirm Включение TOTP первым кодом; коды восстановления показываются только здесь
+func (h *AuthHandler) PostAuthTotpConfirm(ctx context.Context, request auth.PostAuthTotpConfirmRequestObject) (
+	auth.PostAuthTotpConfirmResponseObject, error) {
+	codes, err := h.service.ConfirmTOTP(ctx, request.Body.Email, request.Body.Password, request.Body.Code)
+	switch {
+	case errors.Is(err, authService.ErrInvalidCredentials):
+		return auth.PostAuthTotpConfirm401JSONResponse(requestid.Error(ctx, err.Error())), nil
+	case errors.Is(err, authService.ErrTOTPAlreadyEnrolled):
+		return auth.PostAuthTotpConfirm409JSONResponse(requestid.Error(ctx, err.Error())), nil
+	case errors.Is(err, authService.ErrTOTPNotEnrolled), errors.Is(err, authService.ErrInvalidTOTPCode):
+		return auth.PostAuthTotpConfirm422JSONResponse(requestid.Error(ctx, err.Error())), nil
+	case err != nil:
+		return nil, fmt.Errorf("handler: could not confirm totp: %w", err)
+	}
+	return auth.PostAuthTotpConfirm200JSONResponse{RecoveryCodes: codes}, nil
+}
+
+// PostAuthVerifyCredentials Проверка email и пароля, а для пользователей с TOTP - вторым шагом кода
+func (h *AuthHandler) PostAuthVerifyCredentials(ctx context.Context, request auth.PostAuthVerifyCredentialsRequestObject) (
+	auth.PostAuthVerifyCredentialsResponseObject, error) {
+	body := request.Body
+	switch {
+	case body.Email != nil && body.Password != nil && body.Challenge == nil && body.Code == nil:
+		verification, err := h.service.VerifyCredentials(ctx, *body.Email, *body.Password)
+		if errors.Is(err, authService.ErrInvalidCredentials) {
+			return auth.PostAuthVerifyCredentials401JSONResponse(requestid.Error(ctx, err.Error())), nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("handler: could not verify credentials: %w", err)
+		}
+		annotateUser(ctx, verification.User.ID)
+		if verification.Challenge == "" {
+			return verified(verification.User.ID), nil
+		}
+		return auth.PostAuthVerifyCredentials200JSONResponse{
+			Status:    api.TotpRequired,
+			Challenge: &verification.Challenge,
+			ExpiresAt: &verification.ExpiresAt,
+		}, nil
+
+	case body.Challenge != nil && body.Code != nil && body.Email == nil && body.Password == nil:
+		user, err := h.service.VerifyTOTP(ctx, *body.Challenge, *body.Code)
+		if errors.Is(err, authService.ErrInvalidToken) || errors.Is(err, authService.ErrInvalidTOTPCode) {
+			return auth.PostAuthVerifyCredentials401JSONResponse(requestid.Error(ctx, err.Error())), nil
+		}
+		if err != nil {
+			return nil, fmt.Errorf("handler: could not verify totp: %w", err)
+		}
+		annotateUser(ctx, user.ID)
+		return verified(user.ID), nil
+	}
+	return auth.PostAuthVerifyCredentials422JSONResponse(
+		requestid.Error(ctx, "expected either email and password or challenge and code")), nil
+}
+
+// verified Ответ для подтвержденного пользователя
+func verified(userID string) auth.PostAuthVerifyCredentials200JSONResponse {
+	return auth.PostAuthVerifyCredentials200JSONResponse{Status: api.Verified, UserID: &userID}
+}
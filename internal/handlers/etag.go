@@ -0,0 +1,96 @@
+package handlers
+
+import (
+	"slices"
+	"strconv"
+	"strings"
+)
+
+// formatETag превращает версию ресурса в сильный ETag вида "3"
+func formatETag(version int64) string {
+	return strconv.Quote(strconv.FormatInt(version, 10))
+}
+
+// parseIfMatch разбирает заголовок If-Match: "*" или список ETag через запятую (RFC 9110, 13.1.1).
+// "*" означает любую существующую версию (anyVersion = true),
+// иначе возвращаются версии из сильных ETag: слабые в строгом сравнении не участвуют.
+// ok = false, если в заголовке есть мусор
+func parseIfMatch(header string) (versions []int64, anyVersion bool, ok bool) {
+	header = strings.TrimSpace(header)
+	if header == "*" {
+		return nil, true, true
+	}
+
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if strings.HasPrefix(candidate, "W/") {
+			continue
+		}
+		unquoted, err := strconv.Unquote(candidate)
+		if err != nil {
+			return nil, false, false
+		}
+		v, err := strconv.ParseInt(unquoted, 10, 64)
+		if err != nil {
+			return nil, false, false
+		}
+		versions = append(versions, v)
+	}
+	return versions, false, true
+}
+
+// matchIfNoneMatch проверяет, есть ли текущий ETag в заголовке If-None-Match.
+// Используется слабое сравнение: префикс W/ игнорируется (RFC 9110, 13.1.2)
+func matchIfNoneMatch(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" {
+			return true
+		}
+		if strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
+
+// Результат проверки предусловия If-Match
+type precondition int
+
+const (
+	preconditionOK       precondition = iota // Можно выполнять изменение
+	preconditionFailed                       // 412: ETag не может совпасть с текущей версией
+	preconditionRequired                     // 428: заголовок обязателен, но не передан
+)
+
+// ifMatchVersion достаёт ожидаемую версию из If-Match для PATCH/DELETE.
+// required включает обязательность заголовка (настраивается через конфиг).
+// Для списка из нескольких ETag текущая версия читается через current: если она есть в списке,
+// изменение выполняется условно на неё, и параллельная запись всё равно даст конфликт версий
+func ifMatchVersion(header *string, required bool, current func() (int64, error)) (*int64, precondition, error) {
+	if header == nil {
+		if required {
+			return nil, preconditionRequired, nil
+		}
+		return nil, preconditionOK, nil
+	}
+
+	versions, anyVersion, ok := parseIfMatch(*header)
+	switch {
+	case !ok || (!anyVersion && len(versions) == 0):
+		return nil, preconditionFailed, nil
+	case anyVersion:
+		return nil, preconditionOK, nil
+	case len(versions) == 1:
+		return &versions[0], preconditionOK, nil
+	}
+
+	version, err := current()
+	if err != nil {
+		return nil, preconditionOK, err
+	}
+	if !slices.Contains(versions, version) {
+		return nil, preconditionFailed, nil
+	}
+	return &version, preconditionOK, nil
+}
@@ -0,0 +1,84 @@
+package handlers
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIfMatchVersion(t *testing.T) {
+	header := func(s string) *string { return &s }
+	version := func(v int64) *int64 { return &v }
+	errStore := errors.New("store unavailable")
+
+	tests := []struct {
+		name     string
+		header   *string
+		required bool
+		current  func() (int64, error) // Текущая версия ресурса
+		want     *int64
+		wantRes  precondition
+		wantErr  error
+	}{
+		{name: "заголовок не передан", header: nil, required: false, want: nil, wantRes: preconditionOK},
+		{name: "заголовок обязателен", header: nil, required: true, want: nil, wantRes: preconditionRequired},
+		{name: "любая версия", header: header("*"), required: true, want: nil, wantRes: preconditionOK},
+		{name: "конкретная версия", header: header(`"7"`), required: true, want: version(7), wantRes: preconditionOK},
+		{name: "слабый ETag", header: header(`W/"7"`), required: false, want: nil, wantRes: preconditionFailed},
+		{name: "ETag без кавычек", header: header("7"), required: false, want: nil, wantRes: preconditionFailed},
+		{
+			name:    "список с текущей версией",
+			header:  header(`"5", "7"`),
+			current: func() (int64, error) { return 7, nil },
+			want:    version(7),
+			wantRes: preconditionOK,
+		},
+		{
+			name:    "список без текущей версии",
+			header:  header(`"5","6"`),
+			current: func() (int64, error) { return 7, nil },
+			want:    nil,
+			wantRes: preconditionFailed,
+		},
+		{name: "слабый ETag в списке пропускается", header: header(`W/"5", "7"`), want: version(7), wantRes: preconditionOK},
+		{name: "мусор в списке", header: header(`"5", 7`), want: nil, wantRes: preconditionFailed},
+		{
+			name:    "ошибка чтения текущей версии",
+			header:  header(`"5", "7"`),
+			current: func() (int64, error) { return 0, errStore },
+			wantErr: errStore,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			current := tt.current
+			if current == nil {
+				current = func() (int64, error) {
+					t.Fatal("текущая версия не должна читаться")
+					return 0, nil
+				}
+			}
+			got, res, err := ifMatchVersion(tt.header, tt.required, current)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.wantRes, res)
+		})
+	}
+}
+
+func TestMatchIfNoneMatch(t *testing.T) {
+	etag := formatETag(3)
+
+	assert.Equal(t, `"3"`, etag)
+	assert.True(t, matchIfNoneMatch(`"3"`, etag))
+	assert.True(t, matchIfNoneMatch(`"1", W/"3"`, etag))
+	assert.True(t, matchIfNoneMatch("*", etag))
+	assert.False(t, matchIfNoneMatch(`"2"`, etag))
+}
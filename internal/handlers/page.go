@@ -0,0 +1,18 @@
+package handlers
+
+import (
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/web/api"
+)
+
+// pageOf Собирает страницу из query-параметров limit и offset (границы проверяет валидация по спецификации)
+func pageOf(limit *api.Limit, offset *api.Offset) models.Page {
+	var page models.Page
+	if limit != nil {
+		page.Limit = *limit
+	}
+	if offset != nil {
+		page.Offset = *offset
+	}
+	return page
+}
@@ -0,0 +1,6 @@
+package handlers
+
+import "errors"
+
+// errUnsupportedPatch Тело PATCH пришло в медиатипе, который не описан в спецификации
+var errUnsupportedPatch = errors.New("unsupported patch content type")
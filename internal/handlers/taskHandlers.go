@@ -1,28 +1,35 @@
 package handlers
 
 import (
+	"POSTnGETtrain/internal/authService"
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/requestid"
 	"POSTnGETtrain/internal/taskService"
+	"POSTnGETtrain/internal/web/api"
 	"POSTnGETtrain/internal/web/tasks"
+	"POSTnGETtrain/pkg/patch"
 	"context"
+	"errors"
 	"fmt"
 )
 
 // Handler - заготовка для конструктора
 type Handler struct {
-	service taskService.TaskService // Сервис для бизнес-логики работы с задачами
+	service        taskService.TaskService // Сервис для бизнес-логики работы с задачами
+	requireIfMatch bool                    // Требовать If-Match для изменения и удаления
 }
 
 // NewHandler - сам конструктор
-func NewHandler(s taskService.TaskService) *Handler {
-	return &Handler{service: s}
+func NewHandler(s taskService.TaskService, requireIfMatch bool) *Handler {
+	return &Handler{service: s, requireIfMatch: requireIfMatch}
 }
 
-// GetTasks - ctx и request не используются, но требуются для запроса
-func (h *Handler) GetTasks(_ context.Context, _ tasks.GetTasksRequestObject) (
+// GetTasks - страница задач
+func (h *Handler) GetTasks(ctx context.Context, request tasks.GetTasksRequestObject) (
 	tasks.GetTasksResponseObject, error) {
 
-	// Получаем все задачи из сервисного слоя
-	dbTasks, err := h.service.GetAllTasks()
+	// Получаем страницу задач из сервисного слоя
+	dbTasks, err := h.service.GetAllTasks(ctx, pageOf(request.Params.Limit, request.Params.Offset))
 	if err != nil {
 		return nil, fmt.Errorf("handler: could not get all tasks: %w", err)
 	}
@@ -32,7 +39,7 @@ func (h *Handler) GetTasks(_ context.Context, _ tasks.GetTasksRequestObject) (
 
 	// Преобразуем задачи из формата сервиса в формат API
 	for _, t := range dbTasks {
-		response = append(response, tasks.Task{
+		response = append(response, api.Task{
 			ID:     t.ID,     // Идентификатор задачи
 			Name:   t.Name,   // Название задачи
 			IsDone: t.IsDone, // Статус выполнения
@@ -42,11 +49,12 @@ func (h *Handler) GetTasks(_ context.Context, _ tasks.GetTasksRequestObject) (
 	return response, nil // Возвращаем список задач
 }
 
-func (h *Handler) PostTasks(_ context.Context, request tasks.PostTasksRequestObject) (
+func (h *Handler) PostTasks(ctx context.Context, request tasks.PostTasksRequestObject) (
 	tasks.PostTasksResponseObject, error) {
 	if request.Body.UserID == "" {
 		return nil, fmt.Errorf("handler: user_id is required")
 	}
+	annotateUser(ctx, request.Body.UserID)
 	// Устанавливаем статус по умолчанию
 	isDone := false
 	if request.Body.IsDone != nil {
@@ -54,7 +62,10 @@ func (h *Handler) PostTasks(_ context.Context, request tasks.PostTasksRequestObj
 	}
 
 	// Создаем задачу с запросом в сервис
-	created, err := h.service.CreateTask(request.Body.Name, isDone, request.Body.UserID)
+	created, err := h.service.CreateTask(ctx, request.Body.Name, isDone, request.Body.UserID)
+	if errors.Is(err, authService.ErrEmailNotVerified) {
+		return tasks.PostTasks403JSONResponse(requestid.Error(ctx, err.Error())), nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("handler: could not create task: %w", err) // Обрабатываем ошибку создания
 	}
@@ -68,82 +79,181 @@ func (h *Handler) PostTasks(_ context.Context, request tasks.PostTasksRequestObj
 	}, nil
 }
 
-// GetUsersIdTasks - получить все задачи юзера
-func (h *Handler) GetUsersIdTasks(_ context.Context, request tasks.GetUsersIdTasksRequestObject) (tasks.GetUsersIdTasksResponseObject, error) {
-	tasksList, err := h.service.GetTasksByUserID(request.Id)
-	if err != nil {
-		return nil, fmt.Errorf("handler: could not get tasks for user%s: %w", request.Id, err)
-	}
-
-	response := make(tasks.GetUsersIdTasks200JSONResponse, 0)
-	for _, t := range tasksList {
-		response = append(response, tasks.Task{
-			ID:     t.ID,
-			Name:   t.Name,
-			IsDone: t.IsDone,
-			UserID: t.UserID,
-		})
-	}
-	return response, nil
-}
-
-func (h *Handler) GetTasksId(_ context.Context, request tasks.GetTasksIdRequestObject) (
+func (h *Handler) GetTasksId(ctx context.Context, request tasks.GetTasksIdRequestObject) (
 	tasks.GetTasksIdResponseObject, error) {
 	// Получаем задачу из сервиса по ID
-	task, err := h.service.GetTaskByID(request.Id)
+	task, err := h.service.GetTaskByID(ctx, request.Id)
+	if errors.Is(err, taskService.ErrTaskNotFound) {
+		return tasks.GetTasksId404Response{}, nil
+	}
 	if err != nil {
 		return nil, fmt.Errorf("handler: could not get task by ID %s: %w", request.Id, err) // Обрабатываем ошибку поиска
 	}
 
+	// У клиента уже есть актуальная версия - тело не отправляем
+	etag := formatETag(task.Version)
+	if request.Params.IfNoneMatch != nil && matchIfNoneMatch(*request.Params.IfNoneMatch, etag) {
+		return tasks.GetTasksId304Response{Headers: tasks.GetTasksId304ResponseHeaders{ETag: etag}}, nil
+	}
+
 	// Возвращаем найденную задачу
 	return tasks.GetTasksId200JSONResponse{
-		ID:     task.ID,     // ID задачи
-		Name:   task.Name,   // Название задачи
-		IsDone: task.IsDone, // Статус выполнения
-		UserID: task.UserID,
+		Body: api.Task{
+			ID:     task.ID,     // ID задачи
+			Name:   task.Name,   // Название задачи
+			IsDone: task.IsDone, // Статус выполнения
+			UserID: task.UserID,
+		},
+		Headers: tasks.GetTasksId200ResponseHeaders{ETag: etag},
 	}, nil
 }
 
-func (h *Handler) PatchTasksId(_ context.Context, request tasks.PatchTasksIdRequestObject) (
+func (h *Handler) PatchTasksId(ctx context.Context, request tasks.PatchTasksIdRequestObject) (
 	tasks.PatchTasksIdResponseObject, error) {
-	// Переменные для обновляемых полей
-	var name *string
-	var isDone *bool
-	var userID *string
-
-	// Если в запросе указано новое название, сохраняем его
-	if request.Body.Name != nil {
-		name = request.Body.Name
-	}
-	// Если в запросе указан новый статус, сохраняем его
-	if request.Body.IsDone != nil {
-		isDone = request.Body.IsDone
+	// Проверяем предусловие If-Match
+	version, check, err := ifMatchVersion(request.Params.IfMatch, h.requireIfMatch, h.taskVersion(ctx, request.Id))
+	switch {
+	case errors.Is(err, taskService.ErrTaskNotFound):
+		return tasks.PatchTasksId404Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("handler: could not read version of task %s: %w", request.Id, err)
+	case check == preconditionRequired:
+		return tasks.PatchTasksId428Response{}, nil
+	case check == preconditionFailed:
+		return tasks.PatchTasksId412Response{}, nil
 	}
 
-	if request.Body.UserID != nil {
-		userID = request.Body.UserID
+	// Собираем набор изменений из тела запроса
+	changes, version, err := h.taskChanges(ctx, request, version)
+	switch {
+	case errors.Is(err, errUnsupportedPatch):
+		return tasks.PatchTasksId415Response{}, nil
+	case errors.Is(err, taskService.ErrTaskNotFound):
+		return tasks.PatchTasksId404Response{}, nil
+	case errors.Is(err, patch.ErrInvalidPatch):
+		return tasks.PatchTasksId422JSONResponse(requestid.Error(ctx, err.Error())), nil
+	case err != nil:
+		return nil, fmt.Errorf("handler: could not read changes for task %s: %w", request.Id, err)
 	}
 
 	// Обновляем задачу через сервис
-	updated, err := h.service.UpdateTask(request.Id, name, isDone, userID)
-	if err != nil {
+	updated, err := h.service.UpdateTask(ctx, request.Id, version, changes)
+	switch {
+	case errors.Is(err, taskService.ErrTaskNotFound):
+		return tasks.PatchTasksId404Response{}, nil
+	case errors.Is(err, taskService.ErrVersionConflict):
+		return tasks.PatchTasksId412Response{}, nil
+	case errors.Is(err, authService.ErrEmailNotVerified):
+		return tasks.PatchTasksId403JSONResponse(requestid.Error(ctx, err.Error())), nil
+	case errors.Is(err, taskService.ErrInvalidTask):
+		return tasks.PatchTasksId422JSONResponse(requestid.Error(ctx, err.Error())), nil
+	case err != nil:
 		return nil, fmt.Errorf("handler: could not update task %s: %w", request.Id, err) // Обрабатываем ошибку обновления
 	}
 
 	// Возвращаем обновленную задачу
 	return tasks.PatchTasksId200JSONResponse{
-		ID:     updated.ID,     // ID задачи
-		Name:   updated.Name,   // Новое название
-		IsDone: updated.IsDone, // Новый статус
-		UserID: updated.UserID,
+		Body: api.Task{
+			ID:     updated.ID,     // ID задачи
+			Name:   updated.Name,   // Новое название
+			IsDone: updated.IsDone, // Новый статус
+			UserID: updated.UserID,
+		},
+		Headers: tasks.PatchTasksId200ResponseHeaders{ETag: formatETag(updated.Version)},
 	}, nil
 }
 
-func (h *Handler) DeleteTasksId(_ context.Context, request tasks.DeleteTasksIdRequestObject) (
+// taskChanges Собирает набор изменений из тела PATCH в зависимости от Content-Type.
+// JSON Patch применяется к текущей задаче, поэтому без If-Match версия фиксируется на прочитанной
+func (h *Handler) taskChanges(ctx context.Context, request tasks.PatchTasksIdRequestObject, version *int64) (
+	models.TaskChanges, *int64, error) {
+	switch {
+	// Проверяется первым: для json-patch+json сгенерированный код заполняет ещё и пустой JSONBody
+	case request.ApplicationJSONPatchPlusJSONBody != nil:
+		current, err := h.service.GetTaskByID(ctx, request.Id)
+		if err != nil {
+			return models.TaskChanges{}, nil, err
+		}
+		if version == nil {
+			version = &current.Version
+		}
+
+		merge, err := patch.ApplyJSONPatch(api.Task{
+			ID:     current.ID,
+			Name:   current.Name,
+			IsDone: current.IsDone,
+			UserID: current.UserID,
+		}, *request.ApplicationJSONPatchPlusJSONBody)
+		if err != nil {
+			return models.TaskChanges{}, nil, err
+		}
+
+		var mergePatch api.TaskMergePatch
+		if err := patch.DecodeMergePatch(merge, &mergePatch); err != nil {
+			return models.TaskChanges{}, nil, err
+		}
+		return taskMergeChanges(mergePatch), version, nil
+
+	case request.ApplicationMergePatchPlusJSONBody != nil:
+		return taskMergeChanges(*request.ApplicationMergePatchPlusJSONBody), version, nil
+
+	// Обычный JSON: null и отсутствующие поля одинаково означают "не менять"
+	case request.JSONBody != nil:
+		return models.TaskChanges{
+			Name:   patch.FromPointer(request.JSONBody.Name),
+			IsDone: patch.FromPointer(request.JSONBody.IsDone),
+			UserID: patch.FromPointer(request.JSONBody.UserID),
+		}, version, nil
+	}
+	return models.TaskChanges{}, nil, errUnsupportedPatch
+}
+
+// taskMergeChanges Переводит merge patch из API в набор изменений сервиса
+func taskMergeChanges(mergePatch api.TaskMergePatch) models.TaskChanges {
+	return models.TaskChanges{
+		Name:   mergePatch.Name,
+		IsDone: mergePatch.IsDone,
+		UserID: mergePatch.UserID,
+	}
+}
+
+func (h *Handler) DeleteTasksId(ctx context.Context, request tasks.DeleteTasksIdRequestObject) (
 	tasks.DeleteTasksIdResponseObject, error) {
+	// Проверяем предусловие If-Match
+	version, check, err := ifMatchVersion(request.Params.IfMatch, h.requireIfMatch, h.taskVersion(ctx, request.Id))
+	switch {
+	case errors.Is(err, taskService.ErrTaskNotFound):
+		return tasks.DeleteTasksId404Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("handler: could not read version of task %s: %w", request.Id, err)
+	case check == preconditionRequired:
+		return tasks.DeleteTasksId428Response{}, nil
+	case check == preconditionFailed:
+		return tasks.DeleteTasksId412Response{}, nil
+	}
+
 	// Удаляем задачу через сервис
-	if err := h.service.DeleteTask(request.Id); err != nil {
+	err = h.service.DeleteTask(ctx, request.Id, version)
+	switch {
+	case errors.Is(err, taskService.ErrTaskNotFound):
+		return tasks.DeleteTasksId404Response{}, nil
+	case errors.Is(err, taskService.ErrVersionConflict):
+		return tasks.DeleteTasksId412Response{}, nil
+	case errors.Is(err, authService.ErrEmailNotVerified):
+		return tasks.DeleteTasksId403JSONResponse(requestid.Error(ctx, err.Error())), nil
+	case err != nil:
 		return nil, fmt.Errorf("handler: could not delete task %s: %w", request.Id, err)
 	}
 	return tasks.DeleteTasksId204Response{}, nil
 }
+
+// taskVersion Текущая версия задачи для сравнения со списком ETag в If-Match
+func (h *Handler) taskVersion(ctx context.Context, id string) func() (int64, error) {
+	return func() (int64, error) {
+		task, err := h.service.GetTaskByID(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return task.Version, nil
+	}
+}
@@ -1,38 +1,66 @@
 package handlers
 
 import (
+	"POSTnGETtrain/internal/authService"
+	"POSTnGETtrain/internal/logging"
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/password"
+	"POSTnGETtrain/internal/requestid"
 	"POSTnGETtrain/internal/userService"
+	"POSTnGETtrain/internal/web/api"
 	"POSTnGETtrain/internal/web/users"
+	"POSTnGETtrain/pkg/patch"
 	"context"
 	"errors"
 	"fmt"
+	"log/slog"
 )
 
 // UserHandler заготовка для конструктора
 type UserHandler struct {
-	service userService.UserService // Сервис для работы с пользователями
+	service        userService.UserService // Сервис для работы с пользователями
+	requireIfMatch bool                    // Требовать If-Match для изменения и удаления
 }
 
 // NewUserHandler создает новый экземпляр UserHandler с заданным сервисом (конструктор)
-func NewUserHandler(s userService.UserService) *UserHandler {
-	return &UserHandler{service: s}
+func NewUserHandler(s userService.UserService, requireIfMatch bool) *UserHandler {
+	return &UserHandler{service: s, requireIfMatch: requireIfMatch}
+}
+
+// annotateUser Добавляет пользователя, к которому относится запрос, в логи запроса
+func annotateUser(ctx context.Context, id string) {
+	logging.Annotate(ctx, slog.String("user_id", id))
+}
+
+// invalidUser Тело ответа 422 на ErrInvalidUser. Нарушения политики паролей перечисляются по правилам
+func invalidUser(ctx context.Context, err error) api.Error {
+	body := requestid.Error(ctx, err.Error())
+	var validationErr *password.ValidationError
+	if errors.As(err, &validationErr) {
+		violations := make([]api.PasswordViolation, len(validationErr.Violations))
+		for i, violation := range validationErr.Violations {
+			violations[i] = api.PasswordViolation{Rule: string(violation.Rule), Message: violation.Message}
+		}
+		body.Violations = &violations
+	}
+	return body
 }
 
 // GetUsers обрабатывает GET-запрос для получения списка всех пользователей
-func (h *UserHandler) GetUsers(_ context.Context, _ users.GetUsersRequestObject) (users.GetUsersResponseObject, error) {
-	// Получаем список пользователей из сервиса
-	usersList, err := h.service.GetAllUsers()
+func (h *UserHandler) GetUsers(ctx context.Context, request users.GetUsersRequestObject) (users.GetUsersResponseObject, error) {
+	// Получаем страницу пользователей из сервиса
+	usersList, err := h.service.GetAllUsers(ctx, pageOf(request.Params.Limit, request.Params.Offset))
 	if err != nil {
 		return nil, fmt.Errorf("failed to get users: %w", err)
 	}
 
 	// Преобразуем пользователей в формат ответа API
-	response := make([]users.User, len(usersList))
+	response := make([]api.User, len(usersList))
 	for i, u := range usersList {
-		response[i] = users.User{
-			ID:       u.ID,
-			Email:    u.Email,
-			Password: u.Password, // Пароль не должен передаваться в API
+		response[i] = api.User{
+			ID:              u.ID,
+			Email:           u.Email,
+			EmailVerifiedAt: u.EmailVerifiedAt,
 		}
 	}
 
@@ -41,59 +69,163 @@ func (h *UserHandler) GetUsers(_ context.Context, _ users.GetUsersRequestObject)
 }
 
 // PostUsers обрабатывает POST-запрос для создания нового пользователя
-func (h *UserHandler) PostUsers(_ context.Context, request users.PostUsersRequestObject) (users.PostUsersResponseObject, error) {
+func (h *UserHandler) PostUsers(ctx context.Context, request users.PostUsersRequestObject) (users.PostUsersResponseObject, error) {
 	// Проверяем наличие тела запроса
 	if request.Body == nil {
 		return nil, errors.New("request body is required")
 	}
 
 	// Создаем пользователя через сервис
-	createdUser, err := h.service.CreateUser(request.Body.Email, request.Body.Password)
+	createdUser, err := h.service.CreateUser(ctx, request.Body.Email, request.Body.Password)
 	if err != nil {
 		if errors.Is(err, userService.ErrEmailExists) {
 			return nil, errors.New("email already exists")
 		}
+		if errors.Is(err, userService.ErrInvalidUser) {
+			return users.PostUsers422JSONResponse(invalidUser(ctx, err)), nil
+		}
 		return nil, fmt.Errorf("failed to create user: %w", err)
 	}
 
+	annotateUser(ctx, createdUser.ID)
+
 	// Возвращаем успешный ответ с данными созданного пользователя
 	return users.PostUsers201JSONResponse{
-		ID:       createdUser.ID,
-		Email:    createdUser.Email,
-		Password: createdUser.Password, // Пароль не должен передаваться в API
+		ID:              createdUser.ID,
+		Email:           createdUser.Email,
+		EmailVerifiedAt: createdUser.EmailVerifiedAt,
 	}, nil
 }
 
 // PatchUsersId обрабатывает PATCH-запрос для обновления данных пользователя по ID
-func (h *UserHandler) PatchUsersId(_ context.Context, request users.PatchUsersIdRequestObject) (users.PatchUsersIdResponseObject, error) {
-	// Обновляем пользователя через сервис
-	updatedUser, err := h.service.UpdateUser(
-		request.Id,
-		request.Body.Email,
-		request.Body.Password, // Пароль не должен передаваться в API
-	)
+func (h *UserHandler) PatchUsersId(ctx context.Context, request users.PatchUsersIdRequestObject) (users.PatchUsersIdResponseObject, error) {
+	annotateUser(ctx, request.Id)
+	// Проверяем предусловие If-Match
+	version, check, err := ifMatchVersion(request.Params.IfMatch, h.requireIfMatch, h.userVersion(ctx, request.Id))
+	switch {
+	case errors.Is(err, userService.ErrUserNotFound):
+		return users.PatchUsersId404Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read user version: %w", err)
+	case check == preconditionRequired:
+		return users.PatchUsersId428Response{}, nil
+	case check == preconditionFailed:
+		return users.PatchUsersId412Response{}, nil
+	}
+
+	// Собираем набор изменений из тела запроса
+	changes, version, err := h.userChanges(ctx, request, version)
+	switch {
+	case errors.Is(err, errUnsupportedPatch):
+		return users.PatchUsersId415Response{}, nil
+	case errors.Is(err, userService.ErrUserNotFound):
+		return users.PatchUsersId404Response{}, nil
+	case errors.Is(err, patch.ErrInvalidPatch):
+		return users.PatchUsersId422JSONResponse(requestid.Error(ctx, err.Error())), nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read user changes: %w", err)
+	}
+
+	// Обновляем пользователя через сервис; код TOTP нужен для смены пароля
+	ctx = withTOTPCode(ctx, request.Params.XTOTPCode)
+	updatedUser, err := h.service.UpdateUser(ctx, request.Id, version, changes)
 	if err != nil {
 		if errors.Is(err, userService.ErrUserNotFound) {
-			return nil, errors.New("user not found")
+			return users.PatchUsersId404Response{}, nil
+		}
+		if isTOTPError(err) {
+			return users.PatchUsersId403JSONResponse(requestid.Error(ctx, err.Error())), nil
+		}
+		if errors.Is(err, userService.ErrVersionConflict) {
+			return users.PatchUsersId412Response{}, nil
+		}
+		if errors.Is(err, userService.ErrInvalidUser) {
+			return users.PatchUsersId422JSONResponse(invalidUser(ctx, err)), nil
 		}
 		return nil, fmt.Errorf("failed to update user: %w", err)
 	}
 
 	// Возвращаем успешный ответ с обновленными данными пользователя
 	return users.PatchUsersId200JSONResponse{
-		ID:       updatedUser.ID,
-		Email:    updatedUser.Email,
-		Password: updatedUser.Password, // Пароль не должен передаваться в API
+		Body: api.User{
+			ID:              updatedUser.ID,
+			Email:           updatedUser.Email,
+			EmailVerifiedAt: updatedUser.EmailVerifiedAt,
+		},
+		Headers: users.PatchUsersId200ResponseHeaders{ETag: formatETag(updatedUser.Version)},
 	}, nil
 }
 
+// userChanges собирает набор изменений из тела PATCH в зависимости от Content-Type.
+// JSON Patch применяется к текущему пользователю, поэтому без If-Match версия фиксируется на прочитанной
+func (h *UserHandler) userChanges(ctx context.Context, request users.PatchUsersIdRequestObject, version *int64) (
+	models.UserChanges, *int64, error) {
+	switch {
+	// Проверяется первым: для json-patch+json сгенерированный код заполняет ещё и пустой JSONBody
+	case request.ApplicationJSONPatchPlusJSONBody != nil:
+		current, err := h.service.GetUserByID(ctx, request.Id)
+		if err != nil {
+			return models.UserChanges{}, nil, err
+		}
+		if version == nil {
+			version = &current.Version
+		}
+
+		merge, err := patch.ApplyJSONPatch(api.User{
+			ID:    current.ID,
+			Email: current.Email,
+		}, *request.ApplicationJSONPatchPlusJSONBody)
+		if err != nil {
+			return models.UserChanges{}, nil, err
+		}
+
+		var mergePatch api.UserMergePatch
+		if err := patch.DecodeMergePatch(merge, &mergePatch); err != nil {
+			return models.UserChanges{}, nil, err
+		}
+		return models.UserChanges{Email: mergePatch.Email, Password: mergePatch.Password}, version, nil
+
+	case request.ApplicationMergePatchPlusJSONBody != nil:
+		mergePatch := request.ApplicationMergePatchPlusJSONBody
+		return models.UserChanges{Email: mergePatch.Email, Password: mergePatch.Password}, version, nil
+
+	// Обычный JSON: null и отсутствующие поля одинаково означают "не менять"
+	case request.JSONBody != nil:
+		return models.UserChanges{
+			Email:    patch.FromPointer(request.JSONBody.Email),
+			Password: patch.FromPointer(request.JSONBody.Password),
+		}, version, nil
+	}
+	return models.UserChanges{}, nil, errUnsupportedPatch
+}
+
 // DeleteUsersId обрабатывает DELETE-запрос для удаления пользователя по ID
-func (h *UserHandler) DeleteUsersId(_ context.Context, request users.DeleteUsersIdRequestObject) (users.DeleteUsersIdResponseObject, error) {
+func (h *UserHandler) DeleteUsersId(ctx context.Context, request users.DeleteUsersIdRequestObject) (users.DeleteUsersIdResponseObject, error) {
+	annotateUser(ctx, request.Id)
+	// Проверяем предусловие If-Match
+	version, check, err := ifMatchVersion(request.Params.IfMatch, h.requireIfMatch, h.userVersion(ctx, request.Id))
+	switch {
+	case errors.Is(err, userService.ErrUserNotFound):
+		return users.DeleteUsersId404Response{}, nil
+	case err != nil:
+		return nil, fmt.Errorf("failed to read user version: %w", err)
+	case check == preconditionRequired:
+		return users.DeleteUsersId428Response{}, nil
+	case check == preconditionFailed:
+		return users.DeleteUsersId412Response{}, nil
+	}
+
 	// Удаляем пользователя через сервис
-	err := h.service.DeleteUser(request.Id)
+	err = h.service.DeleteUser(withTOTPCode(ctx, request.Params.XTOTPCode), request.Id, version)
 	if err != nil {
 		if errors.Is(err, userService.ErrUserNotFound) {
-			return nil, errors.New("user not found")
+			return users.DeleteUsersId404Response{}, nil
+		}
+		if isTOTPError(err) {
+			return users.DeleteUsersId403JSONResponse(requestid.Error(ctx, err.Error())), nil
+		}
+		if errors.Is(err, userService.ErrVersionConflict) {
+			return users.DeleteUsersId412Response{}, nil
 		}
 		return nil, fmt.Errorf("failed to delete user: %w", err)
 	}
@@ -102,16 +234,45 @@ func (h *UserHandler) DeleteUsersId(_ context.Context, request users.DeleteUsers
 	return users.DeleteUsersId204Response{}, nil
 }
 
-func (h *UserHandler) GetUsersIdTasks(_ context.Context, request users.GetUsersIdTasksRequestObject) (
+// userVersion Текущая версия пользователя для сравнения со списком ETag в If-Match
+func (h *UserHandler) userVersion(ctx context.Context, id string) func() (int64, error) {
+	return func() (int64, error) {
+		user, err := h.service.GetUserByID(ctx, id)
+		if err != nil {
+			return 0, err
+		}
+		return user.Version, nil
+	}
+}
+
+// withTOTPCode Передает код TOTP из заголовка X-TOTP-Code в сервис через контекст
+func withTOTPCode(ctx context.Context, code *string) context.Context {
+	if code == nil {
+		return ctx
+	}
+	return authService.WithTOTPCode(ctx, *code)
+}
+
+// isTOTPError Действие не подтверждено кодом TOTP: кода нет, он неверный или уже использован
+func isTOTPError(err error) bool {
+	return errors.Is(err, authService.ErrTOTPRequired) || errors.Is(err, authService.ErrInvalidTOTPCode)
+}
+
+// GetUsersIdTasks обрабатывает GET-запрос для получения всех задач пользователя
+func (h *UserHandler) GetUsersIdTasks(ctx context.Context, request users.GetUsersIdTasksRequestObject) (
 	users.GetUsersIdTasksResponseObject, error) {
-	tasks, err := h.service.GetTasksForUser(request.Id)
+	annotateUser(ctx, request.Id)
+	tasks, err := h.service.GetTasksForUser(ctx, request.Id)
 	if err != nil {
+		if errors.Is(err, userService.ErrUserNotFound) {
+			return users.GetUsersIdTasks404Response{}, nil
+		}
 		return nil, fmt.Errorf("failed to get user tasks: %w", err)
 	}
 
-	response := make([]users.Task, len(tasks))
+	response := make([]api.Task, len(tasks))
 	for i, t := range tasks {
-		response[i] = users.Task{
+		response[i] = api.Task{
 			ID:     t.ID,
 			Name:   t.Name,
 			IsDone: t.IsDone,
@@ -123,8 +284,9 @@ func (h *UserHandler) GetUsersIdTasks(_ context.Context, request users.GetUsersI
 }
 
 // GetUsersId - Метод для получения пользователя с задачами
-func (h *UserHandler) GetUsersId(_ context.Context, request users.GetUsersIdRequestObject) (users.GetUsersIdResponseObject, error) {
-	userWithTasks, err := h.service.GetUserByID(request.Id)
+func (h *UserHandler) GetUsersId(ctx context.Context, request users.GetUsersIdRequestObject) (users.GetUsersIdResponseObject, error) {
+	annotateUser(ctx, request.Id)
+	userWithTasks, err := h.service.GetUserByID(ctx, request.Id)
 	if err != nil {
 		if errors.Is(err, userService.ErrUserNotFound) {
 			return users.GetUsersId404Response{}, nil
@@ -133,18 +295,27 @@ func (h *UserHandler) GetUsersId(_ context.Context, request users.GetUsersIdRequ
 	}
 
 	// Преобразуем в формат ответа
-	taskResponse := make([]users.Task, len(userWithTasks.Tasks))
+	taskResponse := make([]api.Task, len(userWithTasks.Tasks))
 	for i, task := range userWithTasks.Tasks {
-		taskResponse[i] = users.Task{
+		taskResponse[i] = api.Task{
 			ID:     task.ID,
 			Name:   task.Name,
 			IsDone: task.IsDone,
 		}
 	}
 
+	// У клиента уже есть актуальная версия - тело не отправляем
+	etag := formatETag(userWithTasks.Version)
+	if request.Params.IfNoneMatch != nil && matchIfNoneMatch(*request.Params.IfNoneMatch, etag) {
+		return users.GetUsersId304Response{Headers: users.GetUsersId304ResponseHeaders{ETag: etag}}, nil
+	}
+
 	return users.GetUsersId200JSONResponse{
-		ID:       userWithTasks.ID,
-		Email:    userWithTasks.Email,
-		Password: userWithTasks.Password,
+		Body: api.User{
+			ID:              userWithTasks.ID,
+			Email:           userWithTasks.Email,
+			EmailVerifiedAt: userWithTasks.EmailVerifiedAt,
+		},
+		Headers: users.GetUsersId200ResponseHeaders{ETag: etag},
 	}, nil
 }
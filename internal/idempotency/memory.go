@@ -0,0 +1,89 @@
+package idempotency
+
+import (
+	"POSTnGETtrain/internal/models"
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryStore - реализация Store в памяти процесса (для тестов и локального запуска)
+type memoryStore struct {
+	mu      sync.Mutex
+	records map[recordID]models.IdempotencyKey
+}
+
+// recordID Ключ записи: ключи разных клиентов не пересекаются
+type recordID struct{ caller, key string }
+
+// NewMemoryStore Конструктор хранилища ключей в памяти
+func NewMemoryStore() Store {
+	return &memoryStore{records: make(map[recordID]models.IdempotencyKey)}
+}
+
+// Acquire Резервирует ключ, если он свободен или просрочен
+func (s *memoryStore) Acquire(_ context.Context, caller, key, fingerprint string, ttl time.Duration) (
+	*models.IdempotencyKey, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	now := time.Now()
+	id := recordID{caller: caller, key: key}
+	if existing, ok := s.records[id]; ok && existing.ExpiresAt.After(now) {
+		return &existing, false, nil
+	}
+
+	record := models.IdempotencyKey{
+		Caller:      caller,
+		Key:         key,
+		Fingerprint: fingerprint,
+		Headers:     "{}",
+		Body:        []byte{},
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+	s.records[id] = record
+	return &record, true, nil
+}
+
+// Get Возвращает актуальную запись по ключу
+func (s *memoryStore) Get(_ context.Context, caller, key string) (*models.IdempotencyKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record, ok := s.records[recordID{caller: caller, key: key}]
+	if !ok || !record.ExpiresAt.After(time.Now()) {
+		return nil, nil
+	}
+	return &record, nil
+}
+
+// Complete Сохраняет ответ на первый запрос
+func (s *memoryStore) Complete(_ context.Context, caller, key string, status int, headers string, body []byte) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := recordID{caller: caller, key: key}
+	record, ok := s.records[id]
+	if !ok {
+		return nil
+	}
+	record.Completed = true
+	record.StatusCode = status
+	record.Headers = headers
+	record.Body = body
+	s.records[id] = record
+	return nil
+}
+
+// Release Удаляет незавершенную запись
+func (s *memoryStore) Release(_ context.Context, caller, key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	id := recordID{caller: caller, key: key}
+	if record, ok := s.records[id]; ok && !record.Completed {
+		delete(s.records, id)
+	}
+	return nil
+}
@@ -0,0 +1,210 @@
+// Package idempotency реализует поддержку заголовка Idempotency-Key:
+// повтор запроса с тем же ключом получает сохраненный ответ вместо повторного выполнения
+package idempotency
+
+import (
+	"POSTnGETtrain/internal/tlsconfig"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Заголовки протокола идемпотентности
+const (
+	HeaderKey      = "Idempotency-Key"     // Ключ, который присылает клиент
+	HeaderReplayed = "Idempotent-Replayed" // Маркер ответа, взятого из хранилища
+)
+
+// maxKeyLength Ограничение длины ключа (совпадает с колонкой в миграции)
+const maxKeyLength = 255
+
+// Config Настройки middleware
+type Config struct {
+	Store        Store         // Где хранить ключи и ответы
+	TTL          time.Duration // Сколько хранить ответ
+	WaitTimeout  time.Duration // Сколько ждать завершения параллельного запроса с тем же ключом
+	PollInterval time.Duration // Как часто проверять его завершение
+	// Caller Проверенная постоянная идентичность клиента, в пределах которой уникален ключ;
+	// пустая строка - анонимный клиент. По умолчанию DefaultCaller
+	Caller func(c echo.Context) string
+}
+
+// DefaultCaller Клиент запроса: сервис по проверенному клиентскому сертификату, иначе анонимный ("").
+// IP не подходит: мобильный клиент повторяет запрос уже с другого адреса. Непроверенные заголовки
+// (например, Authorization) не учитываются: иначе клиент выбирал бы область ключей сам
+func DefaultCaller(c echo.Context) string {
+	if service, ok := tlsconfig.ServiceFromContext(c.Request().Context()); ok {
+		return "service:" + service
+	}
+	return ""
+}
+
+// scope Область ключа: идентичность клиента, а у анонимного - сам запрос. Повтор анонимного запроса
+// с тем же ключом и телом получает сохраненный ответ с любого адреса, а тот же ключ с другим телом
+// считается другим запросом: без идентичности нельзя отличить ошибку клиента от чужого совпадения ключа
+func scope(caller, fingerprint string) string {
+	if caller != "" {
+		return caller
+	}
+	return "request:" + fingerprint
+}
+
+// Middleware Возвращает Echo middleware, которое сохраняет первый ответ на запрос
+// с заголовком Idempotency-Key и отдаёт его на повторы. Запросы без заголовка не затрагиваются
+func Middleware(cfg Config) echo.MiddlewareFunc {
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = 100 * time.Millisecond
+	}
+	if cfg.Caller == nil {
+		cfg.Caller = DefaultCaller
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			key := c.Request().Header.Get(HeaderKey)
+			if key == "" {
+				return next(c)
+			}
+			if len(key) > maxKeyLength {
+				return echo.NewHTTPError(http.StatusBadRequest, "Idempotency-Key is too long")
+			}
+
+			fingerprint, err := fingerprintRequest(c.Request())
+			if err != nil {
+				return err
+			}
+
+			ctx := c.Request().Context()
+			caller := scope(cfg.Caller(c), fingerprint)
+			record, acquired, err := cfg.Store.Acquire(ctx, caller, key, fingerprint, cfg.TTL)
+			if errors.Is(err, ErrInProgress) {
+				return echo.NewHTTPError(http.StatusConflict, ErrInProgress.Error())
+			}
+			if err != nil {
+				return err
+			}
+
+			// Ключ уже использован: ждём первый запрос и повторяем его ответ
+			if !acquired {
+				if record.Fingerprint != fingerprint {
+					return echo.NewHTTPError(http.StatusUnprocessableEntity, ErrFingerprintMismatch.Error())
+				}
+				return replay(c, cfg, caller, key)
+			}
+
+			return execute(c, next, cfg.Store, caller, key)
+		}
+	}
+}
+
+// execute Выполняет запрос, записывая ответ, и сохраняет его в хранилище
+func execute(c echo.Context, next echo.HandlerFunc, store Store, caller, key string) error {
+	ctx := c.Request().Context()
+
+	response := c.Response()
+	recorder := &bodyRecorder{ResponseWriter: response.Writer}
+	response.Writer = recorder
+	defer func() { response.Writer = recorder.ResponseWriter }()
+
+	// Ошибки и 5xx не сохраняем: повтор запроса должен иметь шанс на успех
+	if err := next(c); err != nil || response.Status >= http.StatusInternalServerError {
+		if releaseErr := store.Release(ctx, caller, key); releaseErr != nil {
+			c.Logger().Errorf("could not release idempotency key: %v", releaseErr)
+		}
+		return err
+	}
+
+	headers, err := json.Marshal(response.Header())
+	if err != nil {
+		return err
+	}
+	if err := store.Complete(ctx, caller, key, response.Status, string(headers), recorder.body.Bytes()); err != nil {
+		c.Logger().Errorf("could not store idempotent response: %v", err)
+	}
+	return nil
+}
+
+// replay Отдаёт сохраненный ответ. Если первый запрос ещё выполняется,
+// ждёт его завершения не дольше WaitTimeout, а затем отвечает 409
+func replay(c echo.Context, cfg Config, caller, key string) error {
+	ctx := c.Request().Context()
+	deadline := time.Now().Add(cfg.WaitTimeout)
+
+	for {
+		record, err := cfg.Store.Get(ctx, caller, key)
+		if err != nil {
+			return err
+		}
+		if record == nil {
+			// Первый запрос завершился ошибкой и освободил ключ
+			return echo.NewHTTPError(http.StatusConflict, ErrInProgress.Error())
+		}
+		if record.Completed {
+			return writeStored(c, record.StatusCode, record.Headers, record.Body)
+		}
+		if time.Now().After(deadline) {
+			return echo.NewHTTPError(http.StatusConflict, ErrInProgress.Error())
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(cfg.PollInterval):
+		}
+	}
+}
+
+// writeStored Пишет сохраненный ответ клиенту с маркером повтора
+func writeStored(c echo.Context, status int, rawHeaders string, body []byte) error {
+	var headers http.Header
+	if err := json.Unmarshal([]byte(rawHeaders), &headers); err != nil {
+		return err
+	}
+
+	for name, values := range headers {
+		if name == echo.HeaderXRequestID {
+			continue // Идентификатор у повтора свой, а не первого запроса
+		}
+		c.Response().Header()[name] = values
+	}
+	c.Response().Header().Set(HeaderReplayed, "true")
+	c.Response().WriteHeader(status)
+	_, err := c.Response().Write(body)
+	return err
+}
+
+// fingerprintRequest Считает хэш метода, пути и тела запроса. Тело возвращается обратно в запрос
+func fingerprintRequest(req *http.Request) (string, error) {
+	var body []byte
+	if req.Body != nil {
+		var err error
+		body, err = io.ReadAll(req.Body)
+		if err != nil {
+			return "", err
+		}
+		req.Body = io.NopCloser(bytes.NewReader(body))
+	}
+
+	hash := sha256.New()
+	hash.Write([]byte(req.Method + " " + req.URL.Path + "\n"))
+	hash.Write(body)
+	return hex.EncodeToString(hash.Sum(nil)), nil
+}
+
+// bodyRecorder Копирует тело ответа, пропуская его дальше клиенту
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,229 @@
+package idempotency
+
+import (
+	"POSTnGETtrain/internal/requestid"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// newTestServer Поднимает Echo с одним POST-маршрутом, считающим вызовы
+func newTestServer(cfg Config, handler echo.HandlerFunc) *echo.Echo {
+	e := echo.New()
+	Wrap(e, Middleware(cfg)).POST("/tasks", handler)
+	return e
+}
+
+func doPost(e *echo.Echo, key, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	if key != "" {
+		req.Header.Set(HeaderKey, key)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name       string
+		firstKey   string
+		secondKey  string
+		secondBody string
+		wantStatus int
+		wantCalls  int32
+		wantReplay bool
+	}{
+		{
+			name:       "повтор с тем же ключом",
+			firstKey:   "key-1",
+			secondKey:  "key-1",
+			secondBody: `{"name":"Buy milk"}`,
+			wantStatus: http.StatusCreated,
+			wantCalls:  1,
+			wantReplay: true,
+		},
+		{
+			name:       "тот же ключ с другим телом",
+			firstKey:   "key-1",
+			secondKey:  "key-1",
+			secondBody: `{"name":"Buy bread"}`,
+			wantStatus: http.StatusUnprocessableEntity,
+			wantCalls:  1,
+		},
+		{
+			name:       "запросы без ключа",
+			secondBody: `{"name":"Buy milk"}`,
+			wantStatus: http.StatusCreated,
+			wantCalls:  2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			// Клиент с проверенной идентичностью: ключ уникален в ее пределах
+			service := func(echo.Context) string { return "service:billing" }
+			e := newTestServer(Config{Store: NewMemoryStore(), TTL: time.Hour, Caller: service}, func(c echo.Context) error {
+				n := calls.Add(1)
+				return c.JSON(http.StatusCreated, map[string]int32{"call": n})
+			})
+
+			first := doPost(e, tt.firstKey, `{"name":"Buy milk"}`)
+			second := doPost(e, tt.secondKey, tt.secondBody)
+
+			assert.Equal(t, http.StatusCreated, first.Code)
+			assert.Equal(t, tt.wantStatus, second.Code)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+			if tt.wantReplay {
+				assert.Equal(t, "true", second.Header().Get(HeaderReplayed))
+				assert.Equal(t, first.Body.String(), second.Body.String())
+			} else {
+				assert.Empty(t, second.Header().Get(HeaderReplayed))
+			}
+		})
+	}
+}
+
+func TestMiddlewareConcurrentDuplicate(t *testing.T) {
+	release := make(chan struct{})
+	started := make(chan struct{})
+	e := newTestServer(Config{
+		Store:        NewMemoryStore(),
+		TTL:          time.Hour,
+		WaitTimeout:  50 * time.Millisecond,
+		PollInterval: 5 * time.Millisecond,
+	}, func(c echo.Context) error {
+		close(started)
+		<-release
+		return c.NoContent(http.StatusCreated)
+	})
+
+	done := make(chan *httptest.ResponseRecorder)
+	go func() { done <- doPost(e, "key-1", "{}") }()
+	<-started
+
+	// Первый запрос ещё выполняется - дубликат не дожидается его и получает 409
+	duplicate := doPost(e, "key-1", "{}")
+	assert.Equal(t, http.StatusConflict, duplicate.Code)
+
+	close(release)
+	assert.Equal(t, http.StatusCreated, (<-done).Code)
+
+	// После завершения повтор получает сохраненный ответ
+	replayed := doPost(e, "key-1", "{}")
+	assert.Equal(t, http.StatusCreated, replayed.Code)
+	assert.Equal(t, "true", replayed.Header().Get(HeaderReplayed))
+}
+
+func TestMiddlewareReleasesKeyOnError(t *testing.T) {
+	var calls atomic.Int32
+	e := newTestServer(Config{Store: NewMemoryStore(), TTL: time.Hour}, func(c echo.Context) error {
+		if calls.Add(1) == 1 {
+			return echo.NewHTTPError(http.StatusInternalServerError)
+		}
+		return c.NoContent(http.StatusCreated)
+	})
+
+	assert.Equal(t, http.StatusInternalServerError, doPost(e, "key-1", "{}").Code)
+	assert.Equal(t, http.StatusCreated, doPost(e, "key-1", "{}").Code)
+	assert.Equal(t, int32(2), calls.Load())
+}
+
+func TestMiddlewareReplayKeepsRequestID(t *testing.T) {
+	e := echo.New()
+	e.Use(requestid.Middleware())
+	Wrap(e, Middleware(Config{Store: NewMemoryStore(), TTL: time.Hour})).POST("/tasks", func(c echo.Context) error {
+		return c.NoContent(http.StatusCreated)
+	})
+
+	post := func(id string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader("{}"))
+		req.Header.Set(HeaderKey, "key-1")
+		req.Header.Set(requestid.Header, id)
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	assert.Equal(t, "first", post("first").Header().Get(requestid.Header))
+	replayed := post("second")
+	assert.Equal(t, "true", replayed.Header().Get(HeaderReplayed))
+	assert.Equal(t, []string{"second"}, replayed.Header().Values(requestid.Header), "повтор несет свой идентификатор запроса")
+}
+
+func TestMiddlewareScopesKeysByCaller(t *testing.T) {
+	var calls atomic.Int32
+	e := newTestServer(Config{
+		Store: NewMemoryStore(),
+		TTL:   time.Hour,
+		// Проверенная идентичность из заголовка теста; без него клиент анонимный
+		Caller: func(c echo.Context) string { return c.Request().Header.Get("X-Test-Service") },
+	}, func(c echo.Context) error {
+		n := calls.Add(1)
+		return c.JSON(http.StatusCreated, map[string]int32{"call": n})
+	})
+	post := func(service, remoteAddr, body string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(body))
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(HeaderKey, "key-1")
+		if service != "" {
+			req.Header.Set("X-Test-Service", service)
+		}
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Тот же ключ у разных сервисов - отдельные запросы, а не 422 или чужой ответ
+	assert.Equal(t, http.StatusCreated, post("billing", "192.0.2.1:1000", `{"name":"Buy milk"}`).Code)
+	other := post("reports", "192.0.2.1:1000", `{"name":"Buy milk"}`)
+	assert.Equal(t, http.StatusCreated, other.Code)
+	assert.Empty(t, other.Header().Get(HeaderReplayed))
+	assert.Equal(t, http.StatusUnprocessableEntity, post("billing", "192.0.2.1:1000", `{"name":"Buy bread"}`).Code)
+	assert.Equal(t, int32(2), calls.Load())
+
+	// Анонимный ключ не виден сервису и наоборот
+	anonymous := post("", "192.0.2.1:1000", `{"name":"Buy milk"}`)
+	assert.Equal(t, http.StatusCreated, anonymous.Code)
+	assert.Empty(t, anonymous.Header().Get(HeaderReplayed))
+	assert.Equal(t, int32(3), calls.Load())
+
+	// Анонимный ключ с другим телом - другой запрос
+	assert.Empty(t, post("", "192.0.2.2:1000", `{"name":"Buy bread"}`).Header().Get(HeaderReplayed))
+	assert.Equal(t, int32(4), calls.Load())
+}
+
+func TestMiddlewareReplaysAnonymousRetryFromNewAddress(t *testing.T) {
+	var calls atomic.Int32
+	e := newTestServer(Config{Store: NewMemoryStore(), TTL: time.Hour}, func(c echo.Context) error {
+		n := calls.Add(1)
+		return c.JSON(http.StatusCreated, map[string]int32{"call": n})
+	})
+	post := func(remoteAddr, token string) *httptest.ResponseRecorder {
+		req := httptest.NewRequest(http.MethodPost, "/tasks", strings.NewReader(`{"name":"Buy milk"}`))
+		req.RemoteAddr = remoteAddr
+		req.Header.Set(HeaderKey, "key-1")
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token) // Непроверенный токен область не меняет
+		rec := httptest.NewRecorder()
+		e.ServeHTTP(rec, req)
+		return rec
+	}
+
+	first := post("192.0.2.1:1000", "wifi")
+	require.Equal(t, http.StatusCreated, first.Code)
+	// Клиент сменил сеть: новый адрес и порт, но это повтор того же запроса
+	replayed := post("198.51.100.7:4000", "cellular")
+	assert.Equal(t, http.StatusCreated, replayed.Code)
+	assert.Equal(t, "true", replayed.Header().Get(HeaderReplayed))
+	assert.Equal(t, first.Body.String(), replayed.Body.String())
+	assert.Equal(t, int32(1), calls.Load())
+}
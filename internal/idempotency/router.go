@@ -0,0 +1,35 @@
+package idempotency
+
+import "github.com/labstack/echo/v4"
+
+// Router Набор методов EchoRouter из сгенерированных пакетов (tasks.EchoRouter, users.EchoRouter)
+type Router interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// wrappedRouter Добавляет middleware ко всем POST-маршрутам, остальные регистрирует как есть
+type wrappedRouter struct {
+	Router
+	middleware echo.MiddlewareFunc
+}
+
+// Wrap Оборачивает роутер так, что каждая POST-операция, зарегистрированная
+// через RegisterHandlers, проходит через middleware идемпотентности:
+//
+//	tasks.RegisterHandlers(idempotency.Wrap(echoServer, mw), handler)
+func Wrap(router Router, middleware echo.MiddlewareFunc) Router {
+	return &wrappedRouter{Router: router, middleware: middleware}
+}
+
+// POST Регистрирует маршрут с middleware идемпотентности перед остальными
+func (r *wrappedRouter) POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	return r.Router.POST(path, h, append([]echo.MiddlewareFunc{r.middleware}, m...)...)
+}
@@ -0,0 +1,124 @@
+package idempotency
+
+import (
+	"POSTnGETtrain/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// Ошибки хранилища ключей
+var (
+	ErrFingerprintMismatch = errors.New("idempotency key reused with a different request")
+	ErrInProgress          = errors.New("request with this idempotency key is still in progress")
+)
+
+// Store Хранилище ключей идемпотентности. Ключи разных клиентов (caller) не пересекаются
+type Store interface {
+	// Acquire Резервирует ключ клиента за текущим запросом. acquired = false означает,
+	// что ключ уже занят, и тогда возвращается сохраненная запись
+	Acquire(ctx context.Context, caller, key, fingerprint string, ttl time.Duration) (
+		record *models.IdempotencyKey, acquired bool, err error)
+	// Get Возвращает запись по ключу клиента (nil, если её нет или срок истёк)
+	Get(ctx context.Context, caller, key string) (*models.IdempotencyKey, error)
+	// Complete Сохраняет ответ на первый запрос
+	Complete(ctx context.Context, caller, key string, status int, headers string, body []byte) error
+	// Release Освобождает ключ, если запрос не удалось выполнить
+	Release(ctx context.Context, caller, key string) error
+}
+
+// gormStore - реализация Store поверх Postgres через GORM
+type gormStore struct {
+	db *gorm.DB
+}
+
+// NewGormStore Конструктор хранилища ключей в БД
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+// Acquire Вставляет ключ, если его ещё нет. Просроченные ключи удаляются и занимаются заново
+func (s *gormStore) Acquire(ctx context.Context, caller, key, fingerprint string, ttl time.Duration) (
+	*models.IdempotencyKey, bool, error) {
+	now := time.Now()
+
+	// Освобождаем просроченный ключ, чтобы его можно было переиспользовать
+	err := s.db.WithContext(ctx).
+		Where("caller = ? AND key = ? AND expires_at <= ?", caller, key, now).
+		Delete(&models.IdempotencyKey{}).Error
+	if err != nil {
+		return nil, false, fmt.Errorf("idempotency: could not purge expired key: %w", err)
+	}
+
+	record := models.IdempotencyKey{
+		Caller:      caller,
+		Key:         key,
+		Fingerprint: fingerprint,
+		Headers:     "{}",
+		Body:        []byte{},
+		CreatedAt:   now,
+		ExpiresAt:   now.Add(ttl),
+	}
+
+	// ON CONFLICT DO NOTHING: из параллельных запросов ключ достанется только одному
+	result := s.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(&record)
+	if result.Error != nil {
+		return nil, false, fmt.Errorf("idempotency: could not acquire key: %w", result.Error)
+	}
+	if result.RowsAffected == 1 {
+		return &record, true, nil
+	}
+
+	existing, err := s.Get(ctx, caller, key)
+	if err != nil {
+		return nil, false, err
+	}
+	if existing == nil {
+		// Ключ успели освободить между вставкой и чтением - пусть клиент повторит
+		return nil, false, ErrInProgress
+	}
+	return existing, false, nil
+}
+
+// Get Читает актуальную запись по ключу
+func (s *gormStore) Get(ctx context.Context, caller, key string) (*models.IdempotencyKey, error) {
+	var record models.IdempotencyKey
+	err := s.db.WithContext(ctx).Where("caller = ? AND key = ? AND expires_at > ?", caller, key, time.Now()).First(&record).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("idempotency: could not get key: %w", err)
+	}
+	return &record, nil
+}
+
+// Complete Сохраняет статус, заголовки и тело ответа
+func (s *gormStore) Complete(ctx context.Context, caller, key string, status int, headers string, body []byte) error {
+	err := s.db.WithContext(ctx).Model(&models.IdempotencyKey{}).
+		Where("caller = ? AND key = ?", caller, key).
+		Updates(map[string]interface{}{
+			"completed":   true,
+			"status_code": status,
+			"headers":     headers,
+			"body":        body,
+		}).Error
+	if err != nil {
+		return fmt.Errorf("idempotency: could not complete key: %w", err)
+	}
+	return nil
+}
+
+// Release Удаляет незавершенную запись
+func (s *gormStore) Release(ctx context.Context, caller, key string) error {
+	err := s.db.WithContext(ctx).Where("caller = ? AND key = ? AND completed = ?", caller, key, false).
+		Delete(&models.IdempotencyKey{}).Error
+	if err != nil {
+		return fmt.Errorf("idempotency: could not release key: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,65 @@
+package idempotency
+
+import (
+	"POSTnGETtrain/internal/db/dbtest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestStore Один и тот же набор проверок для всех реализаций Store
+func TestStore(t *testing.T) {
+	implementations := map[string]func(t *testing.T) Store{
+		"memory": func(*testing.T) Store { return NewMemoryStore() },
+		"gorm":   func(t *testing.T) Store { return NewGormStore(dbtest.Open(t)) },
+	}
+
+	for name, newStore := range implementations {
+		t.Run(name, func(t *testing.T) {
+			t.Run("ключ занимается один раз", func(t *testing.T) {
+				store := newStore(t)
+				_, acquired, err := store.Acquire(t.Context(), "ip:192.0.2.1", "key-1", "fp-1", time.Hour)
+				require.NoError(t, err)
+				assert.True(t, acquired)
+
+				record, acquired, err := store.Acquire(t.Context(), "ip:192.0.2.1", "key-1", "fp-2", time.Hour)
+				require.NoError(t, err)
+				assert.False(t, acquired)
+				assert.Equal(t, "fp-1", record.Fingerprint)
+			})
+
+			t.Run("ключи разных клиентов не пересекаются", func(t *testing.T) {
+				store := newStore(t)
+				_, acquired, err := store.Acquire(t.Context(), "ip:192.0.2.1", "key-1", "fp-1", time.Hour)
+				require.NoError(t, err)
+				require.True(t, acquired)
+				require.NoError(t, store.Complete(t.Context(), "ip:192.0.2.1", "key-1", 201, "{}", []byte("first")))
+
+				_, acquired, err = store.Acquire(t.Context(), "service:billing", "key-1", "fp-2", time.Hour)
+				require.NoError(t, err)
+				assert.True(t, acquired)
+				require.NoError(t, store.Release(t.Context(), "service:billing", "key-1"))
+
+				record, err := store.Get(t.Context(), "ip:192.0.2.1", "key-1")
+				require.NoError(t, err)
+				require.NotNil(t, record)
+				assert.True(t, record.Completed)
+				assert.Equal(t, []byte("first"), record.Body)
+				record, err = store.Get(t.Context(), "service:billing", "key-1")
+				require.NoError(t, err)
+				assert.Nil(t, record)
+			})
+
+			t.Run("просроченный ключ занимается заново", func(t *testing.T) {
+				store := newStore(t)
+				_, _, err := store.Acquire(t.Context(), "ip:192.0.2.1", "key-1", "fp-1", -time.Second)
+				require.NoError(t, err)
+				_, acquired, err := store.Acquire(t.Context(), "ip:192.0.2.1", "key-1", "fp-2", time.Hour)
+				require.NoError(t, err)
+				assert.True(t, acquired)
+			})
+		})
+	}
+}
@@ -0,0 +1,48 @@
+package logging
+
+import (
+	"context"
+	"log/slog"
+	"sync"
+)
+
+// scopeKey Ключ логгера запроса в контексте
+type scopeKey struct{}
+
+// scope Логгер одного запроса. Annotate дополняет его по ходу обработки,
+// поэтому поля, найденные в обработчике, попадают и в итоговую запись о запросе
+type scope struct {
+	mu     sync.Mutex
+	logger *slog.Logger
+}
+
+// WithLogger Возвращает контекст с логгером: FromContext в сервисах и репозиториях вернет его
+func WithLogger(ctx context.Context, logger *slog.Logger) context.Context {
+	return context.WithValue(ctx, scopeKey{}, &scope{logger: logger})
+}
+
+// FromContext Логгер из контекста со всеми полями запроса, либо slog.Default()
+func FromContext(ctx context.Context) *slog.Logger {
+	if s, ok := ctx.Value(scopeKey{}).(*scope); ok {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+		return s.logger
+	}
+	return slog.Default()
+}
+
+// Annotate Добавляет поля (например, user_id) ко всем следующим записям запроса.
+// Без логгера в контексте ничего не делает
+func Annotate(ctx context.Context, attrs ...slog.Attr) {
+	s, ok := ctx.Value(scopeKey{}).(*scope)
+	if !ok || len(attrs) == 0 {
+		return
+	}
+	args := make([]any, len(attrs))
+	for i, attr := range attrs {
+		args[i] = attr
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.logger = s.logger.With(args...)
+}
@@ -0,0 +1,67 @@
+// Package logging настраивает структурированные JSON-логи на log/slog: скрытие секретов,
+// логгер запроса в контексте, журнал HTTP-запросов для Echo и SQL-логи GORM
+package logging
+
+import (
+	"io"
+	"log/slog"
+	"regexp"
+	"slices"
+	"strings"
+)
+
+// Redacted Значение, которое пишется вместо секрета
+const Redacted = "[REDACTED]"
+
+// Config Настройки логгера
+type Config struct {
+	Level        slog.Level // Минимальный уровень записей
+	RedactEmails bool       // Маскировать адреса почты: a***@example.com
+}
+
+// sensitiveKeys Части имен полей, значения которых никогда не попадают в лог.
+// Имена сравниваются без учета регистра, "_" и "-": api_key, API-Key и apiKey совпадают
+var sensitiveKeys = []string{"password", "passwd", "token", "secret", "authorization", "cookie", "apikey"}
+
+// emailPattern Адрес почты внутри произвольной строки (например, текста ошибки)
+var emailPattern = regexp.MustCompile(`([A-Za-z0-9._%+-])[A-Za-z0-9._%+-]*@([A-Za-z0-9.-]+\.[A-Za-z]{2,})`)
+
+// New Создает JSON-логгер, который сам скрывает секреты во всех полях, в том числе вложенных в группы
+func New(w io.Writer, cfg Config) *slog.Logger {
+	return slog.New(slog.NewJSONHandler(w, &slog.HandlerOptions{
+		Level:       cfg.Level,
+		ReplaceAttr: redactor(cfg.RedactEmails),
+	}))
+}
+
+// redactor Функция ReplaceAttr для обработчика: значения уже разрешены (LogValuer вызван)
+func redactor(redactEmails bool) func([]string, slog.Attr) slog.Attr {
+	return func(groups []string, attr slog.Attr) slog.Attr {
+		if isSensitive(attr.Key) || slices.ContainsFunc(groups, isSensitive) {
+			return slog.String(attr.Key, Redacted)
+		}
+		if redactEmails && attr.Value.Kind() == slog.KindString {
+			return slog.String(attr.Key, MaskEmails(attr.Value.String()))
+		}
+		return attr
+	}
+}
+
+// isSensitive Относится ли поле к секретам
+func isSensitive(key string) bool {
+	normalized := strings.ToLower(strings.NewReplacer("_", "", "-", "").Replace(key))
+	for _, sensitive := range sensitiveKeys {
+		if strings.Contains(normalized, sensitive) {
+			return true
+		}
+	}
+	return false
+}
+
+// MaskEmails Оставляет от каждого адреса почты в строке первую букву и домен
+func MaskEmails(s string) string {
+	if !strings.Contains(s, "@") {
+		return s
+	}
+	return emailPattern.ReplaceAllString(s, "${1}***@${2}")
+}
@@ -0,0 +1,208 @@
+package logging
+
+import (
+	"POSTnGETtrain/internal/requestid"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"log/slog"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/glebarez/sqlite"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	gormlogger "gorm.io/gorm/logger"
+)
+
+// records Разбирает JSON-записи лога по строкам
+func records(t *testing.T, buf *bytes.Buffer) []map[string]any {
+	t.Helper()
+	var result []map[string]any
+	for _, line := range strings.Split(strings.TrimSpace(buf.String()), "\n") {
+		if line == "" {
+			continue
+		}
+		var record map[string]any
+		require.NoError(t, json.Unmarshal([]byte(line), &record), line)
+		result = append(result, record)
+	}
+	return result
+}
+
+// secretUser Значение с LogValue, как у models.User
+type secretUser struct{ email, password string }
+
+func (u secretUser) LogValue() slog.Value {
+	return slog.GroupValue(slog.String("email", u.email), slog.String("password", u.password))
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name         string
+		redactEmails bool
+		log          func(*slog.Logger)
+		want         map[string]any
+	}{
+		{
+			name: "пароль и токены скрыты",
+			log: func(l *slog.Logger) {
+				l.Info("login", "password", "hunter2", "Access-Token", "abc", "api_key", "k", "user", "anna")
+			},
+			want: map[string]any{"password": Redacted, "Access-Token": Redacted, "api_key": Redacted, "user": "anna"},
+		},
+		{
+			name: "секреты во вложенной группе",
+			log: func(l *slog.Logger) {
+				l.Info("request", slog.Group("headers", slog.String("Authorization", "Bearer x"), slog.String("Accept", "*/*")))
+			},
+			want: map[string]any{"headers": map[string]any{"Authorization": Redacted, "Accept": "*/*"}},
+		},
+		{
+			name: "LogValuer",
+			log:  func(l *slog.Logger) { l.Info("user created", "user", secretUser{"anna@example.com", "hunter2"}) },
+			want: map[string]any{"user": map[string]any{"email": "anna@example.com", "password": Redacted}},
+		},
+		{
+			name:         "почта маскируется по настройке",
+			redactEmails: true,
+			log: func(l *slog.Logger) {
+				l.Info("failed", "email", "anna@example.com", "error", "user boris.petrov@mail.example.com exists")
+			},
+			want: map[string]any{"email": "a***@example.com", "error": "user b***@mail.example.com exists"},
+		},
+		{
+			name: "почта без маскировки",
+			log:  func(l *slog.Logger) { l.Info("created", "email", "anna@example.com") },
+			want: map[string]any{"email": "anna@example.com"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			tt.log(New(&buf, Config{Level: slog.LevelInfo, RedactEmails: tt.redactEmails}))
+			require.NotContains(t, buf.String(), "hunter2")
+
+			logged := records(t, &buf)
+			require.Len(t, logged, 1)
+			for key, want := range tt.want {
+				assert.Equal(t, want, logged[0][key], key)
+			}
+		})
+	}
+}
+
+func TestFromContext(t *testing.T) {
+	assert.Same(t, slog.Default(), FromContext(t.Context()), "без логгера в контексте")
+	Annotate(t.Context(), slog.String("user_id", "1")) // Без логгера ничего не делает
+
+	var buf bytes.Buffer
+	ctx := WithLogger(t.Context(), New(&buf, Config{}))
+	Annotate(ctx, slog.String("user_id", "42"))
+	FromContext(ctx).InfoContext(ctx, "done")
+	assert.Equal(t, "42", records(t, &buf)[0]["user_id"])
+}
+
+func TestMiddleware(t *testing.T) {
+	var buf bytes.Buffer
+	e := echo.New()
+	e.Use(requestid.Middleware())
+	e.Use(Middleware(New(&buf, Config{Level: slog.LevelDebug})))
+	e.GET("/users/:id", func(c echo.Context) error {
+		ctx := c.Request().Context()
+		Annotate(ctx, slog.String("user_id", c.Param("id")))
+		FromContext(ctx).DebugContext(ctx, "loading user")
+		switch c.Param("id") {
+		case "missing":
+			return echo.NewHTTPError(http.StatusNotFound)
+		case "broken":
+			return errors.New("connection refused")
+		}
+		return c.String(http.StatusOK, "ok")
+	})
+
+	tests := []struct {
+		name      string
+		id        string
+		requestID string
+		wantLevel string
+		wantKind  string
+		wantError string
+	}{
+		{name: "успешный запрос", id: "42", requestID: "req-1", wantLevel: "INFO"},
+		{name: "не найден", id: "missing", wantLevel: "WARN", wantKind: "not_found", wantError: "code=404, message=Not Found"},
+		{name: "ошибка сервера", id: "broken", wantLevel: "ERROR", wantKind: "internal_server_error", wantError: "connection refused"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			buf.Reset()
+			req := httptest.NewRequest(http.MethodGet, "/users/"+tt.id, nil)
+			if tt.requestID != "" {
+				req.Header.Set(requestid.Header, tt.requestID)
+			}
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			logged := records(t, &buf)
+			require.Len(t, logged, 2)
+			inner, access := logged[0], logged[1]
+
+			// Запись из обработчика и запись о запросе связаны одним request_id
+			assert.NotEmpty(t, access["request_id"])
+			assert.Equal(t, access["request_id"], inner["request_id"])
+			if tt.requestID != "" {
+				assert.Equal(t, tt.requestID, access["request_id"])
+			}
+
+			assert.Equal(t, "request", access["msg"])
+			assert.Equal(t, tt.wantLevel, access["level"])
+			assert.Equal(t, "/users/:id", access["route"])
+			assert.Equal(t, "/users/"+tt.id, access["path"])
+			assert.Equal(t, tt.id, access["user_id"])
+			assert.Contains(t, access, "latency_ms")
+			if tt.wantKind == "" {
+				assert.EqualValues(t, http.StatusOK, access["status"])
+				assert.NotContains(t, access, "error_kind")
+				return
+			}
+			assert.Equal(t, tt.wantKind, access["error_kind"])
+			assert.Equal(t, tt.wantError, access["error"])
+		})
+	}
+}
+
+func TestGormLogger(t *testing.T) {
+	database, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: NewGormLogger(time.Hour)})
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	ctx := WithLogger(t.Context(), New(&buf, Config{Level: slog.LevelDebug}).With("request_id", "req-1"))
+
+	require.NoError(t, database.WithContext(ctx).Exec("CREATE TABLE secrets (value TEXT NOT NULL)").Error)
+	require.NoError(t, database.WithContext(ctx).Exec("INSERT INTO secrets (value) VALUES (?)", "hunter2").Error)
+	require.Error(t, database.WithContext(ctx).Exec("INSERT INTO missing (value) VALUES (?)", "hunter2").Error)
+
+	assert.NotContains(t, buf.String(), "hunter2", "значения параметров не логируются")
+	logged := records(t, &buf)
+	require.Len(t, logged, 3)
+	for _, record := range logged {
+		assert.Equal(t, "req-1", record["request_id"])
+	}
+	assert.Equal(t, "DEBUG", logged[1]["level"])
+	assert.Equal(t, "INSERT INTO secrets (value) VALUES (?)", logged[1]["sql"])
+	assert.Equal(t, "ERROR", logged[2]["level"])
+	assert.Equal(t, "query failed", logged[2]["msg"])
+	assert.Contains(t, logged[2]["error"], "no such table")
+
+	// Silent отключает логи
+	buf.Reset()
+	require.NoError(t, database.WithContext(ctx).Session(&gorm.Session{Logger: NewGormLogger(0).LogMode(gormlogger.Silent)}).
+		Exec("SELECT 1").Error)
+	assert.Empty(t, buf.String())
+}
@@ -0,0 +1,82 @@
+package logging
+
+import (
+	"POSTnGETtrain/internal/requestid"
+	"context"
+	"errors"
+	"log/slog"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Middleware Возвращает Echo middleware, которое кладет в контекст запроса логгер с request_id
+// (его назначает requestid.Middleware, которое должно стоять раньше) и по завершении пишет одну
+// запись о запросе: маршрут (шаблон, а не путь), статус, длительность и вид ошибки
+func Middleware(logger *slog.Logger) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			request := c.Request()
+
+			requestLogger := logger
+			if id := requestid.FromContext(request.Context()); id != "" {
+				requestLogger = requestLogger.With(slog.String("request_id", id))
+			}
+			// Запрос внутри трассы: по trace_id записи лога находятся рядом со спанами
+			if span := trace.SpanContextFromContext(request.Context()); span.IsValid() {
+				requestLogger = requestLogger.With(slog.String("trace_id", span.TraceID().String()))
+			}
+			ctx := WithLogger(request.Context(), requestLogger)
+			c.SetRequest(request.WithContext(ctx))
+
+			err := next(c)
+			if err != nil {
+				c.Error(err) // Отправляем ответ об ошибке сейчас, чтобы знать итоговый статус
+			}
+
+			status := c.Response().Status
+			attrs := []slog.Attr{
+				slog.String("method", request.Method),
+				slog.String("route", c.Path()),
+				slog.String("path", request.URL.Path),
+				slog.Int("status", status),
+				slog.Float64("latency_ms", float64(time.Since(start).Microseconds())/1000),
+				slog.Int64("bytes_out", c.Response().Size),
+			}
+			if kind := errorKind(status, err); kind != "" {
+				attrs = append(attrs, slog.String("error_kind", kind))
+			}
+			if err != nil {
+				attrs = append(attrs, slog.String("error", err.Error()))
+			}
+
+			level := slog.LevelInfo
+			switch {
+			case status >= http.StatusInternalServerError:
+				level = slog.LevelError
+			case status >= http.StatusBadRequest:
+				level = slog.LevelWarn
+			}
+			FromContext(ctx).LogAttrs(ctx, level, "request", attrs...)
+			return nil
+		}
+	}
+}
+
+// errorKind Вид ошибки для группировки в логах: canceled, timeout или статус ответа
+// в виде not_found, unprocessable_entity, internal_server_error. Пусто для успешных ответов
+func errorKind(status int, err error) string {
+	switch {
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	case errors.Is(err, context.DeadlineExceeded):
+		return "timeout"
+	case status < http.StatusBadRequest:
+		return ""
+	}
+	return strings.ReplaceAll(strings.ToLower(http.StatusText(status)), " ", "_")
+}
@@ -0,0 +1,39 @@
+package mailer
+
+import (
+	"context"
+	"fmt"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// fileMailer Сохраняет письма файлами .eml: их открывает любой почтовый клиент
+type fileMailer struct {
+	from *mail.Address
+	dir  string
+}
+
+// NewFileMailer Создает отправителя, который складывает письма в каталог dir
+func NewFileMailer(from *mail.Address, dir string) Mailer {
+	return &fileMailer{from: from, dir: dir}
+}
+
+func (m *fileMailer) Send(ctx context.Context, msg Message) error {
+	now := time.Now()
+	data, err := encode(m.from, msg, now)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(m.dir, 0o700); err != nil {
+		return fmt.Errorf("mailer: could not create mail directory: %w", err)
+	}
+
+	// Имя из времени и случайной части: файлы упорядочены по отправке и не перезаписывают друг друга
+	name := filepath.Join(m.dir, now.UTC().Format("20060102T150405.000000000")+"-"+randomHex(4)+".eml")
+	if err := os.WriteFile(name, data, 0o600); err != nil {
+		return fmt.Errorf("mailer: could not write message: %w", err)
+	}
+	return nil
+}
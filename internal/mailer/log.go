@@ -0,0 +1,33 @@
+package mailer
+
+import (
+	"POSTnGETtrain/internal/logging"
+	"context"
+	"log/slog"
+	"net/mail"
+	"time"
+)
+
+// logMailer Пишет письма в лог вместо отправки. Текст письма (со ссылками и токенами)
+// попадает в лог, поэтому транспорт подходит только для разработки
+type logMailer struct {
+	from *mail.Address
+}
+
+// NewLogMailer Создает отправителя, который пишет письма в лог
+func NewLogMailer(from *mail.Address) Mailer {
+	return &logMailer{from: from}
+}
+
+func (m *logMailer) Send(ctx context.Context, msg Message) error {
+	if _, err := encode(m.from, msg, time.Now()); err != nil {
+		return err
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "mail logged",
+		slog.String("from", m.from.Address),
+		slog.String("to", msg.To),
+		slog.String("subject", msg.Subject),
+		slog.String("body", msg.Body),
+	)
+	return nil
+}
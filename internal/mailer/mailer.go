@@ -0,0 +1,120 @@
+// Package mailer отправляет письма пользователям. Транспорт выбирается настройкой:
+// SMTP для настоящей почты (в том числе локальный фейковый сервер вроде Mailpit),
+// файлы .eml в каталоге или запись писем в лог для локального запуска без почтового сервера
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"strings"
+	"time"
+)
+
+// Поддерживаемые транспорты
+const (
+	TransportLog  = "log"  // Письма пишутся в лог целиком (только для разработки)
+	TransportFile = "file" // Письма сохраняются файлами .eml в каталог
+	TransportSMTP = "smtp" // Письма отправляются через SMTP-сервер
+)
+
+// Ошибки пакета
+var (
+	ErrUnknownTransport = errors.New("mailer: unknown transport")
+	ErrInvalidMessage   = errors.New("mailer: invalid message")
+)
+
+// Message Письмо в виде простого текста
+type Message struct {
+	To      string // Адрес получателя
+	Subject string // Тема
+	Body    string // Текст письма
+}
+
+// Mailer Отправляет письма
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
+
+// Config Настройки отправки почты
+type Config struct {
+	Transport string // log, file или smtp
+	From      string // Отправитель: "no-reply@example.com" или "Tasks <no-reply@example.com>"
+	Dir       string // Каталог для транспорта file
+
+	SMTPAddr     string // Адрес SMTP-сервера host:port
+	SMTPUsername string // Логин (пусто - без аутентификации)
+	SMTPPassword string // Пароль
+}
+
+// New Создает отправителя писем по настройкам
+func New(cfg Config) (Mailer, error) {
+	from, err := mail.ParseAddress(cfg.From)
+	if err != nil {
+		return nil, fmt.Errorf("mailer: invalid sender %q: %w", cfg.From, err)
+	}
+	switch cfg.Transport {
+	case TransportLog:
+		return NewLogMailer(from), nil
+	case TransportFile:
+		return NewFileMailer(from, cfg.Dir), nil
+	case TransportSMTP:
+		return NewSMTPMailer(from, cfg.SMTPAddr, cfg.SMTPUsername, cfg.SMTPPassword), nil
+	default:
+		return nil, fmt.Errorf("%w %q: expected %s, %s or %s",
+			ErrUnknownTransport, cfg.Transport, TransportLog, TransportFile, TransportSMTP)
+	}
+}
+
+// encode Собирает письмо в формате RFC 5322: текст в UTF-8, quoted-printable
+func encode(from *mail.Address, msg Message, date time.Time) ([]byte, error) {
+	to, err := mail.ParseAddress(msg.To)
+	if err != nil {
+		return nil, fmt.Errorf("%w: recipient %q: %v", ErrInvalidMessage, msg.To, err)
+	}
+	if strings.ContainsAny(msg.Subject, "\r\n") {
+		return nil, fmt.Errorf("%w: subject must be a single line", ErrInvalidMessage)
+	}
+
+	var buf bytes.Buffer
+	header := func(name, value string) {
+		buf.WriteString(name + ": " + value + "\r\n")
+	}
+	header("From", from.String())
+	header("To", to.String())
+	header("Subject", mime.QEncoding.Encode("utf-8", msg.Subject))
+	header("Date", date.Format(time.RFC1123Z))
+	header("Message-ID", messageID(from))
+	header("MIME-Version", "1.0")
+	header("Content-Type", "text/plain; charset=utf-8")
+	header("Content-Transfer-Encoding", "quoted-printable")
+	buf.WriteString("\r\n")
+
+	body := quotedprintable.NewWriter(&buf)
+	if _, err := body.Write([]byte(strings.ReplaceAll(msg.Body, "\n", "\r\n"))); err != nil {
+		return nil, err
+	}
+	if err := body.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// messageID Уникальный Message-ID в домене отправителя
+func messageID(from *mail.Address) string {
+	_, domain, _ := strings.Cut(from.Address, "@")
+	return "<" + randomHex(16) + "@" + domain + ">"
+}
+
+// randomHex Случайная строка из n байт в шестнадцатеричном виде
+func randomHex(n int) string {
+	random := make([]byte, n)
+	_, _ = rand.Read(random)
+	return hex.EncodeToString(random)
+}
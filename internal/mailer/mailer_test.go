@@ -0,0 +1,153 @@
+package mailer_test
+
+import (
+	"POSTnGETtrain/internal/logging"
+	. "POSTnGETtrain/internal/mailer"
+	"POSTnGETtrain/internal/mailer/mailtest"
+	"bytes"
+	"io"
+	"log/slog"
+	"mime"
+	"mime/quotedprintable"
+	"net/mail"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testMessage = Message{
+	To:      "alice@example.com",
+	Subject: "Подтвердите email",
+	Body:    "Откройте ссылку:\nhttps://app.example.com/verify?token=abc",
+}
+
+// readMessage Разбирает письмо и декодирует тему и текст
+// (без перевода строки, который SMTP добавляет перед завершающей точкой)
+func readMessage(t *testing.T, data []byte) (*mail.Message, string, string) {
+	t.Helper()
+	msg, err := mail.ReadMessage(bytes.NewReader(data))
+	require.NoError(t, err)
+	subject, err := new(mime.WordDecoder).DecodeHeader(msg.Header.Get("Subject"))
+	require.NoError(t, err)
+	body, err := io.ReadAll(quotedprintable.NewReader(msg.Body))
+	require.NoError(t, err)
+	return msg, subject, strings.TrimSuffix(string(bytes.ReplaceAll(body, []byte("\r\n"), []byte("\n"))), "\n")
+}
+
+func TestNew(t *testing.T) {
+	tests := []struct {
+		name    string
+		cfg     Config
+		wantErr error
+	}{
+		{name: "лог", cfg: Config{Transport: TransportLog, From: "no-reply@example.com"}},
+		{name: "файлы", cfg: Config{Transport: TransportFile, From: "Tasks <no-reply@example.com>", Dir: "mail"}},
+		{name: "SMTP", cfg: Config{Transport: TransportSMTP, From: "no-reply@example.com", SMTPAddr: "localhost:25"}},
+		{name: "неизвестный транспорт", cfg: Config{Transport: "pigeon", From: "no-reply@example.com"}, wantErr: ErrUnknownTransport},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			m, err := New(tt.cfg)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.NotNil(t, m)
+		})
+	}
+
+	_, err := New(Config{Transport: TransportLog, From: "no-reply"})
+	assert.Error(t, err, "отправитель без домена")
+}
+
+func TestSMTPMailer(t *testing.T) {
+	from := &mail.Address{Name: "Tasks", Address: "no-reply@example.com"}
+
+	t.Run("без аутентификации", func(t *testing.T) {
+		server := mailtest.NewSMTPServer(t, "", "")
+		require.NoError(t, NewSMTPMailer(from, server.Addr, "", "").Send(t.Context(), testMessage))
+
+		received := server.Received()
+		require.Len(t, received, 1)
+		assert.Equal(t, "no-reply@example.com", received[0].From)
+		assert.Equal(t, []string{"alice@example.com"}, received[0].To)
+		msg, subject, body := readMessage(t, received[0].Data)
+		assert.Equal(t, `"Tasks" <no-reply@example.com>`, msg.Header.Get("From"))
+		assert.Equal(t, "<alice@example.com>", msg.Header.Get("To"))
+		assert.Equal(t, testMessage.Subject, subject)
+		assert.Equal(t, testMessage.Body, body)
+		assert.Contains(t, msg.Header.Get("Message-ID"), "@example.com>")
+	})
+
+	t.Run("с аутентификацией", func(t *testing.T) {
+		server := mailtest.NewSMTPServer(t, "tasks", "s3cret")
+		require.NoError(t, NewSMTPMailer(from, server.Addr, "tasks", "s3cret").Send(t.Context(), testMessage))
+		assert.Len(t, server.Received(), 1)
+
+		assert.Error(t, NewSMTPMailer(from, server.Addr, "tasks", "wrong").Send(t.Context(), testMessage))
+		assert.Error(t, NewSMTPMailer(from, server.Addr, "", "").Send(t.Context(), testMessage), "сервер требует AUTH")
+		assert.Len(t, server.Received(), 1)
+	})
+
+	t.Run("сервер недоступен", func(t *testing.T) {
+		server := mailtest.NewSMTPServer(t, "", "")
+		addr := server.Addr
+		require.NoError(t, server.Close()) // Порт освобожден: подключиться к нему уже нельзя
+		assert.Error(t, NewSMTPMailer(from, addr, "", "").Send(t.Context(), testMessage))
+	})
+}
+
+func TestFileMailer(t *testing.T) {
+	dir := filepath.Join(t.TempDir(), "mail")
+	m := NewFileMailer(&mail.Address{Address: "no-reply@example.com"}, dir)
+	require.NoError(t, m.Send(t.Context(), testMessage))
+	require.NoError(t, m.Send(t.Context(), testMessage))
+
+	files, err := filepath.Glob(filepath.Join(dir, "*.eml"))
+	require.NoError(t, err)
+	require.Len(t, files, 2, "письма не перезаписывают друг друга")
+
+	data, err := os.ReadFile(files[0])
+	require.NoError(t, err)
+	msg, subject, body := readMessage(t, data)
+	assert.Equal(t, testMessage.Subject, subject)
+	assert.Equal(t, testMessage.Body, body)
+	date, err := msg.Header.Date()
+	require.NoError(t, err)
+	assert.WithinDuration(t, time.Now(), date, time.Minute)
+}
+
+func TestLogMailer(t *testing.T) {
+	var logs bytes.Buffer
+	ctx := logging.WithLogger(t.Context(), slog.New(slog.NewJSONHandler(&logs, nil)))
+
+	m := NewLogMailer(&mail.Address{Address: "no-reply@example.com"})
+	require.NoError(t, m.Send(ctx, testMessage))
+	assert.Contains(t, logs.String(), `"to":"alice@example.com"`)
+	assert.Contains(t, logs.String(), "verify?token=abc")
+}
+
+func TestInvalidMessage(t *testing.T) {
+	m := NewLogMailer(&mail.Address{Address: "no-reply@example.com"})
+
+	tests := []struct {
+		name string
+		msg  Message
+	}{
+		{name: "получатель без адреса", msg: Message{To: "alice", Subject: "Hi"}},
+		{name: "перевод строки в теме", msg: Message{To: "alice@example.com", Subject: "Hi\r\nBcc: eve@example.com"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.ErrorIs(t, m.Send(t.Context(), tt.msg), ErrInvalidMessage)
+		})
+	}
+}
@@ -0,0 +1,178 @@
+// Package mailtest содержит отправителя, который запоминает письма, и фейковый SMTP-сервер для тестов
+package mailtest
+
+import (
+	"POSTnGETtrain/internal/mailer"
+	"bytes"
+	"context"
+	"encoding/base64"
+	"net"
+	"net/textproto"
+	"strings"
+	"sync"
+	"testing"
+)
+
+// Outbox Отправитель, который запоминает письма вместо отправки
+type Outbox struct {
+	mu       sync.Mutex
+	messages []mailer.Message
+	Err      error // Если задана, Send возвращает ее и ничего не запоминает
+}
+
+func (o *Outbox) Send(_ context.Context, msg mailer.Message) error {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	if o.Err != nil {
+		return o.Err
+	}
+	o.messages = append(o.messages, msg)
+	return nil
+}
+
+// Messages Отправленные письма в порядке отправки
+func (o *Outbox) Messages() []mailer.Message {
+	o.mu.Lock()
+	defer o.mu.Unlock()
+	return append([]mailer.Message(nil), o.messages...)
+}
+
+// Received Письмо, принятое фейковым SMTP-сервером
+type Received struct {
+	From string   // Адрес из MAIL FROM
+	To   []string // Адреса из RCPT TO
+	Data []byte   // Письмо целиком, как его передал клиент
+}
+
+// SMTPServer Фейковый SMTP-сервер на свободном порту localhost. Понимает ровно столько
+// протокола, сколько нужно net/smtp: EHLO, AUTH PLAIN, MAIL, RCPT, DATA, RSET, NOOP и QUIT
+type SMTPServer struct {
+	Addr string // Адрес host:port
+
+	username, password string
+	listener           net.Listener
+
+	mu       sync.Mutex
+	received []Received
+}
+
+// NewSMTPServer Запускает сервер до конца теста. С непустым username сервер требует AUTH PLAIN
+func NewSMTPServer(t testing.TB, username, password string) *SMTPServer {
+	t.Helper()
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("mailtest: %v", err)
+	}
+	s := &SMTPServer{Addr: listener.Addr().String(), username: username, password: password, listener: listener}
+	go s.serve()
+	t.Cleanup(func() { _ = s.Close() })
+	return s
+}
+
+// Received Принятые письма в порядке получения
+func (s *SMTPServer) Received() []Received {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]Received(nil), s.received...)
+}
+
+// Close Останавливает сервер раньше конца теста
+func (s *SMTPServer) Close() error {
+	return s.listener.Close()
+}
+
+func (s *SMTPServer) serve() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			return
+		}
+		go s.session(textproto.NewConn(conn))
+	}
+}
+
+// session Один SMTP-диалог
+func (s *SMTPServer) session(conn *textproto.Conn) {
+	defer conn.Close()
+	var (
+		authenticated = s.username == ""
+		current       Received
+	)
+	reply := func(format string, args ...any) bool {
+		return conn.PrintfLine(format, args...) == nil
+	}
+	if !reply("220 mailtest ESMTP") {
+		return
+	}
+
+	for {
+		line, err := conn.ReadLine()
+		if err != nil {
+			return
+		}
+		verb, arg, _ := strings.Cut(line, " ")
+		var ok bool
+		switch strings.ToUpper(verb) {
+		case "EHLO", "HELO":
+			if s.username != "" {
+				ok = reply("250-mailtest") && reply("250 AUTH PLAIN")
+			} else {
+				ok = reply("250 mailtest")
+			}
+		case "AUTH":
+			mechanism, initial, _ := strings.Cut(arg, " ")
+			credentials, _ := base64.StdEncoding.DecodeString(initial)
+			if strings.EqualFold(mechanism, "PLAIN") && s.username != "" &&
+				bytes.Equal(credentials, []byte("\x00"+s.username+"\x00"+s.password)) {
+				authenticated = true
+				ok = reply("235 authenticated")
+			} else {
+				ok = reply("535 authentication failed")
+			}
+		case "MAIL":
+			if !authenticated {
+				ok = reply("530 authentication required")
+				break
+			}
+			current = Received{From: address(arg)}
+			ok = reply("250 ok")
+		case "RCPT":
+			current.To = append(current.To, address(arg))
+			ok = reply("250 ok")
+		case "DATA":
+			if !reply("354 end data with <CR><LF>.<CR><LF>") {
+				return
+			}
+			data, err := conn.ReadDotBytes()
+			if err != nil {
+				return
+			}
+			current.Data = data
+			s.mu.Lock()
+			s.received = append(s.received, current)
+			s.mu.Unlock()
+			current = Received{}
+			ok = reply("250 queued")
+		case "RSET":
+			current = Received{}
+			ok = reply("250 ok")
+		case "NOOP":
+			ok = reply("250 ok")
+		case "QUIT":
+			reply("221 bye")
+			return
+		default:
+			ok = reply("502 command not implemented")
+		}
+		if !ok {
+			return
+		}
+	}
+}
+
+// address Адрес из аргумента вида "FROM:<alice@example.com>"
+func address(arg string) string {
+	_, value, _ := strings.Cut(arg, ":")
+	value, _, _ = strings.Cut(strings.TrimSpace(value), " ") // Без параметров вроде BODY=8BITMIME
+	return strings.Trim(value, "<>")
+}
@@ -0,0 +1,98 @@
+package mailer
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"net"
+	"net/mail"
+	"net/smtp"
+	"time"
+)
+
+// smtpTimeout Сколько ждать SMTP-сервер, если у ctx нет своего срока
+const smtpTimeout = 30 * time.Second
+
+// smtpMailer Отправляет письма через SMTP-сервер. STARTTLS включается, если сервер его
+// предлагает; логин и пароль передаются только по TLS или на localhost (см. smtp.PlainAuth)
+type smtpMailer struct {
+	from               *mail.Address
+	addr               string
+	username, password string
+}
+
+// NewSMTPMailer Создает отправителя через SMTP-сервер addr (host:port)
+func NewSMTPMailer(from *mail.Address, addr, username, password string) Mailer {
+	return &smtpMailer{from: from, addr: addr, username: username, password: password}
+}
+
+func (m *smtpMailer) Send(ctx context.Context, msg Message) error {
+	data, err := encode(m.from, msg, time.Now())
+	if err != nil {
+		return err
+	}
+	host, _, err := net.SplitHostPort(m.addr)
+	if err != nil {
+		return fmt.Errorf("mailer: invalid SMTP address %q: %w", m.addr, err)
+	}
+
+	var dialer net.Dialer
+	conn, err := dialer.DialContext(ctx, "tcp", m.addr)
+	if err != nil {
+		return fmt.Errorf("mailer: could not connect to SMTP server: %w", err)
+	}
+	deadline, ok := ctx.Deadline()
+	if !ok {
+		deadline = time.Now().Add(smtpTimeout)
+	}
+	if err := conn.SetDeadline(deadline); err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("mailer: %w", err)
+	}
+
+	client, err := smtp.NewClient(conn, host)
+	if err != nil {
+		_ = conn.Close()
+		return fmt.Errorf("mailer: SMTP handshake failed: %w", err)
+	}
+	defer client.Close()
+	if err := m.send(client, host, msg.To, data); err != nil {
+		return fmt.Errorf("mailer: could not send message: %w", err)
+	}
+	return nil
+}
+
+// send Диалог с сервером после приветствия
+func (m *smtpMailer) send(client *smtp.Client, host, to string, data []byte) error {
+	if ok, _ := client.Extension("STARTTLS"); ok {
+		if err := client.StartTLS(&tls.Config{ServerName: host, MinVersion: tls.VersionTLS12}); err != nil {
+			return err
+		}
+	}
+	if m.username != "" {
+		if err := client.Auth(smtp.PlainAuth("", m.username, m.password, host)); err != nil {
+			return err
+		}
+	}
+	recipient, err := mail.ParseAddress(to)
+	if err != nil {
+		return err
+	}
+	if err := client.Mail(m.from.Address); err != nil {
+		return err
+	}
+	if err := client.Rcpt(recipient.Address); err != nil {
+		return err
+	}
+	w, err := client.Data()
+	if err != nil {
+		return err
+	}
+	if _, err := w.Write(data); err != nil {
+		return err
+	}
+	if err := w.Close(); err != nil {
+		return err
+	}
+	return client.Quit()
+}
@@ -0,0 +1,87 @@
+package metrics
+
+import (
+	"errors"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"gorm.io/gorm"
+)
+
+// startKey Ключ времени начала запроса в настройках экземпляра gorm.DB
+const startKey = "metrics:start"
+
+var (
+	dbQueryDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "db_query_duration_seconds",
+		Help:    "Длительность SQL-запросов GORM по типу операции и таблице.",
+		Buckets: []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"operation", "table"})
+	dbQueryErrors = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "db_query_errors_total",
+		Help: "SQL-запросы GORM, завершившиеся ошибкой (кроме отсутствия записи).",
+	}, []string{"operation", "table"})
+)
+
+// InstrumentDB Подключает к БД плагин метрик запросов и экспортирует статистику пула sql.DB
+func InstrumentDB(database *gorm.DB) error {
+	if err := database.Use(gormPlugin{}); err != nil && !errors.Is(err, gorm.ErrRegistered) {
+		return err
+	}
+	sqlDB, err := database.DB()
+	if err != nil {
+		return err
+	}
+	// Повторное подключение к той же СУБД (например, в тестах) не ошибка
+	err = Registry.Register(collectors.NewDBStatsCollector(sqlDB, database.Dialector.Name()))
+	if errors.As(err, &prometheus.AlreadyRegisteredError{}) {
+		return nil
+	}
+	return err
+}
+
+// gormPlugin Засекает время каждого запроса колбэками до и после встроенных колбэков GORM
+type gormPlugin struct{}
+
+func (gormPlugin) Name() string { return "metrics" }
+
+func (gormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register("metrics:before_create", beforeQuery),
+		callback.Create().After("gorm:create").Register("metrics:after_create", afterQuery("create")),
+		callback.Query().Before("gorm:query").Register("metrics:before_query", beforeQuery),
+		callback.Query().After("gorm:query").Register("metrics:after_query", afterQuery("query")),
+		callback.Update().Before("gorm:update").Register("metrics:before_update", beforeQuery),
+		callback.Update().After("gorm:update").Register("metrics:after_update", afterQuery("update")),
+		callback.Delete().Before("gorm:delete").Register("metrics:before_delete", beforeQuery),
+		callback.Delete().After("gorm:delete").Register("metrics:after_delete", afterQuery("delete")),
+		callback.Row().Before("gorm:row").Register("metrics:before_row", beforeQuery),
+		callback.Row().After("gorm:row").Register("metrics:after_row", afterQuery("row")),
+		callback.Raw().Before("gorm:raw").Register("metrics:before_raw", beforeQuery),
+		callback.Raw().After("gorm:raw").Register("metrics:after_raw", afterQuery("raw")),
+	)
+}
+
+func beforeQuery(db *gorm.DB) {
+	db.InstanceSet(startKey, time.Now())
+}
+
+func afterQuery(operation string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		value, ok := db.InstanceGet(startKey)
+		if !ok {
+			return
+		}
+		start, ok := value.(time.Time)
+		if !ok {
+			return
+		}
+		table := db.Statement.Table
+		dbQueryDuration.WithLabelValues(operation, table).Observe(time.Since(start).Seconds())
+		if db.Error != nil && !errors.Is(db.Error, gorm.ErrRecordNotFound) {
+			dbQueryErrors.WithLabelValues(operation, table).Inc()
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// otherOperation Метка для маршрутов вне спецификации (/docs, /metrics) и несуществующих путей
+const otherOperation = "other"
+
+// wrapperType Тип, методы которого сгенерированный RegisterHandlers регистрирует как обработчики.
+// Имя метода совпадает с ID операции: GetTasks, PatchTasksId, GetUsersIdTasks
+const wrapperType = ".(*ServerInterfaceWrapper)."
+
+var (
+	httpRequests = prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "http_requests_total",
+		Help: "HTTP-запросы по операциям OpenAPI и статусам ответа.",
+	}, []string{"operation", "status"})
+	httpDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "http_request_duration_seconds",
+		Help:    "Длительность обработки HTTP-запросов по операциям OpenAPI и статусам ответа.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"operation", "status"})
+)
+
+// Middleware Возвращает Echo middleware, которое считает запросы и их длительность.
+// Метка operation - ID операции из сгенерированного роутера, а не путь: /tasks/{id}
+// дает одну серию GetTasksId вместо серии на каждый ID
+func Middleware() echo.MiddlewareFunc {
+	var (
+		once       sync.Once
+		operations map[string]string
+	)
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			// К первому запросу все маршруты уже зарегистрированы
+			once.Do(func() { operations = Operations(c.Echo().Routes()) })
+
+			start := time.Now()
+			err := next(c)
+
+			operation, ok := operations[c.Request().Method+" "+c.Path()]
+			if !ok {
+				operation = otherOperation
+			}
+			status := strconv.Itoa(responseStatus(c, err))
+			httpRequests.WithLabelValues(operation, status).Inc()
+			httpDuration.WithLabelValues(operation, status).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
+
+// Operations ID операций сгенерированных обработчиков по ключу "METHOD /path/:param"
+func Operations(routes []*echo.Route) map[string]string {
+	operations := make(map[string]string)
+	for _, route := range routes {
+		if id, ok := operationID(route.Name); ok {
+			operations[route.Method+" "+route.Path] = id
+		}
+	}
+	return operations
+}
+
+// operationID Извлекает ID операции из имени обработчика, которое Echo дает маршруту:
+// "POSTnGETtrain/internal/web/tasks.(*ServerInterfaceWrapper).GetTasksId-fm" -> GetTasksId
+func operationID(handlerName string) (string, bool) {
+	_, method, ok := strings.Cut(handlerName, wrapperType)
+	if !ok || method == "" {
+		return "", false
+	}
+	return strings.TrimSuffix(method, "-fm"), true
+}
+
+// responseStatus Статус ответа. Ошибку Echo превратит в ответ позже, поэтому статус берется из нее
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	return http.StatusInternalServerError
+}
@@ -0,0 +1,48 @@
+// Package metrics собирает метрики Prometheus: HTTP-запросы по операциям OpenAPI,
+// запросы GORM, пул соединений с БД и бизнес-события. Метрики отдаются на Path
+package metrics
+
+import (
+	"net/http"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+// Path Адрес, по которому Prometheus забирает метрики
+const Path = "/metrics"
+
+// Registry Реестр всех метрик приложения (плюс метрики рантайма Go и процесса)
+var Registry = prometheus.NewRegistry()
+
+// Бизнес-события. Считаются в сервисах, поэтому не зависят от хранилища и транспорта
+var (
+	TasksCreated = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "tasks_created_total",
+		Help: "Создано задач.",
+	})
+	TasksCompleted = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "tasks_completed_total",
+		Help: "Задач переведено в выполненные.",
+	})
+	UsersRegistered = prometheus.NewCounter(prometheus.CounterOpts{
+		Name: "users_registered_total",
+		Help: "Зарегистрировано пользователей.",
+	})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		httpRequests, httpDuration,
+		dbQueryDuration, dbQueryErrors,
+		TasksCreated, TasksCompleted, UsersRegistered,
+	)
+}
+
+// Handler Отдает метрики реестра в формате Prometheus
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
@@ -0,0 +1,122 @@
+package metrics
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// ServerInterfaceWrapper Повторяет тип-обертку из сгенерированного кода: имена маршрутов такие же
+type ServerInterfaceWrapper struct{}
+
+func (*ServerInterfaceWrapper) GetTasksId(c echo.Context) error {
+	switch c.Param("id") {
+	case "missing":
+		return echo.NewHTTPError(http.StatusNotFound)
+	case "broken":
+		return errors.New("connection refused")
+	}
+	return c.NoContent(http.StatusOK)
+}
+
+func TestOperationID(t *testing.T) {
+	tests := []struct {
+		name    string
+		handler string
+		want    string
+		wantOK  bool
+	}{
+		{name: "сгенерированный обработчик", handler: "POSTnGETtrain/internal/web/tasks.(*ServerInterfaceWrapper).GetTasksId-fm", want: "GetTasksId", wantOK: true},
+		{name: "вложенный путь", handler: "POSTnGETtrain/internal/web/users.(*ServerInterfaceWrapper).GetUsersIdTasks-fm", want: "GetUsersIdTasks", wantOK: true},
+		{name: "обычная функция", handler: "POSTnGETtrain/internal/docs.Register.func1"},
+		{name: "пустое имя", handler: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := operationID(tt.handler)
+			assert.Equal(t, tt.wantOK, ok)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestMiddleware(t *testing.T) {
+	wrapper := &ServerInterfaceWrapper{}
+	e := echo.New()
+	e.Use(Middleware())
+	e.GET("/tasks/:id", wrapper.GetTasksId)
+	e.GET(Path, echo.WrapHandler(Handler()))
+
+	tests := []struct {
+		name          string
+		path          string
+		wantOperation string
+		wantStatus    string
+	}{
+		{name: "успешный запрос", path: "/tasks/1", wantOperation: "GetTasksId", wantStatus: "200"},
+		{name: "другой ID - та же серия", path: "/tasks/2", wantOperation: "GetTasksId", wantStatus: "200"},
+		{name: "ошибка Echo", path: "/tasks/missing", wantOperation: "GetTasksId", wantStatus: "404"},
+		{name: "ошибка обработчика", path: "/tasks/broken", wantOperation: "GetTasksId", wantStatus: "500"},
+		{name: "несуществующий путь", path: "/nope/1", wantOperation: otherOperation, wantStatus: "404"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			counter := httpRequests.WithLabelValues(tt.wantOperation, tt.wantStatus)
+			before := testutil.ToFloat64(counter)
+
+			e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, tt.path, nil))
+			assert.Equal(t, before+1, testutil.ToFloat64(counter))
+		})
+	}
+
+	// Серии по шаблону пути, а не по каждому ID
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	body := rec.Body.String()
+	assert.Contains(t, body, `http_request_duration_seconds_count{operation="GetTasksId",status="200"}`)
+	assert.NotContains(t, body, "/tasks/1")
+	assert.Contains(t, body, "tasks_created_total")
+}
+
+func TestInstrumentDB(t *testing.T) {
+	database, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, InstrumentDB(database))
+	require.NoError(t, InstrumentDB(database), "повторный вызов не ошибка")
+
+	type widget struct {
+		ID   int
+		Name string
+	}
+	require.NoError(t, database.AutoMigrate(&widget{}))
+	require.NoError(t, database.Create(&widget{Name: "a"}).Error)
+	require.NoError(t, database.Find(&[]widget{}).Error)
+
+	errorsBefore := testutil.ToFloat64(dbQueryErrors.WithLabelValues("query", "missing"))
+	require.Error(t, database.Table("missing").Find(&[]widget{}).Error)
+	assert.Equal(t, errorsBefore+1, testutil.ToFloat64(dbQueryErrors.WithLabelValues("query", "missing")))
+
+	// Отсутствие записи ошибкой не считается
+	queryErrors := testutil.ToFloat64(dbQueryErrors.WithLabelValues("query", "widgets"))
+	require.ErrorIs(t, database.First(&widget{}, 100).Error, gorm.ErrRecordNotFound)
+	assert.Equal(t, queryErrors, testutil.ToFloat64(dbQueryErrors.WithLabelValues("query", "widgets")))
+
+	// Серии по запросам и статистика пула соединений
+	rec := httptest.NewRecorder()
+	Handler().ServeHTTP(rec, httptest.NewRequest(http.MethodGet, Path, nil))
+	body := rec.Body.String()
+	assert.Contains(t, body, `db_query_duration_seconds_count{operation="create",table="widgets"}`)
+	assert.Contains(t, body, `go_sql_open_connections{db_name="sqlite"}`)
+}
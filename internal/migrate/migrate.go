@@ -0,0 +1,273 @@
+// Package migrate применяет встроенные в бинарник SQL-миграции.
+// Версия схемы хранится в таблице schema_migrations в том же формате, что у
+// golang-migrate, поэтому базы, размеченные внешней утилитой migrate, подхватываются как есть
+package migrate
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"regexp"
+	"sort"
+	"strconv"
+
+	"gorm.io/gorm"
+)
+
+// Ошибки раннера
+var (
+	ErrDirty       = errors.New("migrate: database is dirty, fix it manually and run force")
+	ErrNoMigration = errors.New("migrate: no migration with this version")
+)
+
+// lockKey Ключ advisory lock в Postgres: пока он захвачен, другие реплики ждут
+const lockKey int64 = 7312468145091
+
+// fileName Имя файла миграции: <версия>_<название>.up.sql или .down.sql
+var fileName = regexp.MustCompile(`^(\d+)_(.+)\.(up|down)\.sql$`)
+
+// Migration Одна миграция
+type Migration struct {
+	Version int64
+	Name    string
+	Up      string
+	Down    string
+}
+
+// Status Состояние схемы
+type Status struct {
+	Version int64 // Последняя примененная миграция (0 - ни одной)
+	Dirty   bool  // Миграция Version упала на середине
+	Latest  int64 // Последняя известная бинарнику миграция
+	Pending []Migration
+}
+
+// Behind Отстает ли схема от бинарника (или находится в неизвестном состоянии)
+func (s Status) Behind() bool {
+	return s.Dirty || s.Version < s.Latest
+}
+
+// Migrator Применяет миграции к БД
+type Migrator struct {
+	db         *gorm.DB
+	migrations []Migration // По возрастанию версии
+}
+
+// New Читает миграции из fsys и создает раннер
+func New(db *gorm.DB, fsys fs.FS) (*Migrator, error) {
+	migrations, err := Load(fsys)
+	if err != nil {
+		return nil, err
+	}
+	return &Migrator{db: db, migrations: migrations}, nil
+}
+
+// Load Собирает миграции из файлов. У каждой версии должны быть и up, и down
+func Load(fsys fs.FS) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, fmt.Errorf("migrate: could not read migrations: %w", err)
+	}
+
+	byVersion := make(map[int64]*Migration)
+	for _, entry := range entries {
+		match := fileName.FindStringSubmatch(entry.Name())
+		if match == nil {
+			continue // migrations.go и прочие файлы
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("migrate: bad version in %s: %w", entry.Name(), err)
+		}
+		script, err := fs.ReadFile(fsys, entry.Name())
+		if err != nil {
+			return nil, fmt.Errorf("migrate: could not read %s: %w", entry.Name(), err)
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: match[2]}
+			byVersion[version] = m
+		}
+		if match[3] == "up" {
+			m.Up = string(script)
+		} else {
+			m.Down = string(script)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		if m.Up == "" || m.Down == "" {
+			return nil, fmt.Errorf("migrate: migration %d_%s needs both up and down files", m.Version, m.Name)
+		}
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// Status Возвращает текущую версию схемы и непримененные миграции
+func (m *Migrator) Status(ctx context.Context) (Status, error) {
+	var status Status
+	err := m.locked(ctx, func(conn *gorm.DB) error {
+		var err error
+		status, err = m.status(conn)
+		return err
+	})
+	return status, err
+}
+
+// Up Применяет все непримененные миграции
+func (m *Migrator) Up(ctx context.Context) (applied []Migration, err error) {
+	err = m.locked(ctx, func(conn *gorm.DB) error {
+		status, err := m.status(conn)
+		if err != nil {
+			return err
+		}
+		if status.Dirty {
+			return ErrDirty
+		}
+		for _, migration := range status.Pending {
+			if err := m.apply(conn, migration.Up, migration.Version); err != nil {
+				return fmt.Errorf("migrate: up %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			applied = append(applied, migration)
+		}
+		return nil
+	})
+	return applied, err
+}
+
+// Down Откатывает последние steps миграций
+func (m *Migrator) Down(ctx context.Context, steps int) (reverted []Migration, err error) {
+	err = m.locked(ctx, func(conn *gorm.DB) error {
+		status, err := m.status(conn)
+		if err != nil {
+			return err
+		}
+		if status.Dirty {
+			return ErrDirty
+		}
+
+		applied := m.appliedUpTo(status.Version)
+		for i := len(applied) - 1; i >= 0 && len(reverted) < steps; i-- {
+			migration := applied[i]
+			previous := int64(0)
+			if i > 0 {
+				previous = applied[i-1].Version
+			}
+			if err := m.apply(conn, migration.Down, previous); err != nil {
+				return fmt.Errorf("migrate: down %d_%s: %w", migration.Version, migration.Name, err)
+			}
+			reverted = append(reverted, migration)
+		}
+		return nil
+	})
+	return reverted, err
+}
+
+// Force Записывает версию схемы без выполнения миграций и снимает флаг dirty.
+// Нужна после ручного исправления упавшей миграции. Версия 0 - "ни одной миграции"
+func (m *Migrator) Force(ctx context.Context, version int64) error {
+	if version != 0 && !m.known(version) {
+		return fmt.Errorf("%w: %d", ErrNoMigration, version)
+	}
+	return m.locked(ctx, func(conn *gorm.DB) error {
+		return conn.Transaction(func(tx *gorm.DB) error {
+			return setVersion(tx, version)
+		})
+	})
+}
+
+// locked Выполняет fn на одном соединении. В Postgres на это время берется advisory lock,
+// чтобы реплики, стартующие одновременно, не применяли миграции параллельно
+func (m *Migrator) locked(ctx context.Context, fn func(conn *gorm.DB) error) error {
+	return m.db.WithContext(ctx).Connection(func(conn *gorm.DB) error {
+		if conn.Name() == "postgres" {
+			if err := conn.Exec("SELECT pg_advisory_lock(?)", lockKey).Error; err != nil {
+				return fmt.Errorf("migrate: could not take lock: %w", err)
+			}
+			defer conn.Exec("SELECT pg_advisory_unlock(?)", lockKey)
+		}
+
+		err := conn.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+			version BIGINT NOT NULL PRIMARY KEY,
+			dirty BOOLEAN NOT NULL
+		)`).Error
+		if err != nil {
+			return fmt.Errorf("migrate: could not create schema_migrations: %w", err)
+		}
+		return fn(conn)
+	})
+}
+
+// status Читает версию из schema_migrations
+func (m *Migrator) status(conn *gorm.DB) (Status, error) {
+	var rows []struct {
+		Version int64
+		Dirty   bool
+	}
+	if err := conn.Raw("SELECT version, dirty FROM schema_migrations LIMIT 1").Scan(&rows).Error; err != nil {
+		return Status{}, fmt.Errorf("migrate: could not read version: %w", err)
+	}
+
+	var status Status
+	if len(rows) > 0 {
+		status.Version, status.Dirty = rows[0].Version, rows[0].Dirty
+	}
+	if len(m.migrations) > 0 {
+		status.Latest = m.migrations[len(m.migrations)-1].Version
+	}
+	for _, migration := range m.migrations {
+		if migration.Version > status.Version {
+			status.Pending = append(status.Pending, migration)
+		}
+	}
+	return status, nil
+}
+
+// apply Выполняет скрипт и записывает новую версию в одной транзакции.
+// DDL в Postgres и SQLite транзакционен, поэтому упавшая миграция не оставляет схему
+// в промежуточном состоянии (флаг dirty ставит только внешняя утилита migrate)
+func (m *Migrator) apply(conn *gorm.DB, script string, version int64) error {
+	return conn.Transaction(func(tx *gorm.DB) error {
+		if err := tx.Exec(script).Error; err != nil {
+			return err
+		}
+		return setVersion(tx, version)
+	})
+}
+
+// setVersion Заменяет единственную строку schema_migrations
+func setVersion(tx *gorm.DB, version int64) error {
+	if err := tx.Exec("DELETE FROM schema_migrations").Error; err != nil {
+		return err
+	}
+	if version == 0 {
+		return nil
+	}
+	return tx.Exec("INSERT INTO schema_migrations (version, dirty) VALUES (?, ?)", version, false).Error
+}
+
+// appliedUpTo Миграции с версией не больше version
+func (m *Migrator) appliedUpTo(version int64) []Migration {
+	var applied []Migration
+	for _, migration := range m.migrations {
+		if migration.Version <= version {
+			applied = append(applied, migration)
+		}
+	}
+	return applied
+}
+
+// known Есть ли миграция с такой версией
+func (m *Migrator) known(version int64) bool {
+	for _, migration := range m.migrations {
+		if migration.Version == version {
+			return true
+		}
+	}
+	return false
+}
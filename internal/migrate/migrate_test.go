@@ -0,0 +1,142 @@
+package migrate
+
+import (
+	"POSTnGETtrain/internal/db"
+	"POSTnGETtrain/migrations"
+	"context"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+func openSQLite(t *testing.T) *gorm.DB {
+	t.Helper()
+	database, err := db.Open("sqlite://"+filepath.Join(t.TempDir(), "test.db"),
+		&gorm.Config{Logger: logger.Default.LogMode(logger.Silent)})
+	require.NoError(t, err)
+	return database
+}
+
+func TestLoad(t *testing.T) {
+	tests := []struct {
+		name    string
+		files   fstest.MapFS
+		want    []int64
+		wantErr bool
+	}{
+		{
+			name: "сортировка по версии",
+			files: fstest.MapFS{
+				"2_second.up.sql":   {Data: []byte("SELECT 1")},
+				"2_second.down.sql": {Data: []byte("SELECT 1")},
+				"1_first.up.sql":    {Data: []byte("SELECT 1")},
+				"1_first.down.sql":  {Data: []byte("SELECT 1")},
+				"migrations.go":     {Data: []byte("package migrations")},
+			},
+			want: []int64{1, 2},
+		},
+		{
+			name:    "нет down-файла",
+			files:   fstest.MapFS{"1_first.up.sql": {Data: []byte("SELECT 1")}},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Load(tt.files)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			versions := make([]int64, 0, len(got))
+			for _, m := range got {
+				versions = append(versions, m.Version)
+			}
+			assert.Equal(t, tt.want, versions)
+		})
+	}
+}
+
+func TestMigrator(t *testing.T) {
+	ctx := context.Background()
+	database := openSQLite(t)
+	m, err := New(database, fstest.MapFS{
+		"1_items.up.sql":     {Data: []byte("CREATE TABLE items (id INTEGER PRIMARY KEY);")},
+		"1_items.down.sql":   {Data: []byte("DROP TABLE items;")},
+		"2_name.up.sql":      {Data: []byte("ALTER TABLE items ADD COLUMN name TEXT;")},
+		"2_name.down.sql":    {Data: []byte("ALTER TABLE items DROP COLUMN name;")},
+		"3_broken.up.sql":    {Data: []byte("CREATE TABLE extra (id INTEGER); ALTER TABLE missing ADD COLUMN x TEXT;")},
+		"3_broken.down.sql":  {Data: []byte("DROP TABLE extra;")},
+		"not_a_migration.md": {Data: []byte("# readme")},
+	})
+	require.NoError(t, err)
+
+	status, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), status.Version)
+	assert.Equal(t, int64(3), status.Latest)
+	assert.True(t, status.Behind())
+
+	// Упавшая миграция откатывается целиком, версия остается на последней успешной
+	applied, err := m.Up(ctx)
+	assert.Error(t, err)
+	assert.Len(t, applied, 2)
+	assert.False(t, database.Migrator().HasTable("extra"))
+
+	status, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), status.Version)
+	assert.False(t, status.Dirty)
+	assert.Len(t, status.Pending, 1)
+
+	reverted, err := m.Down(ctx, 1)
+	require.NoError(t, err)
+	require.Len(t, reverted, 1)
+	assert.Equal(t, int64(2), reverted[0].Version)
+	assert.False(t, database.Migrator().HasColumn("items", "name"))
+
+	// Force только для известных версий
+	assert.ErrorIs(t, m.Force(ctx, 42), ErrNoMigration)
+	require.NoError(t, m.Force(ctx, 3))
+	status, err = m.Status(ctx)
+	require.NoError(t, err)
+	assert.False(t, status.Behind())
+
+	// dirty от внешней утилиты migrate блокирует up и down до force
+	require.NoError(t, database.Exec("UPDATE schema_migrations SET dirty = ?", true).Error)
+	_, err = m.Up(ctx)
+	assert.ErrorIs(t, err, ErrDirty)
+	_, err = m.Down(ctx, 1)
+	assert.ErrorIs(t, err, ErrDirty)
+}
+
+// TestMigrationsRoundTrip Все миграции проекта применяются и откатываются
+func TestMigrationsRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	database := openSQLite(t)
+	m, err := New(database, migrations.FS)
+	require.NoError(t, err)
+
+	applied, err := m.Up(ctx)
+	require.NoError(t, err)
+	assert.NotEmpty(t, applied)
+	assert.True(t, database.Migrator().HasTable("tasks"))
+
+	reverted, err := m.Down(ctx, len(applied))
+	require.NoError(t, err)
+	assert.Len(t, reverted, len(applied))
+	assert.False(t, database.Migrator().HasTable("tasks"))
+
+	_, err = m.Up(ctx)
+	require.NoError(t, err)
+	status, err := m.Status(ctx)
+	require.NoError(t, err)
+	assert.False(t, status.Behind())
+}
@@ -0,0 +1,16 @@
+package models
+
+import "time"
+
+// IdempotencyKey Сохраненный результат запроса с заголовком Idempotency-Key
+type IdempotencyKey struct {
+	Caller      string    `gorm:"primaryKey"`     // Клиент, в пределах которого уникален ключ, или хэш запроса анонимного клиента
+	Key         string    `gorm:"primaryKey"`     // Значение заголовка Idempotency-Key
+	Fingerprint string    `gorm:"not null"`       // Хэш метода, пути и тела запроса
+	Completed   bool      `gorm:"not null"`       // false, пока первый запрос ещё выполняется
+	StatusCode  int       `gorm:"not null"`       // Статус сохраненного ответа
+	Headers     string    `gorm:"not null"`       // Заголовки сохраненного ответа в JSON
+	Body        []byte    `gorm:"not null"`       // Тело сохраненного ответа
+	CreatedAt   time.Time `gorm:"not null"`       // Время первого запроса
+	ExpiresAt   time.Time `gorm:"not null;index"` // После этого момента ключ можно переиспользовать
+}
@@ -0,0 +1,6 @@
+package models
+
+// Stored Модели, которые хранятся в БД: по ним server schema check сверяет схему с миграциями
+func Stored() []any {
+	return []any{&User{}, &Task{}, &IdempotencyKey{}, &RateLimit{}, &PasswordResetToken{}, &UserTOTP{}, &TOTPRecoveryCode{}}
+}
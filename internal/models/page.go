@@ -0,0 +1,7 @@
+package models
+
+// Page Окно выборки для списков. Нулевой Limit означает "без ограничения"
+type Page struct {
+	Limit  int // Сколько записей вернуть
+	Offset int // Сколько записей пропустить
+}
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// PasswordResetToken Выданный токен сброса пароля. Сам токен уходит только в письмо,
+// в БД хранится его хэш: утечка таблицы не дает сбросить чужой пароль
+type PasswordResetToken struct {
+	ID        string    `gorm:"primaryKey"`           // Идентификатор записи
+	UserID    string    `gorm:"not null;index"`       // Чей пароль сбрасывается
+	Email     string    `gorm:"not null"`             // Адрес, на который ушло письмо: после смены email токен не действует
+	TokenHash string    `gorm:"not null;uniqueIndex"` // SHA-256 токена в hex
+	CreatedAt time.Time `gorm:"not null"`             // Когда выдан
+	ExpiresAt time.Time `gorm:"not null;index"`       // После этого момента токен не действует
+}
@@ -0,0 +1,9 @@
+package models
+
+// RateLimit Состояние корзины токенов одного клиента в одном классе запросов
+type RateLimit struct {
+	Key string `gorm:"primaryKey"` // Класс запросов и клиент, например "write:ip:203.0.113.7"
+	// Момент (наносекунды Unix), когда корзина снова будет полной. Хранится числом, а не временем:
+	// обновление сравнивает старое значение на точное равенство
+	FullAt int64 `gorm:"not null;index"`
+}
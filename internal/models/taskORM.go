@@ -1,13 +1,21 @@
 package models
 
-import "gorm.io/gorm"
+import (
+	"POSTnGETtrain/pkg/patch"
+	"time"
+
+	"gorm.io/gorm"
+)
 
 type Task struct {
 	ID        string         `json:"id" gorm:"primaryKey"`
 	Name      string         `json:"name"`
 	IsDone    bool           `json:"is_done"`
-	UserID    string         `json:"user_id" gorm:"not null"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"` // "-", чтобы это техническое поле не отображалось в JSON
+	UserID    string         `json:"user_id"`                     // В БД nullable (колонка добавлена в заполненную таблицу), обязательность проверяет сервис
+	Version   int64          `json:"-" gorm:"not null;default:1"` // Версия для оптимистичной блокировки (ETag)
+	CreatedAt time.Time      `json:"-"`
+	UpdatedAt time.Time      `json:"-"`
+	DeletedAt gorm.DeletedAt `json:"-"` // "-", чтобы это техническое поле не отображалось в JSON; индексы частичные, см. миграции
 }
 
 // Реализация TaskReference для User
@@ -22,3 +30,10 @@ type TaskRequest struct {
 	IsDone bool   `json:"is_done"`
 	UserID string `json:"user_id"`
 }
+
+// TaskChanges Набор изменений задачи для частичного обновления
+type TaskChanges struct {
+	Name   patch.Field[string]
+	IsDone patch.Field[bool]
+	UserID patch.Field[string]
+}
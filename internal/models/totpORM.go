@@ -0,0 +1,23 @@
+package models
+
+import "time"
+
+// UserTOTP Секрет TOTP пользователя. Пока ConfirmedAt пуст, подключение не завершено
+// и код при входе не спрашивается
+type UserTOTP struct {
+	UserID         string     `gorm:"primaryKey"` // Пользователь
+	Secret         string     `gorm:"not null"`   // Секрет в base32 (нужен для вычисления кодов, поэтому не хэшируется)
+	ConfirmedAt    *time.Time // Когда подключение подтверждено первым кодом
+	LastUsedStep   int64      `gorm:"not null"` // Шаг времени последнего принятого кода: код нельзя использовать повторно
+	CreatedAt      time.Time  `gorm:"not null"` // Когда выдан секрет
+	FailedAttempts int        `gorm:"not null"` // Попытки ввода кода подряд без принятого кода
+	LastAttemptAt  *time.Time // Время последней засчитанной попытки: от него отсчитывается блокировка
+}
+
+// TOTPRecoveryCode Одноразовый код восстановления вместо кода TOTP. Хранится хэш кода
+type TOTPRecoveryCode struct {
+	ID        string    `gorm:"primaryKey"`           // Идентификатор записи
+	UserID    string    `gorm:"not null;index"`       // Чей код
+	CodeHash  string    `gorm:"not null;uniqueIndex"` // SHA-256 кода в hex
+	CreatedAt time.Time `gorm:"not null"`             // Когда выдан
+}
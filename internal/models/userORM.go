@@ -1,6 +1,8 @@
 package models
 
 import (
+	"POSTnGETtrain/pkg/patch"
+	"log/slog"
 	"time"
 
 	"gorm.io/gorm"
@@ -14,7 +16,27 @@ type User struct {
 	Tasks     []Task         `json:"tasks" gorm:"foreignkey:UserID;references:ID"` // Связь с задачами
 	CreatedAt time.Time      `json:"created_at"`
 	UpdatedAt time.Time      `json:"updated_at"`
-	DeletedAt gorm.DeletedAt `json:"-" gorm:"index"`
+	DeletedAt gorm.DeletedAt `json:"-"`                               // Индексы частичные (WHERE deleted_at IS NULL), см. миграции
+	Version   int64          `json:"-" gorm:"not null;default:1"`     // Версия для оптимистичной блокировки (ETag)
+	IsAdmin   bool           `json:"-" gorm:"not null;default:false"` // Администратор (назначается через server user create-admin)
+	// Когда пользователь подтвердил email; nil - еще не подтвердил. Сбрасывается при смене email
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+}
+
+// EmailVerified Подтвержден ли email пользователя
+func (u User) EmailVerified() bool {
+	return u.EmailVerifiedAt != nil
+}
+
+// LogValue Пользователь в логах: без пароля и задач
+func (u User) LogValue() slog.Value {
+	return slog.GroupValue(
+		slog.String("id", u.ID),
+		slog.String("email", u.Email),
+		slog.Int64("version", u.Version),
+		slog.Bool("admin", u.IsAdmin),
+		slog.Bool("email_verified", u.EmailVerified()),
+	)
 }
 
 // UserRequest Используется при обработке входящих запросов
@@ -37,3 +59,9 @@ type UserUpdate struct {
 	Email    *string `json:"email,omitempty"`    // Новый email (опционально)
 	Password *string `json:"password,omitempty"` // Новый пароль (опционально)
 }
+
+// UserChanges Набор изменений пользователя для частичного обновления
+type UserChanges struct {
+	Email    patch.Field[string]
+	Password patch.Field[string]
+}
@@ -0,0 +1,99 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1" //nolint:gosec // SHA-1 задан форматом списка утекших паролей
+	_ "embed"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"sync"
+)
+
+// prefixLength Длина префикса хэша, по которому список разбит на диапазоны
+const prefixLength = 5
+
+// ErrInvalidBreachedList Строка файла не в формате "<SHA-1>[:<число>]"
+var ErrInvalidBreachedList = errors.New("password: invalid breached password list")
+
+//go:embed breached.txt
+var bundled string
+
+// BreachedList Список SHA-1 утекших паролей, разложенный по диапазонам 5-символьных префиксов
+// хэша, как в k-anonymity API Have I Been Pwned: пароль ищется только среди хэшей своего диапазона,
+// а сами пароли в памяти не хранятся
+type BreachedList struct {
+	ranges map[string]map[string]struct{} // Префикс -> суффиксы хэшей
+	size   int
+}
+
+// ParseBreachedList Читает список из r: по хэшу SHA-1 в строке, через двоеточие может идти
+// число утечек (формат выгрузки Have I Been Pwned). Пустые строки и строки с # пропускаются
+func ParseBreachedList(r io.Reader) (*BreachedList, error) {
+	list := &BreachedList{ranges: make(map[string]map[string]struct{})}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		hash, _, _ := strings.Cut(text, ":")
+		if _, err := hex.DecodeString(hash); err != nil || len(hash) != 2*sha1.Size {
+			return nil, fmt.Errorf("%w: line %d: expected SHA-1 hex", ErrInvalidBreachedList, line)
+		}
+		list.add(strings.ToUpper(hash))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("password: could not read breached password list: %w", err)
+	}
+	return list, nil
+}
+
+// LoadBreachedList Читает список из файла
+func LoadBreachedList(name string) (*BreachedList, error) {
+	file, err := os.Open(name)
+	if err != nil {
+		return nil, fmt.Errorf("password: %w", err)
+	}
+	defer file.Close()
+	return ParseBreachedList(file)
+}
+
+// bundledList Встроенный список разбирается один раз
+var bundledList = sync.OnceValues(func() (*BreachedList, error) {
+	return ParseBreachedList(strings.NewReader(bundled))
+})
+
+// BundledBreachedList Встроенный в бинарник список самых распространенных утекших паролей
+func BundledBreachedList() (*BreachedList, error) {
+	return bundledList()
+}
+
+// Contains Есть ли пароль в списке
+func (l *BreachedList) Contains(password string) bool {
+	sum := sha1.Sum([]byte(password)) //nolint:gosec // см. импорт
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+	_, ok := l.ranges[hash[:prefixLength]][hash[prefixLength:]]
+	return ok
+}
+
+// Len Количество хэшей в списке
+func (l *BreachedList) Len() int {
+	return l.size
+}
+
+func (l *BreachedList) add(hash string) {
+	prefix, suffix := hash[:prefixLength], hash[prefixLength:]
+	suffixes, ok := l.ranges[prefix]
+	if !ok {
+		suffixes = make(map[string]struct{})
+		l.ranges[prefix] = suffixes
+	}
+	if _, ok := suffixes[suffix]; !ok {
+		suffixes[suffix] = struct{}{}
+		l.size++
+	}
+}
@@ -0,0 +1,162 @@
+// Package password проверяет пароли по настраиваемой политике: длина, классы символов,
+// отсутствие частей email пользователя и отсутствие в списке утекших паролей
+package password
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Rule Правило политики паролей
+type Rule string
+
+// Правила политики. Значения возвращаются клиенту в списке нарушений
+const (
+	RuleMinLength Rule = "min_length"   // Пароль не короче MinLength символов
+	RuleMaxLength Rule = "max_length"   // Пароль не длиннее MaxLength символов
+	RuleUpper     Rule = "uppercase"    // Есть заглавная буква
+	RuleLower     Rule = "lowercase"    // Есть строчная буква
+	RuleDigit     Rule = "digit"        // Есть цифра
+	RuleSymbol    Rule = "symbol"       // Есть символ, кроме букв и цифр
+	RuleNoEmail   Rule = "no_email"     // Нет частей email пользователя
+	RuleBreached  Rule = "not_breached" // Пароля нет в списке утекших
+)
+
+// Classes Классы символов, которые может требовать политика
+var Classes = []Rule{RuleUpper, RuleLower, RuleDigit, RuleSymbol}
+
+// ErrUnknownClass Неизвестный класс символов в настройках
+var ErrUnknownClass = errors.New("password: unknown character class")
+
+// minEmailPart Части email короче этого не ищутся в пароле: короткие совпадают случайно
+const minEmailPart = 4
+
+// Policy Политика паролей. Нулевое значение принимает любой непустой пароль
+type Policy struct {
+	MinLength     int           // Минимальная длина в символах (не меньше 1)
+	MaxLength     int           // Максимальная длина в символах; 0 - без ограничения
+	Require       []Rule        // Обязательные классы символов из Classes
+	DisallowEmail bool          // Запретить части email пользователя в пароле
+	Breached      *BreachedList // Список утекших паролей; nil - не проверять
+}
+
+// Violation Нарушенное правило
+type Violation struct {
+	Rule    Rule
+	Message string
+}
+
+// ValidationError Пароль нарушает одно или несколько правил политики
+type ValidationError struct {
+	Violations []Violation
+}
+
+// Error Все нарушения одной строкой
+func (e *ValidationError) Error() string {
+	messages := make([]string, len(e.Violations))
+	for i, violation := range e.Violations {
+		messages[i] = violation.Message
+	}
+	return "password " + strings.Join(messages, "; ")
+}
+
+// ParseClasses Разбирает названия классов символов (uppercase, lowercase, digit, symbol)
+func ParseClasses(names []string) ([]Rule, error) {
+	classes := make([]Rule, 0, len(names))
+	for _, name := range names {
+		class := Rule(strings.ToLower(strings.TrimSpace(name)))
+		if !slices.Contains(Classes, class) {
+			return nil, fmt.Errorf("%w %q: expected one of %v", ErrUnknownClass, name, Classes)
+		}
+		classes = append(classes, class)
+	}
+	return classes, nil
+}
+
+// Check Проверяет пароль пользователя с адресом email. Возвращает *ValidationError
+// со всеми нарушенными правилами, а не только с первым
+func (p Policy) Check(password, email string) error {
+	var violations []Violation
+	add := func(rule Rule, format string, args ...any) {
+		violations = append(violations, Violation{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	length := utf8.RuneCountInString(password)
+	if minLength := max(p.MinLength, 1); length < minLength {
+		add(RuleMinLength, "must be at least %d characters long", minLength)
+	}
+	if p.MaxLength > 0 && length > p.MaxLength {
+		add(RuleMaxLength, "must be at most %d characters long", p.MaxLength)
+	}
+	for _, class := range p.Require {
+		if !strings.ContainsFunc(password, classMatcher(class)) {
+			add(class, "must contain %s character", describeClass(class))
+		}
+	}
+	if p.DisallowEmail && containsEmail(password, email) {
+		add(RuleNoEmail, "must not contain parts of the email address")
+	}
+	// Пустой пароль в списке не ищем: он уже нарушает min_length
+	if p.Breached != nil && password != "" && p.Breached.Contains(password) {
+		add(RuleBreached, "has appeared in a data breach, choose another one")
+	}
+
+	if len(violations) > 0 {
+		return &ValidationError{Violations: violations}
+	}
+	return nil
+}
+
+// classMatcher Проверка символа на принадлежность классу
+func classMatcher(class Rule) func(rune) bool {
+	switch class {
+	case RuleUpper:
+		return unicode.IsUpper
+	case RuleLower:
+		return unicode.IsLower
+	case RuleDigit:
+		return unicode.IsDigit
+	default:
+		return func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) }
+	}
+}
+
+// describeClass Название класса символов в сообщении
+func describeClass(class Rule) string {
+	switch class {
+	case RuleUpper:
+		return "an uppercase"
+	case RuleLower:
+		return "a lowercase"
+	case RuleDigit:
+		return "a digit"
+	default:
+		return "a symbol"
+	}
+}
+
+// containsEmail Есть ли в пароле локальная часть email, её слова или имя домена
+// (alice.smith@example.com: alice.smith, alice, smith, example) без учета регистра
+func containsEmail(password, email string) bool {
+	local, domain, ok := strings.Cut(strings.ToLower(email), "@")
+	if !ok {
+		return false
+	}
+	parts := []string{local}
+	parts = append(parts, strings.FieldsFunc(local, func(r rune) bool { return !unicode.IsLetter(r) && !unicode.IsDigit(r) })...)
+	if labels := strings.Split(domain, "."); len(labels) > 1 {
+		parts = append(parts, labels[:len(labels)-1]...) // Без домена верхнего уровня
+	}
+
+	password = strings.ToLower(password)
+	for _, part := range parts {
+		if utf8.RuneCountInString(part) >= minEmailPart && strings.Contains(password, part) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,102 @@
+package password
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheck(t *testing.T) {
+	breached, err := ParseBreachedList(strings.NewReader("2F9E53523B62ABC141A2B4D6019D23CBA835DBD0:42\n")) // correct horse
+	require.NoError(t, err)
+	strict := Policy{
+		MinLength:     10,
+		MaxLength:     20,
+		Require:       Classes,
+		DisallowEmail: true,
+		Breached:      breached,
+	}
+
+	tests := []struct {
+		name      string
+		policy    Policy
+		password  string
+		wantRules []Rule
+	}{
+		{name: "нулевая политика принимает любой непустой пароль", password: "x"},
+		{name: "нулевая политика не принимает пустой пароль", wantRules: []Rule{RuleMinLength}},
+		{name: "надежный пароль", policy: strict, password: "Tr0ub4dor&3x"},
+		{name: "длина в символах, а не байтах", policy: Policy{MinLength: 5}, password: "пароль"},
+		{name: "короткий", policy: strict, password: "Ab1!", wantRules: []Rule{RuleMinLength}},
+		{name: "длинный", policy: strict, password: "Ab1!" + strings.Repeat("x", 20), wantRules: []Rule{RuleMaxLength}},
+		{name: "все нарушения сразу", policy: strict, password: "alice",
+			wantRules: []Rule{RuleMinLength, RuleUpper, RuleDigit, RuleSymbol, RuleNoEmail}},
+		{name: "слово из email без учета регистра", policy: strict, password: "Smith-2026!x", wantRules: []Rule{RuleNoEmail}},
+		{name: "имя домена", policy: strict, password: "Example-2026!", wantRules: []Rule{RuleNoEmail}},
+		{name: "утекший пароль", policy: Policy{Breached: breached}, password: "correct horse", wantRules: []Rule{RuleBreached}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.policy.Check(tt.password, "alice.smith@example.com")
+			if tt.wantRules == nil {
+				assert.NoError(t, err)
+				return
+			}
+			var validationErr *ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			rules := make([]Rule, len(validationErr.Violations))
+			for i, violation := range validationErr.Violations {
+				rules[i] = violation.Rule
+				assert.NotEmpty(t, violation.Message)
+			}
+			assert.Equal(t, tt.wantRules, rules)
+		})
+	}
+}
+
+func TestParseClasses(t *testing.T) {
+	classes, err := ParseClasses([]string{"Uppercase", " digit "})
+	require.NoError(t, err)
+	assert.Equal(t, []Rule{RuleUpper, RuleDigit}, classes)
+
+	_, err = ParseClasses([]string{"emoji"})
+	assert.ErrorIs(t, err, ErrUnknownClass)
+}
+
+func TestBreachedList(t *testing.T) {
+	t.Run("встроенный список", func(t *testing.T) {
+		list, err := BundledBreachedList()
+		require.NoError(t, err)
+		assert.Positive(t, list.Len())
+		assert.True(t, list.Contains("password123"))
+		assert.True(t, list.Contains("P@ssw0rd"))
+		assert.False(t, list.Contains("Tr0ub4dor&3x"))
+	})
+
+	t.Run("файл в формате выгрузки", func(t *testing.T) {
+		name := filepath.Join(t.TempDir(), "pwned.txt")
+		data := "# comment\n\n2f9e53523b62abc141a2b4d6019d23cba835dbd0:42\n" +
+			"2F9E53523B62ABC141A2B4D6019D23CBA835DBD0\n" + // Повтор не считается
+			"7C4A8D09CA3762AF61E59520943DC26494F8941B\n" // 123456
+		require.NoError(t, os.WriteFile(name, []byte(data), 0o600))
+
+		list, err := LoadBreachedList(name)
+		require.NoError(t, err)
+		assert.Equal(t, 2, list.Len())
+		assert.True(t, list.Contains("correct horse"))
+		assert.True(t, list.Contains("123456"))
+		assert.False(t, list.Contains("password123"))
+	})
+
+	t.Run("ошибки", func(t *testing.T) {
+		_, err := ParseBreachedList(strings.NewReader("not-a-hash:1\n"))
+		assert.ErrorIs(t, err, ErrInvalidBreachedList)
+		_, err = LoadBreachedList(filepath.Join(t.TempDir(), "missing.txt"))
+		assert.Error(t, err)
+	})
+}
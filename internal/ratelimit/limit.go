@@ -0,0 +1,85 @@
+package ratelimit
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ErrInvalidLimit Строка лимита не в формате "<запросов>/<период>"
+var ErrInvalidLimit = errors.New("ratelimit: invalid limit")
+
+// Off Значение, которое отключает лимит
+const Off = "off"
+
+// Limit Корзина токенов: до Burst запросов подряд, корзина пополняется на Burst токенов за Period
+// равномерно, по одному токену каждые Period/Burst. Нулевое значение - без ограничений
+type Limit struct {
+	Burst  int
+	Period time.Duration
+}
+
+// ParseLimit Разбирает лимит вида "60/1m" (60 запросов в минуту) или "off"
+func ParseLimit(s string) (Limit, error) {
+	if s == Off {
+		return Limit{}, nil
+	}
+	count, period, ok := strings.Cut(s, "/")
+	if !ok {
+		return Limit{}, fmt.Errorf("%w %q: expected <requests>/<period> or %s", ErrInvalidLimit, s, Off)
+	}
+	burst, err := strconv.Atoi(count)
+	if err != nil || burst <= 0 {
+		return Limit{}, fmt.Errorf("%w %q: requests must be a positive integer", ErrInvalidLimit, s)
+	}
+	duration, err := time.ParseDuration(period)
+	if err != nil || duration < time.Duration(burst) {
+		return Limit{}, fmt.Errorf("%w %q: period must be a duration such as 1m", ErrInvalidLimit, s)
+	}
+	return Limit{Burst: burst, Period: duration}, nil
+}
+
+// Enabled Ограничивает ли лимит запросы
+func (l Limit) Enabled() bool {
+	return l.Burst > 0 && l.Period > 0
+}
+
+// String Лимит в формате ParseLimit
+func (l Limit) String() string {
+	if !l.Enabled() {
+		return Off
+	}
+	return strconv.Itoa(l.Burst) + "/" + l.Period.String()
+}
+
+// Result Решение по запросу и состояние корзины после него
+type Result struct {
+	Allowed    bool
+	Limit      int           // Размер корзины
+	Remaining  int           // Сколько запросов можно сделать сразу
+	Reset      time.Duration // Через сколько корзина снова будет полной
+	RetryAfter time.Duration // Через сколько появится токен (только для отклоненного запроса)
+}
+
+// take Забирает токен из корзины (алгоритм GCRA). Состояние корзины - одно число fullAt:
+// момент в наносекундах Unix, когда корзина снова будет полной (0 - новая корзина).
+// Возвращает новое состояние; у отклоненного запроса оно не меняется
+func take(fullAt int64, now time.Time, limit Limit) (int64, Result) {
+	interval := int64(limit.Period) / int64(limit.Burst)
+	nowNanos := now.UnixNano()
+	next := max(fullAt, nowNanos) + interval
+
+	result := Result{Limit: limit.Burst}
+	if next-nowNanos > int64(limit.Period) {
+		result.Reset = time.Duration(max(fullAt, nowNanos) - nowNanos)
+		result.RetryAfter = time.Duration(next - nowNanos - int64(limit.Period))
+		return fullAt, result
+	}
+
+	result.Allowed = true
+	result.Remaining = int((int64(limit.Period) - (next - nowNanos)) / interval)
+	result.Reset = time.Duration(next - nowNanos)
+	return next, result
+}
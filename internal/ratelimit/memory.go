@@ -0,0 +1,41 @@
+package ratelimit
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// memoryStore - реализация Store в памяти процесса: лимиты действуют в пределах одной реплики
+type memoryStore struct {
+	mu      sync.Mutex
+	buckets map[string]int64
+	sweptAt time.Time
+}
+
+// NewMemoryStore Конструктор хранилища корзин в памяти
+func NewMemoryStore() Store {
+	return &memoryStore{buckets: make(map[string]int64)}
+}
+
+// Take Забирает токен из корзины key
+func (s *memoryStore) Take(_ context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// Полные корзины не отличаются от отсутствующих - не даем карте расти
+	if now.Sub(s.sweptAt) >= sweepInterval {
+		for bucketKey, fullAt := range s.buckets {
+			if fullAt <= now.UnixNano() {
+				delete(s.buckets, bucketKey)
+			}
+		}
+		s.sweptAt = now
+	}
+
+	fullAt, result := take(s.buckets[key], now, limit)
+	if result.Allowed {
+		s.buckets[key] = fullAt
+	}
+	return result, nil
+}
@@ -0,0 +1,112 @@
+// Package ratelimit ограничивает частоту запросов корзинами токенов: отдельные бюджеты на чтение
+// и запись для каждого клиента (по сервису mTLS или IP) и более строгие лимиты
+// отдельных операций. Корзины хранятся в памяти процесса или в БД, чтобы лимиты соблюдались на всех репликах
+package ratelimit
+
+import (
+	"POSTnGETtrain/internal/logging"
+	"POSTnGETtrain/internal/tlsconfig"
+	"fmt"
+	"log/slog"
+	"math"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/labstack/echo/v4/middleware"
+)
+
+// Заголовки ответа (draft-ietf-httpapi-ratelimit-headers)
+const (
+	HeaderLimit     = "RateLimit-Limit"     // Размер корзины
+	HeaderRemaining = "RateLimit-Remaining" // Сколько запросов можно сделать сразу
+	HeaderReset     = "RateLimit-Reset"     // Через сколько секунд корзина снова будет полной
+	HeaderPolicy    = "RateLimit-Policy"    // Лимит в виде "60;w=60": запросов за окно в секундах
+)
+
+// Config Настройки middleware
+type Config struct {
+	Store Store // Где хранить корзины
+	Read  Limit // Бюджет на GET, HEAD и OPTIONS
+	Write Limit // Бюджет на остальные методы
+	// Routes Лимиты отдельных операций по ключу "METHOD /route", например "POST /users".
+	// Заменяют бюджет чтения или записи и всегда считаются по IP: это операции
+	// без учетной записи (регистрация, вход)
+	Routes  map[string]Limit
+	Skipper middleware.Skipper // Запросы без ограничений (например, сбор метрик)
+	Now     func() time.Time   // Часы (подменяются в тестах)
+}
+
+// Middleware Возвращает Echo middleware, которое забирает токен из корзины клиента и отвечает 429
+// с Retry-After, если токенов нет. Заголовки RateLimit-* добавляются к каждому ограниченному ответу.
+// Ошибка хранилища не блокирует запрос: лимит пропускается с записью в лог
+func Middleware(cfg Config) echo.MiddlewareFunc {
+	if cfg.Skipper == nil {
+		cfg.Skipper = middleware.DefaultSkipper
+	}
+	if cfg.Now == nil {
+		cfg.Now = time.Now
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			if cfg.Skipper(c) {
+				return next(c)
+			}
+			key, limit := bucket(c, cfg)
+			if !limit.Enabled() {
+				return next(c)
+			}
+
+			ctx := c.Request().Context()
+			result, err := cfg.Store.Take(ctx, key, limit, cfg.Now())
+			if err != nil {
+				logging.FromContext(ctx).WarnContext(ctx, "rate limit skipped", slog.String("error", err.Error()))
+				return next(c)
+			}
+
+			header := c.Response().Header()
+			header.Set(HeaderLimit, strconv.Itoa(result.Limit))
+			header.Set(HeaderRemaining, strconv.Itoa(result.Remaining))
+			header.Set(HeaderReset, strconv.Itoa(seconds(result.Reset)))
+			header.Set(HeaderPolicy, fmt.Sprintf("%d;w=%d", limit.Burst, seconds(limit.Period)))
+			if !result.Allowed {
+				retryAfter := max(seconds(result.RetryAfter), 1)
+				header.Set(echo.HeaderRetryAfter, strconv.Itoa(retryAfter))
+				return echo.NewHTTPError(http.StatusTooManyRequests,
+					fmt.Sprintf("rate limit exceeded, retry in %d s", retryAfter))
+			}
+			return next(c)
+		}
+	}
+}
+
+// bucket Ключ корзины и лимит запроса
+func bucket(c echo.Context, cfg Config) (string, Limit) {
+	method := c.Request().Method
+	if limit, ok := cfg.Routes[method+" "+c.Path()]; ok {
+		return "route:" + method + " " + c.Path() + ":ip:" + c.RealIP(), limit
+	}
+
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions:
+		return "read:" + caller(c), cfg.Read
+	default:
+		return "write:" + caller(c), cfg.Write
+	}
+}
+
+// caller Клиент запроса: сервис по клиентскому сертификату или IP. Заголовок Authorization
+// не учитывается: сервер не проверяет токены, и случайный токен в каждом запросе давал бы новую корзину
+func caller(c echo.Context) string {
+	if service, ok := tlsconfig.ServiceFromContext(c.Request().Context()); ok {
+		return "service:" + service
+	}
+	return "ip:" + c.RealIP()
+}
+
+// seconds Длительность в целых секундах с округлением вверх
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,234 @@
+package ratelimit
+
+import (
+	"POSTnGETtrain/internal/db/dbtest"
+	"POSTnGETtrain/internal/requestid"
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		name    string
+		value   string
+		want    Limit
+		wantErr bool
+	}{
+		{name: "в минуту", value: "60/1m", want: Limit{Burst: 60, Period: time.Minute}},
+		{name: "в час", value: "10/1h", want: Limit{Burst: 10, Period: time.Hour}},
+		{name: "выключен", value: "off", want: Limit{}},
+		{name: "без периода", value: "60", wantErr: true},
+		{name: "ноль запросов", value: "0/1m", wantErr: true},
+		{name: "период не длительность", value: "60/minute", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLimit(tt.value)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidLimit)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.Equal(t, tt.want.Enabled(), tt.value != Off)
+		})
+	}
+}
+
+// TestStore Один и тот же набор проверок для всех реализаций Store
+func TestStore(t *testing.T) {
+	implementations := map[string]func(t *testing.T) Store{
+		"memory": func(*testing.T) Store { return NewMemoryStore() },
+		"gorm":   func(t *testing.T) Store { return NewGormStore(dbtest.Open(t)) },
+	}
+	limit := Limit{Burst: 3, Period: 3 * time.Second} // Токен в секунду
+	now := time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)
+
+	for name, newStore := range implementations {
+		t.Run(name, func(t *testing.T) {
+			t.Run("корзина опустошается и пополняется", func(t *testing.T) {
+				store := newStore(t)
+				for _, wantRemaining := range []int{2, 1, 0} {
+					result, err := store.Take(t.Context(), "read:ip:1", limit, now)
+					require.NoError(t, err)
+					assert.True(t, result.Allowed)
+					assert.Equal(t, wantRemaining, result.Remaining)
+				}
+
+				denied, err := store.Take(t.Context(), "read:ip:1", limit, now)
+				require.NoError(t, err)
+				assert.False(t, denied.Allowed)
+				assert.Equal(t, time.Second, denied.RetryAfter)
+				assert.Equal(t, 3*time.Second, denied.Reset)
+
+				// Другой клиент со своей корзиной
+				other, err := store.Take(t.Context(), "read:ip:2", limit, now)
+				require.NoError(t, err)
+				assert.True(t, other.Allowed)
+
+				// Через секунду появился один токен
+				later, err := store.Take(t.Context(), "read:ip:1", limit, now.Add(time.Second))
+				require.NoError(t, err)
+				assert.True(t, later.Allowed)
+				assert.Equal(t, 0, later.Remaining)
+
+				// Через период корзина снова полная
+				full, err := store.Take(t.Context(), "read:ip:1", limit, now.Add(time.Hour))
+				require.NoError(t, err)
+				assert.Equal(t, 2, full.Remaining)
+			})
+
+			t.Run("параллельные запросы не превышают лимит", func(t *testing.T) {
+				store := newStore(t)
+				var wg sync.WaitGroup
+				var allowed atomic.Int32
+				for range 8 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						result, err := store.Take(t.Context(), "write:ip:1", limit, now)
+						if err == nil && result.Allowed {
+							allowed.Add(1)
+						}
+					}()
+				}
+				wg.Wait()
+				assert.LessOrEqual(t, allowed.Load(), int32(limit.Burst))
+				assert.Positive(t, allowed.Load())
+			})
+		})
+	}
+}
+
+// clock Часы, которые двигает тест
+type clock struct {
+	mu  sync.Mutex
+	now time.Time
+}
+
+func (c *clock) Now() time.Time {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	return c.now
+}
+
+func (c *clock) Advance(d time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.now = c.now.Add(d)
+}
+
+// failingStore Хранилище, которое всегда возвращает ошибку
+type failingStore struct{}
+
+func (failingStore) Take(context.Context, string, Limit, time.Time) (Result, error) {
+	return Result{}, errors.New("connection refused")
+}
+
+// newTestServer Echo с лимитами: 2 чтения и 1 запись в минуту, регистрация - 1 в час
+func newTestServer(store Store, clk *clock) *echo.Echo {
+	e := echo.New()
+	e.HTTPErrorHandler = requestid.ErrorHandler
+	e.IPExtractor = echo.ExtractIPDirect()
+	e.Use(Middleware(Config{
+		Store:   store,
+		Read:    Limit{Burst: 2, Period: time.Minute},
+		Write:   Limit{Burst: 1, Period: time.Minute},
+		Routes:  map[string]Limit{"POST /users": {Burst: 1, Period: time.Hour}},
+		Skipper: func(c echo.Context) bool { return c.Path() == "/metrics" },
+		Now:     clk.Now,
+	}))
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.GET("/tasks", ok)
+	e.POST("/tasks", ok)
+	e.POST("/users", ok)
+	e.GET("/metrics", ok)
+	return e
+}
+
+func doRequest(e *echo.Echo, method, path, ip, token string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(method, path, nil)
+	req.RemoteAddr = ip + ":40000"
+	if token != "" {
+		req.Header.Set(echo.HeaderAuthorization, "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	e.ServeHTTP(rec, req)
+	return rec
+}
+
+func TestMiddleware(t *testing.T) {
+	t.Run("заголовки и 429 после исчерпания", func(t *testing.T) {
+		clk := &clock{now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+		e := newTestServer(NewMemoryStore(), clk)
+
+		first := doRequest(e, http.MethodGet, "/tasks", "203.0.113.7", "")
+		assert.Equal(t, http.StatusOK, first.Code)
+		assert.Equal(t, "2", first.Header().Get(HeaderLimit))
+		assert.Equal(t, "1", first.Header().Get(HeaderRemaining))
+		assert.Equal(t, "30", first.Header().Get(HeaderReset))
+		assert.Equal(t, "2;w=60", first.Header().Get(HeaderPolicy))
+
+		assert.Equal(t, http.StatusOK, doRequest(e, http.MethodGet, "/tasks", "203.0.113.7", "").Code)
+		limited := doRequest(e, http.MethodGet, "/tasks", "203.0.113.7", "")
+		assert.Equal(t, http.StatusTooManyRequests, limited.Code)
+		assert.Equal(t, "30", limited.Header().Get(echo.HeaderRetryAfter))
+		assert.Equal(t, "0", limited.Header().Get(HeaderRemaining))
+		assert.Contains(t, limited.Body.String(), "rate limit exceeded")
+
+		// Бюджет записи отдельный от бюджета чтения
+		assert.Equal(t, http.StatusOK, doRequest(e, http.MethodPost, "/tasks", "203.0.113.7", "").Code)
+
+		clk.Advance(30 * time.Second)
+		assert.Equal(t, http.StatusOK, doRequest(e, http.MethodGet, "/tasks", "203.0.113.7", "").Code)
+	})
+
+	t.Run("клиенты различаются по IP, а не по токену", func(t *testing.T) {
+		clk := &clock{now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+		e := newTestServer(NewMemoryStore(), clk)
+
+		assert.Equal(t, http.StatusOK, doRequest(e, http.MethodPost, "/tasks", "203.0.113.7", "alice-token").Code)
+		assert.Equal(t, http.StatusTooManyRequests, doRequest(e, http.MethodPost, "/tasks", "203.0.113.7", "bob-token").Code)
+		assert.Equal(t, http.StatusOK, doRequest(e, http.MethodPost, "/tasks", "198.51.100.1", "alice-token").Code)
+	})
+
+	t.Run("регистрация ограничена по IP", func(t *testing.T) {
+		clk := &clock{now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+		e := newTestServer(NewMemoryStore(), clk)
+
+		assert.Equal(t, http.StatusOK, doRequest(e, http.MethodPost, "/users", "203.0.113.7", "alice-token").Code)
+		limited := doRequest(e, http.MethodPost, "/users", "203.0.113.7", "bob-token")
+		assert.Equal(t, http.StatusTooManyRequests, limited.Code, "новый токен не обходит лимит")
+		assert.Equal(t, "3600", limited.Header().Get(echo.HeaderRetryAfter))
+		assert.Equal(t, http.StatusOK, doRequest(e, http.MethodPost, "/users", "198.51.100.1", "").Code)
+	})
+
+	t.Run("пропущенные маршруты", func(t *testing.T) {
+		clk := &clock{now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+		e := newTestServer(NewMemoryStore(), clk)
+		for range 5 {
+			rec := doRequest(e, http.MethodGet, "/metrics", "203.0.113.7", "")
+			assert.Equal(t, http.StatusOK, rec.Code)
+			assert.Empty(t, rec.Header().Get(HeaderLimit))
+		}
+	})
+
+	t.Run("ошибка хранилища не блокирует запросы", func(t *testing.T) {
+		clk := &clock{now: time.Date(2026, 10, 19, 12, 0, 0, 0, time.UTC)}
+		e := newTestServer(failingStore{}, clk)
+		for range 3 {
+			assert.Equal(t, http.StatusOK, doRequest(e, http.MethodPost, "/tasks", "203.0.113.7", "").Code)
+		}
+	})
+}
@@ -0,0 +1,99 @@
+package ratelimit
+
+import (
+	"POSTnGETtrain/internal/models"
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// ErrContention Корзину не удалось обновить: её одновременно меняют другие запросы
+var ErrContention = errors.New("ratelimit: bucket is updated concurrently")
+
+// Store Хранилище корзин токенов
+type Store interface {
+	// Take Забирает токен из корзины key, если он есть
+	Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error)
+}
+
+// Параметры хранилища в БД
+const (
+	maxAttempts   = 5           // Попыток обновить корзину, которую меняют параллельные запросы
+	sweepInterval = time.Minute // Как часто удалять полные корзины
+)
+
+// gormStore - реализация Store поверх Postgres через GORM. Лимиты общие для всех реплик сервиса
+type gormStore struct {
+	db *gorm.DB
+
+	mu      sync.Mutex
+	sweptAt time.Time
+}
+
+// NewGormStore Конструктор хранилища корзин в БД
+func NewGormStore(db *gorm.DB) Store {
+	return &gormStore{db: db}
+}
+
+// Take Читает корзину и сохраняет новое состояние, только если его никто не изменил
+// после чтения (сравнение со старым full_at). При гонке чтение повторяется
+func (s *gormStore) Take(ctx context.Context, key string, limit Limit, now time.Time) (Result, error) {
+	if err := s.sweep(ctx, now); err != nil {
+		return Result{}, err
+	}
+
+	db := s.db.WithContext(ctx)
+	for range maxAttempts {
+		var bucket models.RateLimit
+		err := db.Where("key = ?", key).First(&bucket).Error
+		found := !errors.Is(err, gorm.ErrRecordNotFound)
+		if found && err != nil {
+			return Result{}, fmt.Errorf("ratelimit: could not get bucket: %w", err)
+		}
+
+		fullAt, result := take(bucket.FullAt, now, limit)
+		if !result.Allowed {
+			return result, nil
+		}
+
+		var saved *gorm.DB
+		if found {
+			saved = db.Model(&models.RateLimit{}).
+				Where("key = ? AND full_at = ?", key, bucket.FullAt).
+				Update("full_at", fullAt)
+		} else {
+			// ON CONFLICT DO NOTHING: новую корзину создаст только один из параллельных запросов
+			saved = db.Clauses(clause.OnConflict{DoNothing: true}).
+				Create(&models.RateLimit{Key: key, FullAt: fullAt})
+		}
+		if saved.Error != nil {
+			return Result{}, fmt.Errorf("ratelimit: could not save bucket: %w", saved.Error)
+		}
+		if saved.RowsAffected == 1 {
+			return result, nil
+		}
+	}
+	return Result{}, ErrContention
+}
+
+// sweep Раз в sweepInterval удаляет полные корзины: они не отличаются от отсутствующих
+func (s *gormStore) sweep(ctx context.Context, now time.Time) error {
+	s.mu.Lock()
+	if now.Sub(s.sweptAt) < sweepInterval {
+		s.mu.Unlock()
+		return nil
+	}
+	s.sweptAt = now
+	s.mu.Unlock()
+
+	err := s.db.WithContext(ctx).Where("full_at <= ?", now.UnixNano()).Delete(&models.RateLimit{}).Error
+	if err != nil {
+		return fmt.Errorf("ratelimit: could not purge full buckets: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,105 @@
+// Package requestid присваивает каждому запросу идентификатор X-Request-ID: принимает его от клиента
+// или генерирует, возвращает в ответе и в теле ошибок, кладет в контекст для логов и трасс
+package requestid
+
+import (
+	"POSTnGETtrain/internal/web/api"
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Header Заголовок запроса и ответа
+const Header = echo.HeaderXRequestID
+
+// maxLength Более длинный идентификатор клиента заменяется сгенерированным
+const maxLength = 128
+
+// contextKey Ключ идентификатора в контексте
+type contextKey struct{}
+
+// WithID Возвращает контекст с идентификатором запроса
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, contextKey{}, id)
+}
+
+// FromContext Идентификатор запроса из контекста или пустая строка
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(contextKey{}).(string)
+	return id
+}
+
+// Middleware Возвращает Echo middleware, которое принимает X-Request-ID клиента (если он допустим)
+// или генерирует новый, возвращает его в заголовке ответа и кладет в контекст и в текущий спан.
+// Должно стоять до обработчиков и middleware, которые пишут логи или ответы об ошибках
+func Middleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			request := c.Request()
+			id := request.Header.Get(Header)
+			if !valid(id) {
+				id = uuid.NewString()
+			}
+			c.Response().Header().Set(Header, id)
+
+			ctx := WithID(request.Context(), id)
+			trace.SpanFromContext(ctx).SetAttributes(attribute.String("request.id", id))
+			c.SetRequest(request.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
+
+// valid Идентификатор клиента попадает в заголовки, логи и трассы как есть,
+// поэтому допускаются только видимые символы ASCII без пробелов
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for i := range len(id) {
+		if id[i] < '!' || id[i] > '~' {
+			return false
+		}
+	}
+	return true
+}
+
+// ErrorHandler Обработчик ошибок Echo: как стандартный, но тело ответа - схема Error
+// из спецификации с request_id. Так его получают и ошибки привязки параметров
+// в сгенерированном ServerInterfaceWrapper, и ошибки middleware
+func ErrorHandler(err error, c echo.Context) {
+	if c.Response().Committed {
+		return
+	}
+
+	// Текст внутренних ошибок клиенту не показываем
+	code, message := http.StatusInternalServerError, http.StatusText(http.StatusInternalServerError)
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		code, message = httpErr.Code, fmt.Sprint(httpErr.Message)
+	}
+
+	if c.Request().Method == http.MethodHead {
+		err = c.NoContent(code)
+	} else {
+		err = c.JSON(code, Error(c.Request().Context(), message))
+	}
+	if err != nil {
+		c.Logger().Error(err)
+	}
+}
+
+// Error Тело ответа об ошибке с идентификатором запроса из контекста
+func Error(ctx context.Context, message string) api.Error {
+	body := api.Error{Message: message}
+	if id := FromContext(ctx); id != "" {
+		body.RequestId = &id
+	}
+	return body
+}
@@ -0,0 +1,111 @@
+package requestid
+
+import (
+	"POSTnGETtrain/internal/web/api"
+	"POSTnGETtrain/internal/web/tasks"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name     string
+		header   string
+		wantSame bool
+	}{
+		{name: "без заголовка", header: ""},
+		{name: "идентификатор клиента", header: "client-42:retry/1", wantSame: true},
+		{name: "слишком длинный", header: strings.Repeat("a", maxLength+1)},
+		{name: "с пробелом", header: "two words"},
+		{name: "с переводом строки", header: "id\nInjected: 1"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var fromContext string
+			e := echo.New()
+			e.Use(Middleware())
+			e.GET("/", func(c echo.Context) error {
+				fromContext = FromContext(c.Request().Context())
+				return c.NoContent(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.header != "" {
+				req.Header[Header] = []string{tt.header}
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			id := rec.Header().Get(Header)
+			assert.Equal(t, id, fromContext, "в ответе и в контексте один идентификатор")
+			if tt.wantSame {
+				assert.Equal(t, tt.header, id)
+				return
+			}
+			_, err := uuid.Parse(id)
+			assert.NoError(t, err, "сгенерирован новый идентификатор")
+		})
+	}
+}
+
+func TestErrorHandler(t *testing.T) {
+	e := echo.New()
+	e.HTTPErrorHandler = ErrorHandler
+	e.Use(Middleware())
+	// Настоящие сгенерированные обертки: ошибка привязки возникает до строгого обработчика
+	tasks.RegisterHandlers(e, tasks.NewStrictHandler(nil, nil))
+	e.GET("/broken", func(echo.Context) error { return errors.New("connection refused") })
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		wantStatus  int
+		wantMessage string
+	}{
+		{name: "ошибка привязки параметра", method: http.MethodGet, path: "/tasks?limit=abc", wantStatus: http.StatusBadRequest, wantMessage: "Invalid format for parameter limit"},
+		{name: "несуществующий путь", method: http.MethodGet, path: "/nope", wantStatus: http.StatusNotFound, wantMessage: "Not Found"},
+		{name: "внутренняя ошибка скрыта", method: http.MethodGet, path: "/broken", wantStatus: http.StatusInternalServerError, wantMessage: "Internal Server Error"},
+		{name: "HEAD без тела", method: http.MethodHead, path: "/nope", wantStatus: http.StatusNotFound},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, nil)
+			req.Header.Set(Header, "req-1")
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code)
+			assert.Equal(t, "req-1", rec.Header().Get(Header))
+			if tt.method == http.MethodHead {
+				assert.Empty(t, rec.Body.String())
+				return
+			}
+
+			var body api.Error
+			require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+			assert.Contains(t, body.Message, tt.wantMessage)
+			assert.NotContains(t, body.Message, "connection refused")
+			require.NotNil(t, body.RequestId)
+			assert.Equal(t, "req-1", *body.RequestId)
+		})
+	}
+}
+
+func TestError(t *testing.T) {
+	assert.Equal(t, api.Error{Message: "boom"}, Error(t.Context(), "boom"), "без идентификатора в контексте")
+
+	id := "req-1"
+	assert.Equal(t, api.Error{Message: "boom", RequestId: &id}, Error(WithID(t.Context(), id), "boom"))
+}
@@ -0,0 +1,235 @@
+// Package schemacheck сравнивает схему мигрированной БД с метаданными моделей GORM
+// и находит расхождения: недостающие и лишние колонки, несовпадение типов и
+// допустимости NULL, отсутствующие индексы. Колонки читаются через Migrator GORM
+// (information_schema в Postgres, PRAGMA table_info в SQLite)
+package schemacheck
+
+import (
+	"fmt"
+	"reflect"
+	"slices"
+	"strings"
+
+	"gorm.io/gorm"
+	"gorm.io/gorm/schema"
+)
+
+// Kind Вид расхождения
+type Kind string
+
+// Виды расхождений
+const (
+	MissingTable        Kind = "missing table"        // Таблицы модели нет в БД
+	MissingColumn       Kind = "missing column"       // Поле модели без колонки в БД
+	ExtraColumn         Kind = "extra column"         // Колонка БД без поля в модели
+	TypeMismatch        Kind = "type mismatch"        // Тип колонки не подходит типу поля
+	NullabilityMismatch Kind = "nullability mismatch" // NULL допустим только с одной стороны
+	MissingIndex        Kind = "missing index"        // Индекс или unique из тегов модели отсутствует в БД
+)
+
+// Issue Одно расхождение
+type Issue struct {
+	Table  string
+	Column string // Пусто для расхождений уровня таблицы
+	Kind   Kind
+	Detail string
+}
+
+func (i Issue) String() string {
+	where := i.Table
+	if i.Column != "" {
+		where += "." + i.Column
+	}
+	if i.Detail == "" {
+		return fmt.Sprintf("%s: %s", where, i.Kind)
+	}
+	return fmt.Sprintf("%s: %s: %s", where, i.Kind, i.Detail)
+}
+
+// Check Сверяет таблицы моделей с БД. Порядок расхождений: по моделям, затем по колонкам
+func Check(db *gorm.DB, models ...any) ([]Issue, error) {
+	var issues []Issue
+	for _, model := range models {
+		stmt := &gorm.Statement{DB: db}
+		if err := stmt.Parse(model); err != nil {
+			return nil, fmt.Errorf("schemacheck: could not parse %T: %w", model, err)
+		}
+		modelIssues, err := checkTable(db, stmt.Schema, model)
+		if err != nil {
+			return nil, err
+		}
+		issues = append(issues, modelIssues...)
+	}
+	return issues, nil
+}
+
+// checkTable Сверяет одну модель с её таблицей
+func checkTable(db *gorm.DB, s *schema.Schema, model any) ([]Issue, error) {
+	table := s.Table
+	if !db.Migrator().HasTable(model) {
+		return []Issue{{Table: table, Kind: MissingTable}}, nil
+	}
+
+	columnTypes, err := db.Migrator().ColumnTypes(model)
+	if err != nil {
+		return nil, fmt.Errorf("schemacheck: could not read columns of %s: %w", table, err)
+	}
+	columns := make(map[string]gorm.ColumnType, len(columnTypes))
+	for _, column := range columnTypes {
+		columns[column.Name()] = column
+	}
+	indexes, err := db.Migrator().GetIndexes(model)
+	if err != nil {
+		return nil, fmt.Errorf("schemacheck: could not read indexes of %s: %w", table, err)
+	}
+
+	var issues []Issue
+	for _, field := range s.Fields {
+		if field.DBName == "" {
+			continue // Связи (User.Tasks) и поля с gorm:"-"
+		}
+		column, ok := columns[field.DBName]
+		if !ok {
+			issues = append(issues, Issue{Table: table, Column: field.DBName, Kind: MissingColumn,
+				Detail: fmt.Sprintf("field %s.%s", s.Name, field.Name)})
+			continue
+		}
+		issues = append(issues, checkColumn(table, field, column)...)
+		if field.Unique && !hasUnique(column, indexes) {
+			issues = append(issues, Issue{Table: table, Column: field.DBName, Kind: MissingIndex,
+				Detail: "field is unique, column has no unique constraint"})
+		}
+	}
+
+	for _, column := range columnTypes {
+		if s.LookUpField(column.Name()) == nil {
+			issues = append(issues, Issue{Table: table, Column: column.Name(), Kind: ExtraColumn,
+				Detail: fmt.Sprintf("no field in %s", s.Name)})
+		}
+	}
+
+	for _, index := range s.ParseIndexes() {
+		want := make([]string, 0, len(index.Fields))
+		for _, option := range index.Fields {
+			want = append(want, option.DBName)
+		}
+		if !hasIndex(indexes, want, index.Class == "UNIQUE") {
+			issues = append(issues, Issue{Table: table, Column: strings.Join(want, ","), Kind: MissingIndex,
+				Detail: fmt.Sprintf("%s declared in %s", index.Name, s.Name)})
+		}
+	}
+
+	// Внешние ключи связей has one / has many лежат в таблице связанной модели
+	for _, rel := range s.Relationships.Relations {
+		if rel.Type != schema.HasMany && rel.Type != schema.HasOne {
+			continue
+		}
+		for _, ref := range rel.References {
+			related := ref.ForeignKey.Schema
+			if !db.Migrator().HasColumn(related.Table, ref.ForeignKey.DBName) {
+				issues = append(issues, Issue{Table: related.Table, Column: ref.ForeignKey.DBName, Kind: MissingColumn,
+					Detail: fmt.Sprintf("foreign key of %s.%s", s.Name, rel.Name)})
+			}
+		}
+	}
+	return issues, nil
+}
+
+// checkColumn Сверяет тип и допустимость NULL
+func checkColumn(table string, field *schema.Field, column gorm.ColumnType) []Issue {
+	var issues []Issue
+
+	if want, got := fieldKind(field), columnKind(column.DatabaseTypeName()); want != "" && got != "" && want != got {
+		issues = append(issues, Issue{Table: table, Column: field.DBName, Kind: TypeMismatch,
+			Detail: fmt.Sprintf("field %s is %s, column is %s", field.Name, field.FieldType, column.DatabaseTypeName())})
+	}
+
+	// Первичный ключ не проверяем: SQLite считает nullable даже PRIMARY KEY не-INTEGER типа
+	nullable, ok := column.Nullable()
+	if !ok || field.PrimaryKey {
+		return issues
+	}
+	switch {
+	case field.NotNull && nullable:
+		issues = append(issues, Issue{Table: table, Column: field.DBName, Kind: NullabilityMismatch,
+			Detail: fmt.Sprintf("field %s is NOT NULL, column allows NULL", field.Name)})
+	case holdsNull(field.FieldType) && !nullable:
+		issues = append(issues, Issue{Table: table, Column: field.DBName, Kind: NullabilityMismatch,
+			Detail: fmt.Sprintf("field %s can be NULL (%s), column is NOT NULL", field.Name, field.FieldType)})
+	}
+	return issues
+}
+
+// fieldKind Обобщенный тип поля модели. Пустая строка - тип не проверяется
+func fieldKind(field *schema.Field) string {
+	switch field.DataType {
+	case schema.Bool:
+		return "bool"
+	case schema.Int, schema.Uint:
+		return "int"
+	case schema.Float:
+		return "float"
+	case schema.String:
+		return "string"
+	case schema.Time:
+		return "time"
+	case schema.Bytes:
+		return "bytes"
+	}
+	return ""
+}
+
+// columnKind Обобщенный тип колонки по имени типа в СУБД. Пустая строка - тип не распознан
+func columnKind(databaseType string) string {
+	name := strings.ToLower(databaseType)
+	switch {
+	case strings.Contains(name, "bool"):
+		return "bool"
+	case strings.Contains(name, "timestamp"), name == "date", strings.HasPrefix(name, "time"):
+		return "time"
+	case strings.Contains(name, "int"), strings.Contains(name, "serial"):
+		return "int"
+	case strings.Contains(name, "char"), strings.Contains(name, "text"), strings.Contains(name, "clob"), name == "uuid":
+		return "string"
+	case name == "bytea", strings.Contains(name, "blob"):
+		return "bytes"
+	case strings.Contains(name, "float"), strings.Contains(name, "double"), name == "real",
+		strings.Contains(name, "numeric"), strings.Contains(name, "decimal"):
+		return "float"
+	}
+	return ""
+}
+
+// holdsNull Может ли поле хранить NULL: указатель или обертка с полем Valid (sql.NullString, gorm.DeletedAt)
+func holdsNull(t reflect.Type) bool {
+	if t.Kind() == reflect.Pointer {
+		return true
+	}
+	if t.Kind() != reflect.Struct {
+		return false
+	}
+	valid, ok := t.FieldByName("Valid")
+	return ok && valid.Type.Kind() == reflect.Bool
+}
+
+// hasUnique Есть ли у колонки ограничение UNIQUE или уникальный индекс по ней одной
+func hasUnique(column gorm.ColumnType, indexes []gorm.Index) bool {
+	if unique, ok := column.Unique(); ok && unique {
+		return true
+	}
+	return hasIndex(indexes, []string{column.Name()}, true)
+}
+
+// hasIndex Есть ли индекс ровно по этим колонкам (уникальный, если unique)
+func hasIndex(indexes []gorm.Index, columns []string, unique bool) bool {
+	for _, index := range indexes {
+		if !slices.Equal(index.Columns(), columns) {
+			continue
+		}
+		if isUnique, _ := index.Unique(); unique && !isUnique {
+			continue
+		}
+		return true
+	}
+	return false
+}
@@ -0,0 +1,93 @@
+package schemacheck
+
+import (
+	"POSTnGETtrain/internal/db/dbtest"
+	"POSTnGETtrain/internal/models"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+// TestMigrationsMatchModels Миграции проекта и модели GORM описывают одну и ту же схему
+func TestMigrationsMatchModels(t *testing.T) {
+	database := dbtest.Open(t)
+
+	issues, err := Check(database, models.Stored()...)
+	require.NoError(t, err)
+	assert.Empty(t, issues)
+}
+
+// drifted Модель, расходящаяся с таблицей widgets во всех проверяемых местах
+type drifted struct {
+	ID        string `gorm:"primaryKey"`
+	Title     string `gorm:"not null"`
+	Count     int
+	Note      *string
+	Code      string `gorm:"unique"`
+	OwnerID   string `gorm:"index:idx_widgets_owner"`
+	Missing   string
+	DeletedAt gorm.DeletedAt
+}
+
+func (drifted) TableName() string { return "widgets" }
+
+func TestCheck(t *testing.T) {
+	database := dbtest.Open(t)
+	require.NoError(t, database.Exec(`CREATE TABLE widgets (
+		id VARCHAR(36) PRIMARY KEY,
+		title VARCHAR(255),
+		count VARCHAR(10) NOT NULL,
+		note TEXT NOT NULL,
+		code VARCHAR(10) NOT NULL,
+		owner_id VARCHAR(36) NOT NULL,
+		deleted_at TIMESTAMP,
+		legacy TEXT
+	)`).Error)
+
+	type absent struct{ ID string }
+
+	tests := []struct {
+		name  string
+		model any
+		want  []Issue
+	}{
+		{
+			name:  "все виды расхождений",
+			model: &drifted{},
+			want: []Issue{
+				{Table: "widgets", Column: "title", Kind: NullabilityMismatch, Detail: "field Title is NOT NULL, column allows NULL"},
+				{Table: "widgets", Column: "count", Kind: TypeMismatch, Detail: "field Count is int, column is VARCHAR"},
+				{Table: "widgets", Column: "note", Kind: NullabilityMismatch, Detail: "field Note can be NULL (*string), column is NOT NULL"},
+				{Table: "widgets", Column: "code", Kind: MissingIndex, Detail: "field is unique, column has no unique constraint"},
+				{Table: "widgets", Column: "missing", Kind: MissingColumn, Detail: "field drifted.Missing"},
+				{Table: "widgets", Column: "legacy", Kind: ExtraColumn, Detail: "no field in drifted"},
+				{Table: "widgets", Column: "owner_id", Kind: MissingIndex, Detail: "idx_widgets_owner declared in drifted"},
+			},
+		},
+		{
+			name:  "нет таблицы",
+			model: &absent{},
+			want:  []Issue{{Table: "absents", Kind: MissingTable}},
+		},
+		{
+			name:  "совпадает",
+			model: &models.IdempotencyKey{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			issues, err := Check(database, tt.model)
+			require.NoError(t, err)
+			assert.ElementsMatch(t, tt.want, issues)
+		})
+	}
+}
+
+func TestIssueString(t *testing.T) {
+	issue := Issue{Table: "tasks", Column: "user_id", Kind: NullabilityMismatch, Detail: "field UserID is NOT NULL, column allows NULL"}
+	assert.Equal(t, "tasks.user_id: nullability mismatch: field UserID is NOT NULL, column allows NULL", issue.String())
+	assert.Equal(t, "absents: missing table", Issue{Table: "absents", Kind: MissingTable}.String())
+}
@@ -0,0 +1,219 @@
+// Package seed генерирует правдоподобные наборы пользователей и задач для демо,
+// нагрузочных тестов и воспроизведения ошибок на объёмах клиентов.
+// Одинаковый Config (включая Seed) всегда дает одинаковые данные; записи создаются
+// через сервисный слой, поэтому к ним применяются все бизнес-правила
+package seed
+
+import (
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/taskService"
+	"POSTnGETtrain/internal/userService"
+	"context"
+	"errors"
+	"fmt"
+	"math/rand/v2"
+	"strings"
+	"unicode/utf8"
+)
+
+// ErrInvalidConfig Недопустимые параметры генерации
+var ErrInvalidConfig = errors.New("seed: invalid config")
+
+// maxNameLength Предел длины названия задачи в символах (maxLength в спецификации, VARCHAR(255) в БД)
+const maxNameLength = 255
+
+// Config Параметры генерации
+type Config struct {
+	Users        int     // Сколько пользователей создать
+	Tasks        int     // Сколько задач создать; владельцы распределены неравномерно (закон Ципфа)
+	DoneRatio    float64 // Доля выполненных задач, от 0 до 1
+	LongRatio    float64 // Доля задач с длинным (почти 255 символов) названием
+	UnicodeRatio float64 // Доля названий не на латинице: кириллица, CJK, эмодзи
+	Seed         uint64  // Зерно генератора
+}
+
+// DefaultConfig Небольшой набор для локального демо
+func DefaultConfig() Config {
+	return Config{Users: 20, Tasks: 200, DoneRatio: 0.3, LongRatio: 0.05, UnicodeRatio: 0.2, Seed: 1}
+}
+
+// UserSpec Пользователь, которого нужно создать
+type UserSpec struct {
+	Email    string
+	Password string
+}
+
+// TaskSpec Задача, которую нужно создать
+type TaskSpec struct {
+	Name   string
+	IsDone bool
+	Owner  int // Индекс владельца в Dataset.Users
+}
+
+// Dataset Сгенерированные данные до записи в хранилище
+type Dataset struct {
+	Users []UserSpec
+	Tasks []TaskSpec
+}
+
+// Result Созданные записи
+type Result struct {
+	Users []*models.User
+	Tasks []models.Task
+}
+
+// Generate Генерирует набор данных. Функция детерминирована: результат зависит только от cfg
+func Generate(cfg Config) (Dataset, error) {
+	if err := cfg.validate(); err != nil {
+		return Dataset{}, err
+	}
+	r := rand.New(rand.NewPCG(cfg.Seed, cfg.Seed^0x9e3779b97f4a7c15))
+
+	dataset := Dataset{
+		Users: make([]UserSpec, 0, cfg.Users),
+		Tasks: make([]TaskSpec, 0, cfg.Tasks),
+	}
+	for i := range cfg.Users {
+		dataset.Users = append(dataset.Users, UserSpec{Email: email(r, i), Password: password(r)})
+	}
+	if cfg.Tasks == 0 {
+		return dataset, nil
+	}
+
+	// Немногие пользователи владеют большинством задач; перестановка, чтобы "тяжёлым" не был всегда первый
+	owners := r.Perm(cfg.Users)
+	zipf := rand.NewZipf(r, 1.1, 1, uint64(cfg.Users-1))
+	for range cfg.Tasks {
+		var name string
+		switch roll := r.Float64(); {
+		case roll < cfg.LongRatio:
+			name = longName(r)
+		case roll < cfg.LongRatio+cfg.UnicodeRatio:
+			name = unicodeName(r)
+		default:
+			name = plainName(r)
+		}
+		dataset.Tasks = append(dataset.Tasks, TaskSpec{
+			Name:   name,
+			IsDone: r.Float64() < cfg.DoneRatio,
+			Owner:  owners[zipf.Uint64()],
+		})
+	}
+	return dataset, nil
+}
+
+// Load Создает пользователей и задачи набора через сервисы. ID записей назначают сервисы
+func Load(ctx context.Context, dataset Dataset, users userService.UserService, tasks taskService.TaskService) (Result, error) {
+	result := Result{
+		Users: make([]*models.User, 0, len(dataset.Users)),
+		Tasks: make([]models.Task, 0, len(dataset.Tasks)),
+	}
+	for _, spec := range dataset.Users {
+		user, err := users.CreateUser(ctx, spec.Email, spec.Password)
+		if err != nil {
+			return result, fmt.Errorf("seed: could not create user %s: %w", spec.Email, err)
+		}
+		result.Users = append(result.Users, user)
+	}
+	for i, spec := range dataset.Tasks {
+		if spec.Owner < 0 || spec.Owner >= len(result.Users) {
+			return result, fmt.Errorf("%w: task %d has no owner %d", ErrInvalidConfig, i, spec.Owner)
+		}
+		task, err := tasks.CreateTask(ctx, spec.Name, spec.IsDone, result.Users[spec.Owner].ID)
+		if err != nil {
+			return result, fmt.Errorf("seed: could not create task %d: %w", i, err)
+		}
+		result.Tasks = append(result.Tasks, task)
+	}
+	return result, nil
+}
+
+// validate Проверяет параметры генерации
+func (c Config) validate() error {
+	switch {
+	case c.Users < 0 || c.Tasks < 0:
+		return fmt.Errorf("%w: counts must not be negative", ErrInvalidConfig)
+	case c.Tasks > 0 && c.Users == 0:
+		return fmt.Errorf("%w: tasks need at least one user", ErrInvalidConfig)
+	case !isRatio(c.DoneRatio) || !isRatio(c.LongRatio) || !isRatio(c.UnicodeRatio):
+		return fmt.Errorf("%w: ratios must be between 0 and 1", ErrInvalidConfig)
+	case c.LongRatio+c.UnicodeRatio > 1:
+		return fmt.Errorf("%w: long and unicode ratios must not exceed 1 together", ErrInvalidConfig)
+	}
+	return nil
+}
+
+func isRatio(v float64) bool { return v >= 0 && v <= 1 }
+
+// Словари для названий и адресов
+var (
+	firstNames = []string{"anna", "boris", "chen", "dmitry", "elena", "farid", "grace", "hiro", "ivan", "julia",
+		"kofi", "lena", "maria", "nikita", "olga", "pavel", "priya", "sam", "tatiana", "yusuf"}
+	lastNames = []string{"smirnova", "ivanov", "li", "garcia", "kim", "novak", "petrov", "sato", "muller", "okafor",
+		"kowalski", "rossi", "silva", "volkova", "nguyen"}
+	domains = []string{"example.com", "example.org", "example.net", "mail.example.com"}
+
+	verbs   = []string{"fix", "write", "review", "deploy", "call", "buy", "plan", "update", "test", "refactor", "clean", "book", "send", "prepare"}
+	objects = []string{"report", "invoice", "release notes", "dashboard", "groceries", "tickets", "migration", "slides", "budget",
+		"backlog", "onboarding doc", "car service", "flaky test", "quarterly review"}
+	suffixes = []string{"", "", "", " before Friday", " for Q3", " with the team", " asap", " again", " (follow-up)", " #42"}
+
+	unicodeNames = []string{"Купить молоко", "Подготовить отчёт за квартал", "Позвонить в банк", "Заказать пиццу 🍕",
+		"🚀 Релиз 2.0", "提交季度报告", "准备演示文稿", "レビューを書く", "会議の資料を準備する", "Αγορά εισιτηρίων",
+		"مراجعة التقرير", "סגירת חשבון", "Überprüfung der Straße", "Ünïcödé tëst ✅", "Réserver l'hôtel à Zürich",
+		"회의 일정 잡기", "ตรวจสอบใบแจ้งหนี้", "Обновить зависимости 📦"}
+)
+
+// email Уникальный адрес: номер пользователя входит в локальную часть
+func email(r *rand.Rand, i int) string {
+	first, last := pick(r, firstNames), pick(r, lastNames)
+	if r.IntN(4) == 0 {
+		return fmt.Sprintf("%s+tasks%d@%s", first, i, pick(r, domains))
+	}
+	return fmt.Sprintf("%s.%s%d@%s", first, last, i, pick(r, domains))
+}
+
+// password Случайный пароль из 16 символов
+func password(r *rand.Rand) string {
+	const alphabet = "abcdefghijkmnopqrstuvwxyzABCDEFGHJKLMNPQRSTUVWXYZ23456789!@#%"
+	var b strings.Builder
+	for range 16 {
+		b.WriteByte(alphabet[r.IntN(len(alphabet))])
+	}
+	return b.String()
+}
+
+// plainName Короткое название вида "review budget for Q3"
+func plainName(r *rand.Rand) string {
+	return pick(r, verbs) + " " + pick(r, objects) + pick(r, suffixes)
+}
+
+// unicodeName Название не на латинице, иногда с номером
+func unicodeName(r *rand.Rand) string {
+	name := pick(r, unicodeNames)
+	if r.IntN(3) == 0 {
+		name += fmt.Sprintf(" №%d", r.IntN(100)+1)
+	}
+	return name
+}
+
+// longName Название длиной от 200 до 255 символов: на границе ограничений спецификации и БД
+func longName(r *rand.Rand) string {
+	target := 200 + r.IntN(maxNameLength-200+1)
+	var b strings.Builder
+	for utf8.RuneCountInString(b.String()) < target {
+		if b.Len() > 0 {
+			b.WriteString(", then ")
+		}
+		if r.IntN(4) == 0 {
+			b.WriteString(pick(r, unicodeNames))
+		} else {
+			b.WriteString(plainName(r))
+		}
+	}
+	return strings.TrimSpace(string([]rune(b.String())[:target]))
+}
+
+func pick(r *rand.Rand, words []string) string {
+	return words[r.IntN(len(words))]
+}
@@ -0,0 +1,129 @@
+package seed
+
+import (
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/taskService"
+	"POSTnGETtrain/internal/userService"
+	"testing"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerate(t *testing.T) {
+	cfg := Config{Users: 50, Tasks: 2000, DoneRatio: 0.3, LongRatio: 0.1, UnicodeRatio: 0.2, Seed: 7}
+	dataset, err := Generate(cfg)
+	require.NoError(t, err)
+	require.Len(t, dataset.Users, cfg.Users)
+	require.Len(t, dataset.Tasks, cfg.Tasks)
+
+	again, err := Generate(cfg)
+	require.NoError(t, err)
+	assert.Equal(t, dataset, again, "одинаковое зерно - одинаковые данные")
+
+	cfg.Seed = 8
+	other, err := Generate(cfg)
+	require.NoError(t, err)
+	assert.NotEqual(t, dataset, other)
+
+	emails := make(map[string]bool)
+	for _, user := range dataset.Users {
+		assert.False(t, emails[user.Email], "email %s повторяется", user.Email)
+		emails[user.Email] = true
+		assert.NotEmpty(t, user.Password)
+	}
+
+	var done, long, nonLatin int
+	perOwner := make(map[int]int)
+	for _, task := range dataset.Tasks {
+		length := utf8.RuneCountInString(task.Name)
+		require.True(t, length > 0 && length <= maxNameLength, "длина %d: %q", length, task.Name)
+		if task.IsDone {
+			done++
+		}
+		if length >= 200 {
+			long++
+		}
+		if hasNonLatin(task.Name) {
+			nonLatin++
+		}
+		perOwner[task.Owner]++
+	}
+
+	// Доли близки к заданным
+	assert.InDelta(t, 0.3, float64(done)/float64(cfg.Tasks), 0.05)
+	assert.InDelta(t, 0.1, float64(long)/float64(cfg.Tasks), 0.03)
+	assert.Greater(t, nonLatin, cfg.Tasks/5)
+
+	// Самый нагруженный пользователь владеет заметно большей долей задач, чем при равном распределении
+	heaviest := 0
+	for _, count := range perOwner {
+		heaviest = max(heaviest, count)
+	}
+	assert.Greater(t, heaviest, 3*cfg.Tasks/cfg.Users)
+}
+
+func TestGenerateInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name string
+		cfg  Config
+	}{
+		{name: "отрицательное количество", cfg: Config{Users: -1}},
+		{name: "задачи без пользователей", cfg: Config{Tasks: 1}},
+		{name: "доля больше единицы", cfg: Config{Users: 1, DoneRatio: 1.5}},
+		{name: "длинных и unicode больше всех задач", cfg: Config{Users: 1, LongRatio: 0.6, UnicodeRatio: 0.6}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := Generate(tt.cfg)
+			assert.ErrorIs(t, err, ErrInvalidConfig)
+		})
+	}
+}
+
+func TestLoad(t *testing.T) {
+	taskRepo := taskService.NewMemoryTaskRepository()
+	users := userService.NewUserService(userService.NewMemoryUserRepository(taskRepo))
+	tasks := taskService.NewTaskService(taskRepo)
+
+	dataset, err := Generate(DefaultConfig())
+	require.NoError(t, err)
+	result, err := Load(t.Context(), dataset, users, tasks)
+	require.NoError(t, err)
+	assert.Len(t, result.Users, len(dataset.Users))
+	assert.Len(t, result.Tasks, len(dataset.Tasks))
+
+	stored, err := tasks.GetAllTasks(t.Context(), models.Page{})
+	require.NoError(t, err)
+	assert.Len(t, stored, len(dataset.Tasks))
+	for i, task := range result.Tasks {
+		assert.Equal(t, dataset.Tasks[i].Name, task.Name)
+		assert.Equal(t, result.Users[dataset.Tasks[i].Owner].ID, task.UserID)
+	}
+
+	// Повторная загрузка упирается в правило уникальности email
+	_, err = Load(t.Context(), dataset, users, tasks)
+	assert.ErrorIs(t, err, userService.ErrEmailExists)
+}
+
+func hasNonLatin(s string) bool {
+	for _, r := range s {
+		if unicode.IsLetter(r) && !unicode.In(r, unicode.Latin) {
+			return true
+		}
+	}
+	return false
+}
+
+func BenchmarkGenerate(b *testing.B) {
+	cfg := DefaultConfig()
+	cfg.Users, cfg.Tasks = 1000, 10000
+	for b.Loop() {
+		if _, err := Generate(cfg); err != nil {
+			b.Fatal(err)
+		}
+	}
+}
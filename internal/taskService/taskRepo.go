@@ -2,6 +2,8 @@ package taskService
 
 import (
 	"POSTnGETtrain/internal/models"
+	"context"
+	"errors"
 	"fmt"
 
 	"gorm.io/gorm"
@@ -9,12 +11,12 @@ import (
 
 // TaskRepository Интерфейс репозитория для работы с задачами CRUD
 type TaskRepository interface {
-	GetAll() ([]models.Task, error)
-	GetByID(id string) (models.Task, error)
-	GetByUserID(userID string) ([]models.Task, error)
-	Create(task models.Task) (models.Task, error)
-	Update(task models.Task) (models.Task, error)
-	Delete(id string) error
+	GetAll(ctx context.Context, page models.Page) ([]models.Task, error)
+	GetByID(ctx context.Context, id string) (models.Task, error)
+	GetByUserID(ctx context.Context, userID string) ([]models.Task, error)
+	Create(ctx context.Context, task models.Task) (models.Task, error)
+	Update(ctx context.Context, task models.Task) (models.Task, error)
+	Delete(ctx context.Context, id string, version *int64) error
 }
 
 // Структура, которая реализует все методы TaskRepository
@@ -27,13 +29,13 @@ func NewTaskRepository(db *gorm.DB) TaskRepository {
 	return &taskRepository{db: db} // возвращаем из функции: заворачиваем taskRepository в TaskRepository
 }
 
-// GetAll Извлекаем все неудаленные таски из БД
-func (r *taskRepository) GetAll() ([]models.Task, error) {
+// GetAll Извлекаем неудаленные таски из БД в пределах страницы
+func (r *taskRepository) GetAll(ctx context.Context, page models.Page) ([]models.Task, error) {
 	// Всегда начинаем с инициализированного слайса
 	tasks := make([]models.Task, 0)
 
 	// Выполняем запрос
-	result := r.db.Where("deleted_at IS NULL").Find(&tasks)
+	result := paginate(r.db.WithContext(ctx).Where("deleted_at IS NULL"), page).Find(&tasks)
 
 	// Обрабатываем ошибки
 	if result.Error != nil {
@@ -45,10 +47,13 @@ func (r *taskRepository) GetAll() ([]models.Task, error) {
 }
 
 // GetByID Поиск задачи по ID
-func (r *taskRepository) GetByID(id string) (models.Task, error) {
+func (r *taskRepository) GetByID(ctx context.Context, id string) (models.Task, error) {
 	var task models.Task // место, чтобы временно разместить таску из БД
 
-	result := r.db.Where("id = ? AND deleted_at IS NULL", id).First(&task)
+	result := r.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL", id).First(&task)
+	if errors.Is(result.Error, gorm.ErrRecordNotFound) {
+		return models.Task{}, fmt.Errorf("repo: could not get task by id: %w", ErrTaskNotFound)
+	}
 	if result.Error != nil {
 		return models.Task{}, fmt.Errorf("repo: could not get task by id: %w", result.Error)
 	}
@@ -56,34 +61,74 @@ func (r *taskRepository) GetByID(id string) (models.Task, error) {
 }
 
 // Create Создание задачи
-func (r *taskRepository) Create(task models.Task) (models.Task, error) {
-	err := r.db.Create(&task).Error
+func (r *taskRepository) Create(ctx context.Context, task models.Task) (models.Task, error) {
+	err := r.db.WithContext(ctx).Create(&task).Error
 	return task, err
 }
 
-// Update Редактирование задачи
-func (r *taskRepository) Update(task models.Task) (models.Task, error) {
-	err := r.db.Save(&task).Error
-	return task, err
+// Update Редактирование задачи. Запись обновляется, только если её версия
+// в БД совпадает с task.Version, иначе возвращается ErrVersionConflict
+func (r *taskRepository) Update(ctx context.Context, task models.Task) (models.Task, error) {
+	result := r.db.WithContext(ctx).Model(&models.Task{}).
+		Where("id = ? AND version = ? AND deleted_at IS NULL", task.ID, task.Version).
+		Updates(map[string]interface{}{
+			"name":    task.Name,
+			"is_done": task.IsDone,
+			"user_id": task.UserID,
+			"version": gorm.Expr("version + 1"), // Каждое изменение увеличивает версию
+		})
+	if result.Error != nil {
+		return models.Task{}, fmt.Errorf("repo: could not update task %s: %w", task.ID, result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return models.Task{}, ErrVersionConflict // Кто-то успел изменить задачу раньше нас
+	}
+
+	task.Version++
+	return task, nil
 }
 
-// Delete Удаление (мягкое) задачи
-func (r *taskRepository) Delete(id string) error {
-	result := r.db.Where("id = ? AND deleted_at IS NULL", id).Delete(&models.Task{})
+// Delete Удаление (мягкое) задачи. Если передана версия, задача удаляется только при её совпадении
+func (r *taskRepository) Delete(ctx context.Context, id string, version *int64) error {
+	query := r.db.WithContext(ctx).Where("id = ? AND deleted_at IS NULL", id)
+	if version != nil {
+		query = query.Where("version = ?", *version)
+	}
+
+	result := query.Delete(&models.Task{})
 	if result.Error != nil {
 		return result.Error
 	}
 	if result.RowsAffected == 0 {
-		return gorm.ErrRecordNotFound
+		if version == nil {
+			return ErrTaskNotFound
+		}
+		// Отличаем отсутствующую задачу от устаревшей версии
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return err
+		}
+		return ErrVersionConflict
 	}
 	return nil
 }
 
-func (r *taskRepository) GetByUserID(userID string) ([]models.Task, error) {
+func (r *taskRepository) GetByUserID(ctx context.Context, userID string) ([]models.Task, error) {
 	var tasks []models.Task
-	result := r.db.Where("user_id = ? AND deleted_at IS NULL", userID).Find(&tasks)
+	result := r.db.WithContext(ctx).Where("user_id = ? AND deleted_at IS NULL", userID).Find(&tasks)
 	if result.Error != nil {
 		return nil, fmt.Errorf("repo: could not get tasks for user %s: %w", userID, result.Error)
 	}
 	return tasks, nil
 }
+
+// paginate Применяет страницу к запросу. Сортировка по id нужна, чтобы страницы не пересекались
+func paginate(query *gorm.DB, page models.Page) *gorm.DB {
+	query = query.Order("id")
+	if page.Limit > 0 {
+		query = query.Limit(page.Limit)
+	}
+	if page.Offset > 0 {
+		query = query.Offset(page.Offset)
+	}
+	return query
+}
@@ -0,0 +1,108 @@
+package taskService
+
+import (
+	"POSTnGETtrain/internal/models"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memoryTaskRepository Реализация TaskRepository в памяти процесса.
+// Повторяет поведение репозитория на GORM: мягкое удаление, проверка версии, сортировка по id
+type memoryTaskRepository struct {
+	mu    sync.RWMutex
+	tasks map[string]models.Task // Все задачи, включая мягко удаленные
+}
+
+// NewMemoryTaskRepository Конструктор репозитория в памяти (для локального запуска и тестов)
+func NewMemoryTaskRepository() TaskRepository {
+	return &memoryTaskRepository{tasks: make(map[string]models.Task)}
+}
+
+// alive Неудаленные задачи, упорядоченные по id
+func (r *memoryTaskRepository) alive(keep func(models.Task) bool) []models.Task {
+	tasks := make([]models.Task, 0)
+	for _, task := range r.tasks {
+		if !task.DeletedAt.Valid && keep(task) {
+			tasks = append(tasks, task)
+		}
+	}
+	slices.SortFunc(tasks, func(a, b models.Task) int { return strings.Compare(a.ID, b.ID) })
+	return tasks
+}
+
+func (r *memoryTaskRepository) GetAll(ctx context.Context, page models.Page) ([]models.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return window(r.alive(func(models.Task) bool { return true }), page), nil
+}
+
+func (r *memoryTaskRepository) GetByID(ctx context.Context, id string) (models.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	task, ok := r.tasks[id]
+	if !ok || task.DeletedAt.Valid {
+		return models.Task{}, ErrTaskNotFound
+	}
+	return task, nil
+}
+
+func (r *memoryTaskRepository) GetByUserID(ctx context.Context, userID string) ([]models.Task, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.alive(func(task models.Task) bool { return task.UserID == userID }), nil
+}
+
+func (r *memoryTaskRepository) Create(ctx context.Context, task models.Task) (models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	task.CreatedAt, task.UpdatedAt = now, now
+	r.tasks[task.ID] = task
+	return task, nil
+}
+
+func (r *memoryTaskRepository) Update(ctx context.Context, task models.Task) (models.Task, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.tasks[task.ID]
+	if !ok || stored.DeletedAt.Valid || stored.Version != task.Version {
+		return models.Task{}, ErrVersionConflict // Как и в GORM: обновлено 0 строк
+	}
+
+	task.Version++
+	task.CreatedAt, task.UpdatedAt = stored.CreatedAt, time.Now()
+	task.DeletedAt = stored.DeletedAt
+	r.tasks[task.ID] = task
+	return task, nil
+}
+
+func (r *memoryTaskRepository) Delete(ctx context.Context, id string, version *int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	task, ok := r.tasks[id]
+	if !ok || task.DeletedAt.Valid {
+		return ErrTaskNotFound
+	}
+	if version != nil && *version != task.Version {
+		return ErrVersionConflict
+	}
+
+	task.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	r.tasks[id] = task
+	return nil
+}
+
+// window Вырезает страницу из упорядоченного списка
+func window(tasks []models.Task, page models.Page) []models.Task {
+	start := min(page.Offset, len(tasks))
+	end := len(tasks)
+	if page.Limit > 0 {
+		end = min(start+page.Limit, end)
+	}
+	return tasks[start:end]
+}
@@ -2,6 +2,7 @@ package taskService
 
 import (
 	"POSTnGETtrain/internal/models"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,8 +11,8 @@ type MockTaskRepository struct {
 	mock.Mock
 }
 
-func (m *MockTaskRepository) Create(task models.Task) (models.Task, error) {
-	args := m.Called(task)              // записываем вызов метода с аргументом task
+func (m *MockTaskRepository) Create(ctx context.Context, task models.Task) (models.Task, error) {
+	args := m.Called(ctx, task)         // записываем вызов метода с аргументом task
 	var t models.Task                   // переменная для возврата
 	if res := args.Get(0); res != nil { // получим первый элемент с его проверкой
 		t = res.(models.Task) // res интерфейс{} преобразуется в тип Task
@@ -19,16 +20,16 @@ func (m *MockTaskRepository) Create(task models.Task) (models.Task, error) {
 	return t, args.Error(1)
 }
 
-func (m *MockTaskRepository) GetAll() ([]models.Task, error) {
-	args := m.Called()                  // Фиксируем вызов без аргументов
+func (m *MockTaskRepository) GetAll(ctx context.Context, page models.Page) ([]models.Task, error) {
+	args := m.Called(ctx, page)         // Фиксируем вызов со страницей
 	if res := args.Get(0); res != nil { // проверяем первый возвращаемый аргумент
 		return res.([]models.Task), args.Error(1) // res интерфейс{} преобразуется в тип Task
 	}
 	return []models.Task{}, args.Error(1) // если nil, возвращаем пустой слайс
 }
 
-func (m *MockTaskRepository) GetByID(id string) (models.Task, error) {
-	args := m.Called(id)                // вызываем метод с аргументом айди
+func (m *MockTaskRepository) GetByID(ctx context.Context, id string) (models.Task, error) {
+	args := m.Called(ctx, id)           // вызываем метод с аргументом айди
 	var t models.Task                   // создаем переменную для результата
 	if res := args.Get(0); res != nil { // проверяем первый возвращаемый аргумент
 		t = res.(models.Task) // res интерфейс{} преобразуется в тип Task
@@ -36,16 +37,16 @@ func (m *MockTaskRepository) GetByID(id string) (models.Task, error) {
 	return t, args.Error(1)
 }
 
-func (m *MockTaskRepository) GetByUserID(userID string) ([]models.Task, error) {
-	args := m.Called(userID)
+func (m *MockTaskRepository) GetByUserID(ctx context.Context, userID string) ([]models.Task, error) {
+	args := m.Called(ctx, userID)
 	if res := args.Get(0); res != nil {
 		return res.([]models.Task), args.Error(1)
 	}
 	return []models.Task{}, args.Error(1)
 }
 
-func (m *MockTaskRepository) Update(task models.Task) (models.Task, error) {
-	args := m.Called(task) // вызов с аргументом task
+func (m *MockTaskRepository) Update(ctx context.Context, task models.Task) (models.Task, error) {
+	args := m.Called(ctx, task) // вызов с аргументом task
 	var t models.Task
 	if res := args.Get(0); res != nil {
 		t = res.(models.Task) // res интерфейс{} преобразуется в тип Task
@@ -53,7 +54,7 @@ func (m *MockTaskRepository) Update(task models.Task) (models.Task, error) {
 	return t, args.Error(1)
 }
 
-func (m *MockTaskRepository) Delete(id string) error {
-	args := m.Called(id) // фиксируем вызов с аргументом id
+func (m *MockTaskRepository) Delete(ctx context.Context, id string, version *int64) error {
+	args := m.Called(ctx, id, version) // фиксируем вызов с аргументами id и version
 	return args.Error(0)
 }
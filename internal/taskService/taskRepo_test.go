@@ -0,0 +1,158 @@
+package taskService
+
+import (
+	"POSTnGETtrain/internal/db/dbtest"
+	"POSTnGETtrain/internal/models"
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestTaskRepository Один и тот же набор проверок для всех реализаций TaskRepository
+func TestTaskRepository(t *testing.T) {
+	implementations := map[string]func(t *testing.T) TaskRepository{
+		"memory": func(*testing.T) TaskRepository { return NewMemoryTaskRepository() },
+		"gorm": func(t *testing.T) TaskRepository {
+			db := dbtest.Open(t)
+			// tasks.user_id ссылается на users: создаем владельцев, которых используют проверки
+			for _, id := range []string{"u1", "u2"} {
+				require.NoError(t, db.Create(&models.User{ID: id, Email: id + "@example.com", Password: "secret"}).Error)
+			}
+			return NewTaskRepository(db)
+		},
+	}
+
+	for name, newRepo := range implementations {
+		t.Run(name, func(t *testing.T) {
+			t.Run("создание и получение", func(t *testing.T) {
+				repo := newRepo(t)
+				created, err := repo.Create(t.Context(), models.Task{ID: "1", Name: "Buy milk", UserID: "u1", Version: 1})
+				require.NoError(t, err)
+
+				got, err := repo.GetByID(t.Context(), created.ID)
+				require.NoError(t, err)
+				assert.Equal(t, "Buy milk", got.Name)
+				assert.Equal(t, int64(1), got.Version)
+
+				_, err = repo.GetByID(t.Context(), "missing")
+				assert.ErrorIs(t, err, ErrTaskNotFound)
+			})
+
+			t.Run("страницы упорядочены по id", func(t *testing.T) {
+				repo := newRepo(t)
+				for _, id := range []string{"3", "1", "2"} {
+					_, err := repo.Create(t.Context(), models.Task{ID: id, Name: "Task " + id, UserID: "u1", Version: 1})
+					require.NoError(t, err)
+				}
+
+				all, err := repo.GetAll(t.Context(), models.Page{})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"1", "2", "3"}, taskIDs(all))
+
+				page, err := repo.GetAll(t.Context(), models.Page{Limit: 2, Offset: 1})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"2", "3"}, taskIDs(page))
+
+				empty, err := repo.GetAll(t.Context(), models.Page{Offset: 10})
+				require.NoError(t, err)
+				assert.Empty(t, empty)
+			})
+
+			t.Run("обновление проверяет версию", func(t *testing.T) {
+				repo := newRepo(t)
+				_, err := repo.Create(t.Context(), models.Task{ID: "1", Name: "Buy milk", UserID: "u1", Version: 1})
+				require.NoError(t, err)
+
+				updated, err := repo.Update(t.Context(), models.Task{ID: "1", Name: "Buy bread", UserID: "u1", Version: 1})
+				require.NoError(t, err)
+				assert.Equal(t, int64(2), updated.Version)
+
+				_, err = repo.Update(t.Context(), models.Task{ID: "1", Name: "Buy tea", UserID: "u1", Version: 1})
+				assert.ErrorIs(t, err, ErrVersionConflict)
+
+				got, err := repo.GetByID(t.Context(), "1")
+				require.NoError(t, err)
+				assert.Equal(t, "Buy bread", got.Name)
+				assert.Equal(t, int64(2), got.Version)
+			})
+
+			t.Run("параллельные изменения одной версии", func(t *testing.T) {
+				repo := newRepo(t)
+				_, err := repo.Create(t.Context(), models.Task{ID: "1", Name: "Buy milk", UserID: "u1", Version: 1})
+				require.NoError(t, err)
+
+				// Из нескольких изменений версии 1 проходит ровно одно
+				var wg sync.WaitGroup
+				var succeeded atomic.Int32
+				for i := range 8 {
+					wg.Add(1)
+					go func() {
+						defer wg.Done()
+						name := fmt.Sprintf("Buy milk %d", i)
+						if _, err := repo.Update(t.Context(), models.Task{ID: "1", Name: name, UserID: "u1", Version: 1}); err == nil {
+							succeeded.Add(1)
+						}
+					}()
+				}
+				wg.Wait()
+				assert.Equal(t, int32(1), succeeded.Load())
+			})
+
+			t.Run("мягкое удаление", func(t *testing.T) {
+				repo := newRepo(t)
+				for _, id := range []string{"1", "2"} {
+					_, err := repo.Create(t.Context(), models.Task{ID: id, Name: "Task " + id, UserID: "u1", Version: 1})
+					require.NoError(t, err)
+				}
+
+				stale := int64(5)
+				assert.ErrorIs(t, repo.Delete(t.Context(), "1", &stale), ErrVersionConflict)
+				assert.ErrorIs(t, repo.Delete(t.Context(), "missing", nil), ErrTaskNotFound)
+
+				current := int64(1)
+				require.NoError(t, repo.Delete(t.Context(), "1", &current))
+				assert.ErrorIs(t, repo.Delete(t.Context(), "1", nil), ErrTaskNotFound)
+
+				_, err := repo.GetByID(t.Context(), "1")
+				assert.ErrorIs(t, err, ErrTaskNotFound)
+				_, err = repo.Update(t.Context(), models.Task{ID: "1", Name: "Task 1", UserID: "u1", Version: 1})
+				assert.ErrorIs(t, err, ErrVersionConflict)
+
+				all, err := repo.GetAll(t.Context(), models.Page{})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"2"}, taskIDs(all))
+				byUser, err := repo.GetByUserID(t.Context(), "u1")
+				require.NoError(t, err)
+				assert.Equal(t, []string{"2"}, taskIDs(byUser))
+			})
+
+			t.Run("задачи пользователя", func(t *testing.T) {
+				repo := newRepo(t)
+				_, err := repo.Create(t.Context(), models.Task{ID: "1", Name: "Mine", UserID: "u1", Version: 1})
+				require.NoError(t, err)
+				_, err = repo.Create(t.Context(), models.Task{ID: "2", Name: "Other", UserID: "u2", Version: 1})
+				require.NoError(t, err)
+
+				tasks, err := repo.GetByUserID(t.Context(), "u1")
+				require.NoError(t, err)
+				assert.Equal(t, []string{"1"}, taskIDs(tasks))
+
+				none, err := repo.GetByUserID(t.Context(), "u3")
+				require.NoError(t, err)
+				assert.Empty(t, none)
+			})
+		})
+	}
+}
+
+func taskIDs(tasks []models.Task) []string {
+	ids := make([]string, 0, len(tasks))
+	for _, task := range tasks {
+		ids = append(ids, task.ID)
+	}
+	return ids
+}
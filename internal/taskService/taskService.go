@@ -1,20 +1,32 @@
 package taskService
 
 import (
+	"POSTnGETtrain/internal/logging"
+	"POSTnGETtrain/internal/metrics"
 	"POSTnGETtrain/internal/models"
+	"context"
+	"errors"
 	"fmt"
+	"log/slog"
 
 	"github.com/google/uuid" // Пакет для генерации UUID
 )
 
+// Глобальные ошибки сервиса
+var (
+	ErrTaskNotFound    = errors.New("task not found")
+	ErrVersionConflict = errors.New("task version conflict") // Версия задачи не совпала с ожидаемой
+	ErrInvalidTask     = errors.New("invalid task")          // Изменения нарушают обязательные поля задачи
+)
+
 // TaskService - интерфейс сервиса для работы с задачами
 type TaskService interface {
-	GetAllTasks() ([]models.Task, error)                                                   // Получить все задачи
-	GetTaskByID(id string) (models.Task, error)                                            // Получить задачу по ID
-	CreateTask(name string, isDone bool, userID string) (models.Task, error)               // Создать новую задачу
-	UpdateTask(id string, name *string, isDone *bool, userID *string) (models.Task, error) // Обновить задачу
-	DeleteTask(id string) error                                                            // Удалить задачу
-	GetTasksByUserID(userID string) ([]models.Task, error)
+	GetAllTasks(ctx context.Context, page models.Page) ([]models.Task, error)                                   // Получить страницу задач
+	GetTaskByID(ctx context.Context, id string) (models.Task, error)                                            // Получить задачу по ID
+	CreateTask(ctx context.Context, name string, isDone bool, userID string) (models.Task, error)               // Создать новую задачу
+	UpdateTask(ctx context.Context, id string, version *int64, changes models.TaskChanges) (models.Task, error) // Обновить задачу
+	DeleteTask(ctx context.Context, id string, version *int64) error                                            // Удалить задачу
+	GetTasksByUserID(ctx context.Context, userID string) ([]models.Task, error)
 }
 
 // Реализация интерфейса TaskService
@@ -27,63 +39,103 @@ func NewTaskService(r TaskRepository) TaskService {
 	return &taskService{repo: r} // Возвращаем указатель на созданный сервис
 }
 
-// GetAllTasks - получение всех задач
-func (s *taskService) GetAllTasks() ([]models.Task, error) {
-	return s.repo.GetAll() // Получаем список задач через репозиторий
+// GetAllTasks - получение страницы задач
+func (s *taskService) GetAllTasks(ctx context.Context, page models.Page) ([]models.Task, error) {
+	return s.repo.GetAll(ctx, page) // Получаем список задач через репозиторий
 }
 
 // GetTaskByID Получение задачи по идентификатору
-func (s *taskService) GetTaskByID(id string) (models.Task, error) {
-	return s.repo.GetByID(id) // Получаем задачу через репозиторий
+func (s *taskService) GetTaskByID(ctx context.Context, id string) (models.Task, error) {
+	return s.repo.GetByID(ctx, id) // Получаем задачу через репозиторий
 }
 
 // CreateTask Создание новой задачи
-func (s *taskService) CreateTask(name string, isDone bool, userID string) (models.Task, error) {
+func (s *taskService) CreateTask(ctx context.Context, name string, isDone bool, userID string) (models.Task, error) {
 
 	task := models.Task{
-		ID:     uuid.NewString(), // Генерируем новый UUID
-		Name:   name,             // Устанавливаем название
-		IsDone: isDone,           // Устанавливаем статус
-		UserID: userID,           // Принадлежность пользователю
+		ID:      uuid.NewString(), // Генерируем новый UUID
+		Name:    name,             // Устанавливаем название
+		IsDone:  isDone,           // Устанавливаем статус
+		UserID:  userID,           // Принадлежность пользователю
+		Version: 1,                // Первая версия задачи
+	}
+	created, err := s.repo.Create(ctx, task) // Сохраняем через репозиторий
+	if err != nil {
+		return models.Task{}, err
 	}
-	return s.repo.Create(task) // Сохраняем через репозиторий
+	metrics.TasksCreated.Inc()
+	logging.FromContext(ctx).DebugContext(ctx, "task created",
+		slog.String("task_id", created.ID), slog.String("user_id", created.UserID))
+	return created, nil
 }
 
-// UpdateTask Обновление существующей задачи
-func (s *taskService) UpdateTask(id string, name *string, isDone *bool, userID *string) (models.Task, error) {
+// UpdateTask Обновление существующей задачи набором изменений.
+// Если передана версия, задача обновляется только при её совпадении с текущей
+func (s *taskService) UpdateTask(ctx context.Context, id string, version *int64, changes models.TaskChanges) (models.Task, error) {
 	// Получаем текущую задачу из репозитория
-	task, err := s.repo.GetByID(id)
+	task, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return models.Task{}, err // Возвращаем ошибку если задача не найдена
 	}
 
-	// Обновляем название если передан новый параметр
-	if name != nil {
-		task.Name = *name // Забираем название
+	// Клиент редактировал устаревшую версию задачи
+	if version != nil && *version != task.Version {
+		logging.FromContext(ctx).DebugContext(ctx, "task version conflict",
+			slog.String("task_id", id), slog.Int64("expected", *version), slog.Int64("actual", task.Version))
+		return models.Task{}, ErrVersionConflict
 	}
 
-	// Обновляем статус если передан новый параметр
-	if isDone != nil {
-		task.IsDone = *isDone // Забираем статус выполнения
+	wasDone := task.IsDone
+	if err := applyTaskChanges(&task, changes); err != nil {
+		return models.Task{}, err
 	}
 
-	if userID != nil {
-		task.UserID = *userID
+	// Сохраняем измененную задачу через репозиторий
+	updated, err := s.repo.Update(ctx, task)
+	if err != nil {
+		return models.Task{}, err
 	}
+	if !wasDone && updated.IsDone {
+		metrics.TasksCompleted.Inc()
+	}
+	return updated, nil
+}
 
-	// Сохраняем измененную задачу через репозиторий
-	return s.repo.Update(task)
+// applyTaskChanges Переносит изменения в задачу. Все поля задачи обязательные,
+// поэтому очистить их (null или пустая строка) нельзя
+func applyTaskChanges(task *models.Task, changes models.TaskChanges) error {
+	if changes.Name.Set {
+		if changes.Name.Null || changes.Name.Value == "" {
+			return fmt.Errorf("%w: name must not be empty", ErrInvalidTask)
+		}
+		task.Name = changes.Name.Value // Забираем название
+	}
+
+	if changes.IsDone.Set {
+		if changes.IsDone.Null {
+			return fmt.Errorf("%w: is_done must not be null", ErrInvalidTask)
+		}
+		task.IsDone = changes.IsDone.Value // Забираем статус выполнения
+	}
+
+	if changes.UserID.Set {
+		if changes.UserID.Null || changes.UserID.Value == "" {
+			return fmt.Errorf("%w: user_id must not be empty", ErrInvalidTask)
+		}
+		task.UserID = changes.UserID.Value
+	}
+	return nil
 }
 
-// DeleteTask Удаление задачи по ИДу
-func (s *taskService) DeleteTask(id string) error {
-	if err := s.repo.Delete(id); err != nil {
+// DeleteTask Удаление задачи по ИДу (с проверкой версии, если она передана)
+func (s *taskService) DeleteTask(ctx context.Context, id string, version *int64) error {
+	if err := s.repo.Delete(ctx, id, version); err != nil {
 		return fmt.Errorf("service: could not delete task %s: %w", id, err)
 	}
 	return nil
 }
 
-func (s *taskService) GetTasksByUserID(userID string) ([]models.Task, error) {
+func (s *taskService) GetTasksByUserID(ctx context.Context, userID string) ([]models.Task, error) {
 	// Метод в репозитории!
-	return s.repo.GetByUserID(userID)
+	return s.repo.GetByUserID(ctx, userID)
 }
@@ -1,12 +1,16 @@
 package taskService
 
 import (
+	"POSTnGETtrain/internal/metrics"
 	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/pkg/patch"
 	"errors"
 	"testing"
 
+	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateTask(t *testing.T) {
@@ -20,7 +24,7 @@ func TestCreateTask(t *testing.T) {
 			name:  "успешное создание",
 			input: models.Task{Name: "Test Task", IsDone: false},
 			mockSetup: func(m *MockTaskRepository, input models.Task) {
-				m.On("Create", mock.MatchedBy(func(t models.Task) bool {
+				m.On("Create", mock.Anything, mock.MatchedBy(func(t models.Task) bool {
 					return t.Name == input.Name && t.IsDone == input.IsDone && t.UserID == "test-user-id"
 				})).Return(input, nil)
 			},
@@ -30,7 +34,7 @@ func TestCreateTask(t *testing.T) {
 			name:  "ошибка создания",
 			input: models.Task{Name: "Bad Task", IsDone: false},
 			mockSetup: func(m *MockTaskRepository, input models.Task) {
-				m.On("Create", mock.AnythingOfType("models.Task")).Return(models.Task{},
+				m.On("Create", mock.Anything, mock.AnythingOfType("models.Task")).Return(models.Task{},
 					errors.New("db error"))
 			},
 			wantErr: true,
@@ -43,7 +47,7 @@ func TestCreateTask(t *testing.T) {
 			tt.mockSetup(mockRepo, tt.input)
 
 			service := NewTaskService(mockRepo)
-			_, err := service.CreateTask(tt.input.Name, tt.input.IsDone, "test-user-id")
+			_, err := service.CreateTask(t.Context(), tt.input.Name, tt.input.IsDone, "test-user-id")
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -66,7 +70,7 @@ func TestGetAllTasks(t *testing.T) {
 		{
 			name: "успешное получение всех задач",
 			mockSetup: func(m *MockTaskRepository) {
-				m.On("GetAll").Return([]models.Task{
+				m.On("GetAll", mock.Anything, models.Page{Limit: 2}).Return([]models.Task{
 					{ID: "1", Name: "Task 1", IsDone: false},
 					{ID: "2", Name: "Task 2", IsDone: true},
 				}, nil)
@@ -80,7 +84,7 @@ func TestGetAllTasks(t *testing.T) {
 		{
 			name: "ошибка репозитория",
 			mockSetup: func(m *MockTaskRepository) {
-				m.On("GetAll").Return(nil, errors.New("db error"))
+				m.On("GetAll", mock.Anything, models.Page{Limit: 2}).Return(nil, errors.New("db error"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -93,7 +97,7 @@ func TestGetAllTasks(t *testing.T) {
 			tt.mockSetup(mockRepo)
 
 			service := NewTaskService(mockRepo)
-			result, err := service.GetAllTasks()
+			result, err := service.GetAllTasks(t.Context(), models.Page{Limit: 2})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -118,7 +122,7 @@ func TestGetTaskByID(t *testing.T) {
 			name: "успешное получение",
 			id:   "1",
 			mockSetup: func(m *MockTaskRepository, id string) {
-				m.On("GetByID", id).Return(models.Task{
+				m.On("GetByID", mock.Anything, id).Return(models.Task{
 					ID: id, Name: "Test Task", IsDone: false}, nil)
 
 			},
@@ -129,7 +133,7 @@ func TestGetTaskByID(t *testing.T) {
 			name: "ошибка получения",
 			id:   "99",
 			mockSetup: func(m *MockTaskRepository, id string) {
-				m.On("GetByID", id).Return(models.Task{}, errors.New("not found"))
+				m.On("GetByID", mock.Anything, id).Return(models.Task{}, errors.New("not found"))
 			},
 			want:    models.Task{},
 			wantErr: true,
@@ -142,7 +146,7 @@ func TestGetTaskByID(t *testing.T) {
 			tt.mockSetup(mockRepo, tt.id)
 
 			service := NewTaskService(mockRepo)
-			result, err := service.GetTaskByID(tt.id)
+			result, err := service.GetTaskByID(t.Context(), tt.id)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -157,38 +161,85 @@ func TestGetTaskByID(t *testing.T) {
 }
 
 func TestUpdateTask(t *testing.T) {
-	name := "Updated"
-	isDone := true
-	userID := "new-user-id"
+	currentVersion := int64(1)
+	staleVersion := int64(0)
 
 	tests := []struct {
 		name      string
 		id        string
-		newName   *string
-		newDone   *bool
-		newUserID *string
+		version   *int64
+		changes   models.TaskChanges
 		mockSetup func(m *MockTaskRepository, id string, existing models.Task, updated models.Task)
 		want      models.Task
 		wantErr   bool
+		errIs     error
 	}{
 		{
-			name:      "успешное обновление",
-			id:        "1",
-			newName:   &name,
-			newDone:   &isDone,
-			newUserID: &userID,
+			name: "успешное обновление",
+			id:   "1",
+			changes: models.TaskChanges{
+				Name:   patch.Value("Updated"),
+				IsDone: patch.Value(true),
+				UserID: patch.Value("new-user-id"),
+			},
+			mockSetup: func(m *MockTaskRepository, id string, existing models.Task, updated models.Task) {
+				m.On("GetByID", mock.Anything, id).Return(existing, nil)
+				m.On("Update", mock.Anything, updated).Return(updated, nil)
+			},
+			want:    models.Task{ID: "1", Name: "Updated", IsDone: true, UserID: "new-user-id", Version: 1},
+			wantErr: false,
+		},
+		{
+			name:    "обновление с актуальной версией",
+			id:      "1",
+			version: &currentVersion,
+			changes: models.TaskChanges{Name: patch.Value("Updated")},
 			mockSetup: func(m *MockTaskRepository, id string, existing models.Task, updated models.Task) {
-				m.On("GetByID", id).Return(existing, nil)
-				m.On("Update", updated).Return(updated, nil)
+				m.On("GetByID", mock.Anything, id).Return(existing, nil)
+				m.On("Update", mock.Anything, updated).Return(updated, nil)
 			},
-			want:    models.Task{ID: "1", Name: "Updated", IsDone: true, UserID: "new-user-id"},
+			want:    models.Task{ID: "1", Name: "Updated", IsDone: false, UserID: "user-id", Version: 1},
 			wantErr: false,
 		},
+		{
+			name:    "устаревшая версия",
+			id:      "1",
+			version: &staleVersion,
+			changes: models.TaskChanges{Name: patch.Value("Updated")},
+			mockSetup: func(m *MockTaskRepository, id string, existing models.Task, updated models.Task) {
+				m.On("GetByID", mock.Anything, id).Return(existing, nil)
+			},
+			want:    models.Task{},
+			wantErr: true,
+			errIs:   ErrVersionConflict,
+		},
+		{
+			name:    "очистка обязательного поля",
+			id:      "1",
+			changes: models.TaskChanges{UserID: patch.Null[string]()},
+			mockSetup: func(m *MockTaskRepository, id string, existing models.Task, updated models.Task) {
+				m.On("GetByID", mock.Anything, id).Return(existing, nil)
+			},
+			want:    models.Task{},
+			wantErr: true,
+			errIs:   ErrInvalidTask,
+		},
+		{
+			name:    "пустое название",
+			id:      "1",
+			changes: models.TaskChanges{Name: patch.Value("")},
+			mockSetup: func(m *MockTaskRepository, id string, existing models.Task, updated models.Task) {
+				m.On("GetByID", mock.Anything, id).Return(existing, nil)
+			},
+			want:    models.Task{},
+			wantErr: true,
+			errIs:   ErrInvalidTask,
+		},
 		{
 			name: "ошибка получения задачи",
 			id:   "99",
 			mockSetup: func(m *MockTaskRepository, id string, existing models.Task, updated models.Task) {
-				m.On("GetByID", id).Return(models.Task{}, errors.New("not found"))
+				m.On("GetByID", mock.Anything, id).Return(models.Task{}, errors.New("not found"))
 			},
 			want:    models.Task{},
 			wantErr: true,
@@ -199,22 +250,28 @@ func TestUpdateTask(t *testing.T) {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockTaskRepository)
 
-			existing := models.Task{ID: tt.id, Name: "Old", IsDone: false}
+			existing := models.Task{ID: tt.id, Name: "Old", IsDone: false, UserID: "user-id", Version: 1}
 			updated := existing
-			if tt.newName != nil {
-				updated.Name = *tt.newName
+			if tt.changes.Name.Set {
+				updated.Name = tt.changes.Name.Value
 			}
-			if tt.newDone != nil {
-				updated.IsDone = *tt.newDone
+			if tt.changes.IsDone.Set {
+				updated.IsDone = tt.changes.IsDone.Value
+			}
+			if tt.changes.UserID.Set {
+				updated.UserID = tt.changes.UserID.Value
 			}
 
 			tt.mockSetup(mockRepo, tt.id, existing, updated)
 
 			service := NewTaskService(mockRepo)
-			result, err := service.UpdateTask(tt.id, tt.newName, tt.newDone, tt.newUserID)
+			result, err := service.UpdateTask(t.Context(), tt.id, tt.version, tt.changes)
 
 			if tt.wantErr {
 				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
 			} else {
 				assert.NoError(t, err)
 				assert.Equal(t, tt.want, result)
@@ -226,40 +283,58 @@ func TestUpdateTask(t *testing.T) {
 }
 
 func TestDeleteTask(t *testing.T) {
+	version := int64(2)
+
 	tests := []struct {
 		name      string
 		id        string
-		mockSetup func(m *MockTaskRepository, id string)
+		version   *int64
+		mockSetup func(m *MockTaskRepository, id string, version *int64)
 		wantErr   bool
+		errIs     error
 	}{
 		{
 			name: "успешное удаление",
 			id:   "1",
-			mockSetup: func(m *MockTaskRepository, id string) {
-				m.On("Delete", id).Return(nil)
+			mockSetup: func(m *MockTaskRepository, id string, version *int64) {
+				m.On("Delete", mock.Anything, id, version).Return(nil)
 			},
 			wantErr: false,
 		},
 		{
-			name: "ошибка удаления",
-			id:   "2",
-			mockSetup: func(m *MockTaskRepository, id string) {
-				m.On("Delete", id).Return(errors.New("delete error"))
+			name:    "ошибка удаления",
+			id:      "2",
+			version: &version,
+			mockSetup: func(m *MockTaskRepository, id string, version *int64) {
+				m.On("Delete", mock.Anything, id, version).Return(errors.New("delete error"))
 			},
 			wantErr: true,
 		},
+		{
+			name:    "конфликт версий",
+			id:      "3",
+			version: &version,
+			mockSetup: func(m *MockTaskRepository, id string, version *int64) {
+				m.On("Delete", mock.Anything, id, version).Return(ErrVersionConflict)
+			},
+			wantErr: true,
+			errIs:   ErrVersionConflict,
+		},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			mockRepo := new(MockTaskRepository)
-			tt.mockSetup(mockRepo, tt.id)
+			tt.mockSetup(mockRepo, tt.id, tt.version)
 
 			service := NewTaskService(mockRepo)
-			err := service.DeleteTask(tt.id)
+			err := service.DeleteTask(t.Context(), tt.id, tt.version)
 
 			if tt.wantErr {
 				assert.Error(t, err)
+				if tt.errIs != nil {
+					assert.ErrorIs(t, err, tt.errIs)
+				}
 			} else {
 				assert.NoError(t, err)
 			}
@@ -268,3 +343,23 @@ func TestDeleteTask(t *testing.T) {
 		})
 	}
 }
+
+func TestBusinessMetrics(t *testing.T) {
+	service := NewTaskService(NewMemoryTaskRepository())
+	created := testutil.ToFloat64(metrics.TasksCreated)
+	completed := testutil.ToFloat64(metrics.TasksCompleted)
+
+	task, err := service.CreateTask(t.Context(), "Buy milk", false, "user-1")
+	require.NoError(t, err)
+	assert.Equal(t, created+1, testutil.ToFloat64(metrics.TasksCreated))
+
+	// Выполненной задача становится один раз: повторная отметка и переименование не считаются
+	done := models.TaskChanges{IsDone: patch.Value(true)}
+	_, err = service.UpdateTask(t.Context(), task.ID, nil, done)
+	require.NoError(t, err)
+	_, err = service.UpdateTask(t.Context(), task.ID, nil, done)
+	require.NoError(t, err)
+	_, err = service.UpdateTask(t.Context(), task.ID, nil, models.TaskChanges{Name: patch.Value("Buy oat milk")})
+	require.NoError(t, err)
+	assert.Equal(t, completed+1, testutil.ToFloat64(metrics.TasksCompleted))
+}
@@ -0,0 +1,56 @@
+package taskService
+
+import (
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/tracing"
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedTaskService Выполняет каждый метод сервиса в отдельном спане
+type tracedTaskService struct {
+	next TaskService
+}
+
+// NewTracedTaskService Оборачивает сервис задач трассировкой
+func NewTracedTaskService(s TaskService) TaskService {
+	return &tracedTaskService{next: s}
+}
+
+func (s *tracedTaskService) GetAllTasks(ctx context.Context, page models.Page) (tasks []models.Task, err error) {
+	ctx, span := tracing.Start(ctx, "taskService.GetAllTasks",
+		attribute.Int("page.limit", page.Limit), attribute.Int("page.offset", page.Offset))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetAllTasks(ctx, page)
+}
+
+func (s *tracedTaskService) GetTaskByID(ctx context.Context, id string) (task models.Task, err error) {
+	ctx, span := tracing.Start(ctx, "taskService.GetTaskByID", attribute.String("task.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetTaskByID(ctx, id)
+}
+
+func (s *tracedTaskService) CreateTask(ctx context.Context, name string, isDone bool, userID string) (task models.Task, err error) {
+	ctx, span := tracing.Start(ctx, "taskService.CreateTask", attribute.String("user.id", userID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateTask(ctx, name, isDone, userID)
+}
+
+func (s *tracedTaskService) UpdateTask(ctx context.Context, id string, version *int64, changes models.TaskChanges) (task models.Task, err error) {
+	ctx, span := tracing.Start(ctx, "taskService.UpdateTask", attribute.String("task.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.UpdateTask(ctx, id, version, changes)
+}
+
+func (s *tracedTaskService) DeleteTask(ctx context.Context, id string, version *int64) (err error) {
+	ctx, span := tracing.Start(ctx, "taskService.DeleteTask", attribute.String("task.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.DeleteTask(ctx, id, version)
+}
+
+func (s *tracedTaskService) GetTasksByUserID(ctx context.Context, userID string) (tasks []models.Task, err error) {
+	ctx, span := tracing.Start(ctx, "taskService.GetTasksByUserID", attribute.String("user.id", userID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetTasksByUserID(ctx, userID)
+}
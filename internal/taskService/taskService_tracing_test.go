@@ -0,0 +1,33 @@
+package taskService
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracedTaskService(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	service := NewTracedTaskService(NewTaskService(NewMemoryTaskRepository()))
+	task, err := service.CreateTask(t.Context(), "Buy milk", false, "user-1")
+	require.NoError(t, err)
+	_, err = service.GetTaskByID(t.Context(), "missing")
+	require.ErrorIs(t, err, ErrTaskNotFound, "ошибка проходит через обертку как есть")
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "taskService.CreateTask", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[0].Status().Code)
+	assert.Equal(t, "taskService.GetTaskByID", spans[1].Name())
+	assert.Equal(t, codes.Error, spans[1].Status().Code)
+	assert.NotEmpty(t, task.ID)
+}
@@ -0,0 +1,71 @@
+package tlsconfig
+
+import (
+	"POSTnGETtrain/internal/logging"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"net/http"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// ErrInvalidIdentity Элемент списка сервисов не в формате "<CN сертификата>=<сервис>"
+var ErrInvalidIdentity = errors.New("tlsconfig: invalid client identity")
+
+// serviceKey Ключ имени сервиса-клиента в контексте
+type serviceKey struct{}
+
+// WithService Возвращает контекст с именем сервиса, который прислал запрос
+func WithService(ctx context.Context, service string) context.Context {
+	return context.WithValue(ctx, serviceKey{}, service)
+}
+
+// ServiceFromContext Имя сервиса-клиента, если запрос пришел с клиентским сертификатом
+func ServiceFromContext(ctx context.Context) (string, bool) {
+	service, ok := ctx.Value(serviceKey{}).(string)
+	return service, ok
+}
+
+// ParseIdentities Разбирает список вида ["billing.internal=billing", ...]:
+// Common Name клиентского сертификата и имя сервиса
+func ParseIdentities(list []string) (map[string]string, error) {
+	identities := make(map[string]string, len(list))
+	for _, item := range list {
+		commonName, service, ok := strings.Cut(item, "=")
+		commonName, service = strings.TrimSpace(commonName), strings.TrimSpace(service)
+		if !ok || commonName == "" || service == "" {
+			return nil, fmt.Errorf("%w %q: expected <certificate common name>=<service>", ErrInvalidIdentity, item)
+		}
+		identities[commonName] = service
+	}
+	return identities, nil
+}
+
+// Middleware Возвращает Echo middleware, которое сопоставляет проверенный клиентский сертификат
+// (по Common Name субъекта) имени сервиса и кладет его в контекст и в лог запроса.
+// Сертификат, выпущенный доверенным CA, но не сопоставленный сервису, получает 403.
+// Запросы без сертификата проходят как есть
+func Middleware(identities map[string]string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			state := c.Request().TLS
+			if state == nil || len(state.VerifiedChains) == 0 {
+				return next(c)
+			}
+
+			commonName := state.VerifiedChains[0][0].Subject.CommonName
+			service, ok := identities[commonName]
+			if !ok {
+				return echo.NewHTTPError(http.StatusForbidden, "client certificate is not mapped to a service")
+			}
+
+			ctx := WithService(c.Request().Context(), service)
+			logging.Annotate(ctx, slog.String("service", service))
+			c.SetRequest(c.Request().WithContext(ctx))
+			return next(c)
+		}
+	}
+}
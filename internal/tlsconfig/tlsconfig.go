@@ -0,0 +1,159 @@
+// Package tlsconfig настраивает TLS сервера: сертификат из файлов, который перечитывается
+// при их замене без перезапуска, и необязательная проверка клиентских сертификатов (mTLS)
+// с сопоставлением субъекта сертификата имени сервиса-клиента
+package tlsconfig
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"errors"
+	"fmt"
+	"log/slog"
+	"os"
+	"sync"
+	"time"
+)
+
+// DefaultReloadInterval Как часто проверять файлы сертификата, если интервал не задан
+const DefaultReloadInterval = 30 * time.Second
+
+// ErrIncompleteKeyPair Задан только сертификат или только ключ
+var ErrIncompleteKeyPair = errors.New("tlsconfig: both certificate and key files are required")
+
+// Config Настройки TLS
+type Config struct {
+	CertFile       string        // Сертификат сервера в PEM (может содержать цепочку)
+	KeyFile        string        // Закрытый ключ сервера в PEM
+	ClientCAFile   string        // CA клиентских сертификатов в PEM; пусто - mTLS выключен
+	ReloadInterval time.Duration // Как часто проверять, не заменены ли файлы сертификата
+}
+
+// Enabled Включен ли TLS
+func (c Config) Enabled() bool {
+	return c.CertFile != "" || c.KeyFile != ""
+}
+
+// New Собирает tls.Config сервера. Сертификат перечитывается, пока не отменен ctx.
+// Клиентский сертификат необязателен, но если он предъявлен, то должен быть выпущен ClientCAFile
+func New(ctx context.Context, cfg Config) (*tls.Config, error) {
+	if cfg.CertFile == "" || cfg.KeyFile == "" {
+		return nil, ErrIncompleteKeyPair
+	}
+	reloader, err := NewReloader(cfg.CertFile, cfg.KeyFile)
+	if err != nil {
+		return nil, err
+	}
+	tlsConfig := &tls.Config{
+		MinVersion:     tls.VersionTLS12,
+		GetCertificate: reloader.GetCertificate, // h2 в ALPN добавит http.Server
+	}
+	if cfg.ClientCAFile != "" {
+		pem, err := os.ReadFile(cfg.ClientCAFile)
+		if err != nil {
+			return nil, fmt.Errorf("tlsconfig: could not read client CA: %w", err)
+		}
+		pool := x509.NewCertPool()
+		if !pool.AppendCertsFromPEM(pem) {
+			return nil, fmt.Errorf("tlsconfig: no certificates in client CA file %s", cfg.ClientCAFile)
+		}
+		tlsConfig.ClientCAs = pool
+		tlsConfig.ClientAuth = tls.VerifyClientCertIfGiven // Браузеры и клиенты с токеном ходят без сертификата
+	}
+
+	interval := cfg.ReloadInterval
+	if interval <= 0 {
+		interval = DefaultReloadInterval
+	}
+	go reloader.Watch(ctx, interval)
+	return tlsConfig, nil
+}
+
+// fileStamp Время изменения и размер файла: по ним видно, что файл заменили
+type fileStamp struct {
+	modTime time.Time
+	size    int64
+}
+
+// Reloader Отдает сертификат сервера и перечитывает его, когда файлы заменены
+type Reloader struct {
+	certFile, keyFile string
+
+	mu          sync.RWMutex
+	certificate *tls.Certificate
+	stamps      [2]fileStamp
+}
+
+// NewReloader Загружает сертификат и ключ из файлов
+func NewReloader(certFile, keyFile string) (*Reloader, error) {
+	r := &Reloader{certFile: certFile, keyFile: keyFile}
+	if _, err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// GetCertificate Текущий сертификат для tls.Config.GetCertificate
+func (r *Reloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.certificate, nil
+}
+
+// Reload Перечитывает сертификат, если файлы изменились с прошлой загрузки.
+// При ошибке остается прежний сертификат: файлы могут быть заменены не одновременно
+func (r *Reloader) Reload() (bool, error) {
+	stamps, err := r.stat()
+	if err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	unchanged := r.certificate != nil && stamps == r.stamps
+	r.mu.RUnlock()
+	if unchanged {
+		return false, nil
+	}
+
+	certificate, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return false, fmt.Errorf("tlsconfig: could not load key pair: %w", err)
+	}
+	r.mu.Lock()
+	r.certificate, r.stamps = &certificate, stamps
+	r.mu.Unlock()
+	return true, nil
+}
+
+// Watch Проверяет файлы каждые interval, пока не отменен ctx
+func (r *Reloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.Reload()
+			if err != nil {
+				slog.Error("could not reload tls certificate", slog.String("error", err.Error()))
+				continue
+			}
+			if reloaded {
+				slog.Info("tls certificate reloaded", slog.String("file", r.certFile))
+			}
+		}
+	}
+}
+
+// stat Отметки файлов сертификата и ключа
+func (r *Reloader) stat() ([2]fileStamp, error) {
+	var stamps [2]fileStamp
+	for i, name := range []string{r.certFile, r.keyFile} {
+		info, err := os.Stat(name)
+		if err != nil {
+			return stamps, fmt.Errorf("tlsconfig: %w", err)
+		}
+		stamps[i] = fileStamp{modTime: info.ModTime(), size: info.Size()}
+	}
+	return stamps, nil
+}
@@ -0,0 +1,148 @@
+package tlsconfig
+
+import (
+	"POSTnGETtrain/internal/tlsconfig/tlstest"
+	"crypto/tls"
+	"io"
+	"net"
+	"net/http"
+	"os"
+	"testing"
+	"time"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestReloader(t *testing.T) {
+	dir := t.TempDir()
+	ca := tlstest.NewCA(t)
+	first := ca.Issue(t, "localhost")
+	certFile, keyFile := first.WriteFiles(t, dir)
+
+	reloader, err := NewReloader(certFile, keyFile)
+	require.NoError(t, err)
+	current := func() []byte {
+		certificate, err := reloader.GetCertificate(nil)
+		require.NoError(t, err)
+		return certificate.Certificate[0]
+	}
+	assert.Equal(t, first.Certificate.Raw, current())
+
+	reloaded, err := reloader.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded, "файлы не менялись")
+
+	// Новый сертификат подхватывается без пересоздания
+	second := ca.Issue(t, "localhost")
+	second.WriteFiles(t, dir)
+	touch(t, certFile, keyFile)
+	reloaded, err = reloader.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, second.Certificate.Raw, current())
+
+	// Сертификат заменили, а ключ еще нет: остается прежняя пара
+	third := ca.Issue(t, "localhost")
+	thirdCert, _ := third.WriteFiles(t, t.TempDir())
+	data, err := os.ReadFile(thirdCert)
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(certFile, data, 0o600))
+	touch(t, certFile)
+	_, err = reloader.Reload()
+	assert.Error(t, err)
+	assert.Equal(t, second.Certificate.Raw, current())
+}
+
+// touch Сдвигает время изменения файлов вперед: замена в пределах точности часов ФС тоже должна быть видна
+func touch(t *testing.T, names ...string) {
+	t.Helper()
+	for _, name := range names {
+		info, err := os.Stat(name)
+		require.NoError(t, err)
+		later := info.ModTime().Add(time.Minute)
+		require.NoError(t, os.Chtimes(name, later, later))
+	}
+}
+
+func TestNew(t *testing.T) {
+	_, err := New(t.Context(), Config{CertFile: "cert.pem"})
+	assert.ErrorIs(t, err, ErrIncompleteKeyPair)
+	assert.True(t, Config{KeyFile: "key.pem"}.Enabled())
+	assert.False(t, Config{}.Enabled())
+}
+
+func TestMutualTLS(t *testing.T) {
+	dir := t.TempDir()
+	ca := tlstest.NewCA(t)
+	certFile, keyFile := ca.Issue(t, "localhost").WriteFiles(t, dir)
+	serverTLS, err := New(t.Context(), Config{CertFile: certFile, KeyFile: keyFile, ClientCAFile: ca.WriteFile(t, dir)})
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(Middleware(map[string]string{"billing.internal": "billing"}))
+	e.GET("/whoami", func(c echo.Context) error {
+		service, ok := ServiceFromContext(c.Request().Context())
+		if !ok {
+			service = "anonymous"
+		}
+		return c.String(http.StatusOK, service)
+	})
+
+	listener, err := tls.Listen("tcp", "127.0.0.1:0", serverTLS)
+	require.NoError(t, err)
+	server := &http.Server{Handler: e, ReadHeaderTimeout: time.Second}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(func() { _ = server.Close() })
+
+	tests := []struct {
+		name       string
+		client     *tlstest.Cert
+		wantErr    bool
+		wantStatus int
+		wantBody   string
+	}{
+		{name: "без сертификата", wantStatus: http.StatusOK, wantBody: "anonymous"},
+		{name: "известный сервис", client: ca.Issue(t, "billing.internal"), wantStatus: http.StatusOK, wantBody: "billing"},
+		{name: "сертификат без сервиса", client: ca.Issue(t, "reports.internal"), wantStatus: http.StatusForbidden},
+		{name: "чужой CA", client: tlstest.NewCA(t).Issue(t, "billing.internal"), wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientTLS := &tls.Config{RootCAs: ca.Pool()}
+			if tt.client != nil {
+				clientTLS.Certificates = []tls.Certificate{tt.client.TLS()}
+			}
+			client := &http.Client{Transport: &http.Transport{TLSClientConfig: clientTLS}}
+			defer client.CloseIdleConnections()
+
+			resp, err := client.Get("https://" + listener.Addr().(*net.TCPAddr).String() + "/whoami")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			defer resp.Body.Close()
+			body, err := io.ReadAll(resp.Body)
+			require.NoError(t, err)
+
+			assert.Equal(t, tt.wantStatus, resp.StatusCode)
+			if tt.wantBody != "" {
+				assert.Equal(t, tt.wantBody, string(body))
+			}
+		})
+	}
+}
+
+func TestParseIdentities(t *testing.T) {
+	identities, err := ParseIdentities([]string{"billing.internal=billing", " reports.internal = reports "})
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"billing.internal": "billing", "reports.internal": "reports"}, identities)
+
+	for _, invalid := range []string{"billing", "=billing", "billing.internal="} {
+		_, err := ParseIdentities([]string{invalid})
+		assert.ErrorIs(t, err, ErrInvalidIdentity, invalid)
+	}
+}
@@ -0,0 +1,136 @@
+// Package tlstest выпускает самоподписанные сертификаты для тестов TLS и mTLS
+package tlstest
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+)
+
+// CA Тестовый удостоверяющий центр
+type CA struct {
+	Certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+}
+
+// Cert Выпущенный сертификат с ключом
+type Cert struct {
+	Certificate *x509.Certificate
+	key         *ecdsa.PrivateKey
+}
+
+// NewCA Создает самоподписанный CA
+func NewCA(t testing.TB) *CA {
+	t.Helper()
+	key := newKey(t)
+	template := &x509.Certificate{
+		SerialNumber:          serial(t),
+		Subject:               pkix.Name{CommonName: "test CA"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+	}
+	return &CA{Certificate: create(t, template, template, key, key), key: key}
+}
+
+// Issue Выпускает сертификат с Common Name commonName, годный и для сервера
+// (localhost и 127.0.0.1), и для клиента
+func (ca *CA) Issue(t testing.TB, commonName string) *Cert {
+	t.Helper()
+	key := newKey(t)
+	template := &x509.Certificate{
+		SerialNumber: serial(t),
+		Subject:      pkix.Name{CommonName: commonName},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		DNSNames:     []string{"localhost"},
+		IPAddresses:  []net.IP{net.IPv4(127, 0, 0, 1)},
+	}
+	return &Cert{Certificate: create(t, template, ca.Certificate, key, ca.key), key: key}
+}
+
+// Pool Пул доверенных сертификатов из одного CA
+func (ca *CA) Pool() *x509.CertPool {
+	pool := x509.NewCertPool()
+	pool.AddCert(ca.Certificate)
+	return pool
+}
+
+// WriteFile Записывает сертификат CA в PEM-файл в каталоге dir
+func (ca *CA) WriteFile(t testing.TB, dir string) string {
+	t.Helper()
+	name := filepath.Join(dir, "ca.pem")
+	write(t, name, "CERTIFICATE", ca.Certificate.Raw)
+	return name
+}
+
+// WriteFiles Записывает сертификат и ключ в cert.pem и key.pem в каталоге dir (заменяя прежние)
+func (c *Cert) WriteFiles(t testing.TB, dir string) (certFile, keyFile string) {
+	t.Helper()
+	key, err := x509.MarshalECPrivateKey(c.key)
+	if err != nil {
+		t.Fatalf("tlstest: %v", err)
+	}
+	certFile, keyFile = filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	write(t, certFile, "CERTIFICATE", c.Certificate.Raw)
+	write(t, keyFile, "EC PRIVATE KEY", key)
+	return certFile, keyFile
+}
+
+// TLS Сертификат для tls.Config.Certificates клиента
+func (c *Cert) TLS() tls.Certificate {
+	return tls.Certificate{Certificate: [][]byte{c.Certificate.Raw}, PrivateKey: c.key, Leaf: c.Certificate}
+}
+
+func newKey(t testing.TB) *ecdsa.PrivateKey {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("tlstest: %v", err)
+	}
+	return key
+}
+
+func serial(t testing.TB) *big.Int {
+	t.Helper()
+	n, err := rand.Int(rand.Reader, big.NewInt(1<<62))
+	if err != nil {
+		t.Fatalf("tlstest: %v", err)
+	}
+	return n
+}
+
+func create(t testing.TB, template, parent *x509.Certificate, key, parentKey *ecdsa.PrivateKey) *x509.Certificate {
+	t.Helper()
+	der, err := x509.CreateCertificate(rand.Reader, template, parent, &key.PublicKey, parentKey)
+	if err != nil {
+		t.Fatalf("tlstest: %v", err)
+	}
+	certificate, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("tlstest: %v", err)
+	}
+	return certificate
+}
+
+func write(t testing.TB, name, blockType string, der []byte) {
+	t.Helper()
+	data := pem.EncodeToMemory(&pem.Block{Type: blockType, Bytes: der})
+	if err := os.WriteFile(name, data, 0o600); err != nil {
+		t.Fatalf("tlstest: %v", err)
+	}
+}
@@ -0,0 +1,74 @@
+package tracing
+
+import (
+	"errors"
+
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+	"gorm.io/gorm"
+)
+
+// spanKey Ключ спана запроса в настройках экземпляра gorm.DB
+const spanKey = "tracing:span"
+
+// InstrumentDB Подключает к БД плагин, который создает спан на каждый SQL-запрос.
+// Родитель спана - контекст запроса (db.WithContext(ctx)). Значения параметров в спан не попадают
+func InstrumentDB(database *gorm.DB) error {
+	if err := database.Use(gormPlugin{}); err != nil && !errors.Is(err, gorm.ErrRegistered) {
+		return err
+	}
+	return nil
+}
+
+// gormPlugin Открывает спан колбэком до встроенного колбэка GORM и закрывает после
+type gormPlugin struct{}
+
+func (gormPlugin) Name() string { return "tracing" }
+
+func (gormPlugin) Initialize(db *gorm.DB) error {
+	callback := db.Callback()
+	return errors.Join(
+		callback.Create().Before("gorm:create").Register("tracing:before_create", beforeQuery("gorm.Create")),
+		callback.Create().After("gorm:create").Register("tracing:after_create", afterQuery),
+		callback.Query().Before("gorm:query").Register("tracing:before_query", beforeQuery("gorm.Query")),
+		callback.Query().After("gorm:query").Register("tracing:after_query", afterQuery),
+		callback.Update().Before("gorm:update").Register("tracing:before_update", beforeQuery("gorm.Update")),
+		callback.Update().After("gorm:update").Register("tracing:after_update", afterQuery),
+		callback.Delete().Before("gorm:delete").Register("tracing:before_delete", beforeQuery("gorm.Delete")),
+		callback.Delete().After("gorm:delete").Register("tracing:after_delete", afterQuery),
+		callback.Row().Before("gorm:row").Register("tracing:before_row", beforeQuery("gorm.Row")),
+		callback.Row().After("gorm:row").Register("tracing:after_row", afterQuery),
+		callback.Raw().Before("gorm:raw").Register("tracing:before_raw", beforeQuery("gorm.Raw")),
+		callback.Raw().After("gorm:raw").Register("tracing:after_raw", afterQuery),
+	)
+}
+
+func beforeQuery(name string) func(*gorm.DB) {
+	return func(db *gorm.DB) {
+		_, span := Start(db.Statement.Context, name)
+		db.InstanceSet(spanKey, span)
+	}
+}
+
+func afterQuery(db *gorm.DB) {
+	value, ok := db.InstanceGet(spanKey)
+	if !ok {
+		return
+	}
+	span, ok := value.(trace.Span)
+	if !ok {
+		return
+	}
+	// SQL с плейсхолдерами: значения (пароли, адреса почты) не экспортируются
+	span.SetAttributes(
+		attribute.String("db.system.name", db.Dialector.Name()),
+		attribute.String("db.collection.name", db.Statement.Table),
+		attribute.String("db.query.text", db.Statement.SQL.String()),
+		attribute.Int64("db.rows_affected", db.Statement.RowsAffected),
+	)
+	err := db.Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		err = nil // Отсутствие записи - ожидаемый результат, а не сбой
+	}
+	End(span, err)
+}
@@ -0,0 +1,40 @@
+package tracing
+
+import (
+	"strings"
+
+	"github.com/labstack/echo/v4"
+	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+	"go.opentelemetry.io/contrib/instrumentation/github.com/labstack/echo/otelecho"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Middleware Возвращает Echo middleware, которое продолжает трассу из заголовков traceparent/tracestate
+// (или начинает новую) и создает серверный спан запроса. Пути с префиксами skipPrefixes (например, /metrics) не трассируются
+func Middleware(serviceName string, skipPrefixes ...string) echo.MiddlewareFunc {
+	return otelecho.Middleware(serviceName, otelecho.WithSkipper(func(c echo.Context) bool {
+		for _, prefix := range skipPrefixes {
+			if strings.HasPrefix(c.Request().URL.Path, prefix) {
+				return true
+			}
+		}
+		return false
+	}))
+}
+
+// StrictMiddleware Оборачивает строгий обработчик в спан с именем операции OpenAPI (GetTasksId, PostUsers).
+// Подходит для NewStrictHandler пакетов tasks и users:
+//
+//	tasks.NewStrictHandler(handler, []tasks.StrictMiddlewareFunc{tracing.StrictMiddleware})
+func StrictMiddleware(next strictecho.StrictEchoHandlerFunc, operationID string) strictecho.StrictEchoHandlerFunc {
+	return func(c echo.Context, request any) (any, error) {
+		original := c.Request()
+		ctx, span := Start(original.Context(), operationID, attribute.String("openapi.operation_id", operationID))
+		c.SetRequest(original.WithContext(ctx))
+		defer c.SetRequest(original)
+
+		response, err := next(c, request)
+		End(span, err)
+		return response, err
+	}
+}
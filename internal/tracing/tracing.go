@@ -0,0 +1,100 @@
+// Package tracing настраивает трассировку OpenTelemetry: экспорт спанов (OTLP или stdout),
+// сэмплирование, W3C trace context, спаны строгих обработчиков и SQL-запросов GORM
+package tracing
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.34.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Куда отправлять спаны
+const (
+	ExporterNone   = "none"   // Трассировка выключена
+	ExporterStdout = "stdout" // JSON в консоль, для локальной отладки
+	ExporterOTLP   = "otlp"   // OTLP/HTTP в коллектор по адресу Endpoint
+)
+
+// instrumentationName Имя библиотеки инструментирования в спанах
+const instrumentationName = "POSTnGETtrain"
+
+// ErrUnknownExporter Неизвестное значение Config.Exporter
+var ErrUnknownExporter = errors.New("tracing: unknown exporter")
+
+// Config Настройки трассировки
+type Config struct {
+	Exporter    string  // none, stdout или otlp
+	Endpoint    string  // Полный адрес приема спанов OTLP/HTTP, например http://localhost:4318/v1/traces
+	SampleRatio float64 // Доля новых трасс, которые записываются (0..1). Решение вызывающего сервиса соблюдается
+	ServiceName string  // service.name в ресурсе
+	Version     string  // service.version в ресурсе
+}
+
+// Setup Устанавливает глобальные TracerProvider и пропагатор W3C (traceparent, baggage).
+// Возвращает функцию, которая отправляет накопленные спаны и останавливает экспорт
+func Setup(ctx context.Context, cfg Config, stdout io.Writer) (func(context.Context) error, error) {
+	otel.SetTextMapPropagator(propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{}))
+
+	var processor sdktrace.TracerProviderOption
+	switch cfg.Exporter {
+	case ExporterNone, "":
+		return func(context.Context) error { return nil }, nil
+	case ExporterStdout:
+		exporter, err := stdouttrace.New(stdouttrace.WithWriter(stdout))
+		if err != nil {
+			return nil, fmt.Errorf("tracing: could not create stdout exporter: %w", err)
+		}
+		processor = sdktrace.WithSyncer(exporter) // Для отладки спаны печатаются сразу, без пакетов
+	case ExporterOTLP:
+		exporter, err := otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(cfg.Endpoint))
+		if err != nil {
+			return nil, fmt.Errorf("tracing: could not create otlp exporter: %w", err)
+		}
+		processor = sdktrace.WithBatcher(exporter)
+	default:
+		return nil, fmt.Errorf("%w %q: expected %s, %s or %s", ErrUnknownExporter, cfg.Exporter, ExporterNone, ExporterStdout, ExporterOTLP)
+	}
+
+	res, err := resource.New(ctx,
+		resource.WithFromEnv(), // OTEL_RESOURCE_ATTRIBUTES
+		resource.WithTelemetrySDK(),
+		resource.WithAttributes(semconv.ServiceName(cfg.ServiceName), semconv.ServiceVersion(cfg.Version)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("tracing: could not build resource: %w", err)
+	}
+
+	provider := sdktrace.NewTracerProvider(
+		processor,
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(cfg.SampleRatio))),
+	)
+	otel.SetTracerProvider(provider)
+	return provider.Shutdown, nil
+}
+
+// Start Начинает спан внутри приложения от текущего глобального TracerProvider
+func Start(ctx context.Context, name string, attrs ...attribute.KeyValue) (context.Context, trace.Span) {
+	return otel.Tracer(instrumentationName).Start(ctx, name, trace.WithAttributes(attrs...))
+}
+
+// End Завершает спан, отмечая ошибку, если она есть
+func End(span trace.Span, err error) {
+	if err != nil {
+		span.RecordError(err)
+		span.SetStatus(codes.Error, err.Error())
+	}
+	span.End()
+}
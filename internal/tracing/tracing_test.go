@@ -0,0 +1,169 @@
+package tracing
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/glebarez/sqlite"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"gorm.io/gorm"
+	"gorm.io/gorm/logger"
+)
+
+// recordSpans Подменяет глобальный TracerProvider записью спанов в память до конца теста
+func recordSpans(t *testing.T) *tracetest.SpanRecorder {
+	t.Helper()
+	recorder := tracetest.NewSpanRecorder()
+	previous, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previous)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+	return recorder
+}
+
+// spansByName Завершенные спаны по именам
+func spansByName(recorder *tracetest.SpanRecorder) map[string]sdktrace.ReadOnlySpan {
+	spans := make(map[string]sdktrace.ReadOnlySpan)
+	for _, span := range recorder.Ended() {
+		spans[span.Name()] = span
+	}
+	return spans
+}
+
+func TestMiddleware(t *testing.T) {
+	recorder := recordSpans(t)
+
+	e := echo.New()
+	e.Use(Middleware("tasks-api", "/metrics"))
+	strict := StrictMiddleware(func(c echo.Context, _ any) (any, error) {
+		_, span := Start(c.Request().Context(), "taskService.GetTaskByID")
+		span.End()
+		if c.Param("id") == "broken" {
+			return nil, errors.New("connection refused")
+		}
+		return nil, nil
+	}, "GetTasksId")
+	e.GET("/tasks/:id", func(c echo.Context) error {
+		_, err := strict(c, nil)
+		return err
+	})
+	e.GET("/metrics", func(c echo.Context) error { return c.NoContent(http.StatusOK) })
+
+	const (
+		traceID      = "4bf92f3577b34da6a3ce929d0e0e4736"
+		parentSpanID = "00f067aa0ba902b7"
+	)
+	tests := []struct {
+		name       string
+		path       string
+		wantStatus codes.Code
+	}{
+		{name: "успешный запрос", path: "/tasks/1", wantStatus: codes.Unset},
+		{name: "ошибка обработчика", path: "/tasks/broken", wantStatus: codes.Error},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			recorder.Reset()
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			req.Header.Set("traceparent", "00-"+traceID+"-"+parentSpanID+"-01")
+			e.ServeHTTP(httptest.NewRecorder(), req)
+
+			spans := spansByName(recorder)
+			require.Len(t, spans, 3)
+			server, operation, service := spans["GET /tasks/:id"], spans["GetTasksId"], spans["taskService.GetTaskByID"]
+			require.NotNil(t, server)
+			require.NotNil(t, operation)
+			require.NotNil(t, service)
+
+			// Трасса продолжена из заголовка traceparent
+			assert.Equal(t, traceID, server.SpanContext().TraceID().String())
+			assert.Equal(t, parentSpanID, server.Parent().SpanID().String())
+			assert.Equal(t, server.SpanContext().SpanID(), operation.Parent().SpanID())
+			assert.Equal(t, operation.SpanContext().SpanID(), service.Parent().SpanID())
+			assert.Equal(t, tt.wantStatus, operation.Status().Code)
+		})
+	}
+
+	t.Run("метрики не трассируются", func(t *testing.T) {
+		recorder.Reset()
+		e.ServeHTTP(httptest.NewRecorder(), httptest.NewRequest(http.MethodGet, "/metrics", nil))
+		assert.Empty(t, recorder.Ended())
+	})
+}
+
+func TestInstrumentDB(t *testing.T) {
+	recorder := recordSpans(t)
+
+	database, err := gorm.Open(sqlite.Open("file::memory:"), &gorm.Config{Logger: logger.Discard})
+	require.NoError(t, err)
+	require.NoError(t, InstrumentDB(database))
+	require.NoError(t, InstrumentDB(database), "повторный вызов не ошибка")
+
+	type secret struct {
+		ID    int
+		Value string
+	}
+	require.NoError(t, database.AutoMigrate(&secret{}))
+
+	ctx, parent := Start(t.Context(), "request")
+	recorder.Reset()
+	require.NoError(t, database.WithContext(ctx).Create(&secret{Value: "hunter2"}).Error)
+	require.ErrorIs(t, database.WithContext(ctx).First(&secret{}, 100).Error, gorm.ErrRecordNotFound)
+	require.Error(t, database.WithContext(ctx).Table("missing").Find(&[]secret{}).Error)
+	parent.End()
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 4) // Три запроса и родитель
+	for _, span := range spans[:3] {
+		assert.Equal(t, parent.SpanContext().SpanID(), span.Parent().SpanID(), span.Name())
+		for _, attr := range span.Attributes() {
+			assert.NotContains(t, attr.Value.Emit(), "hunter2", "значения параметров не экспортируются")
+		}
+	}
+	assert.Equal(t, "gorm.Create", spans[0].Name())
+	assert.Equal(t, codes.Unset, spans[1].Status().Code, "отсутствие записи не ошибка")
+	assert.Equal(t, codes.Error, spans[2].Status().Code)
+}
+
+func TestSetup(t *testing.T) {
+	recordSpans(t) // Восстановит глобальный TracerProvider после теста
+
+	_, err := Setup(t.Context(), Config{Exporter: "jaeger"}, nil)
+	assert.ErrorIs(t, err, ErrUnknownExporter)
+
+	shutdown, err := Setup(t.Context(), Config{Exporter: ExporterNone}, nil)
+	require.NoError(t, err)
+	assert.NoError(t, shutdown(t.Context()))
+
+	var out bytes.Buffer
+	shutdown, err = Setup(t.Context(), Config{Exporter: ExporterStdout, SampleRatio: 1, ServiceName: "tasks-api"}, &out)
+	require.NoError(t, err)
+	_, span := Start(t.Context(), "GetTasks")
+	span.End()
+	require.NoError(t, shutdown(t.Context()))
+	assert.Contains(t, out.String(), `"Name":"GetTasks"`)
+	assert.Contains(t, out.String(), "tasks-api")
+
+	// Доля 0: новые трассы не записываются
+	out.Reset()
+	shutdown, err = Setup(t.Context(), Config{Exporter: ExporterStdout, SampleRatio: 0}, &out)
+	require.NoError(t, err)
+	_, span = Start(t.Context(), "GetTasks")
+	span.End()
+	require.NoError(t, shutdown(t.Context()))
+	assert.Empty(t, out.String())
+}
@@ -2,6 +2,7 @@ package userService
 
 import (
 	"POSTnGETtrain/internal/models"
+	"context"
 	"errors"
 	"fmt"
 
@@ -10,13 +11,14 @@ import (
 
 // UserRepository Содержит все необходимые методы для CRUD операций
 type UserRepository interface {
-	GetAll() ([]models.User, error)
-	GetByID(id string) (*models.User, error)
-	Create(user *models.User) (*models.User, error)
-	Update(user *models.User) (*models.User, error)
-	Delete(id string) error
-	EmailExists(email string) (bool, error)
-	GetTasksForUser(userID string) ([]models.Task, error)
+	GetAll(ctx context.Context, page models.Page) ([]models.User, error)
+	GetByID(ctx context.Context, id string) (*models.User, error)
+	GetByEmail(ctx context.Context, email string) (*models.User, error)
+	Create(ctx context.Context, user *models.User) (*models.User, error)
+	Update(ctx context.Context, user *models.User) (*models.User, error)
+	Delete(ctx context.Context, id string, version *int64) error
+	EmailExists(ctx context.Context, email string) (bool, error)
+	GetTasksForUser(ctx context.Context, userID string) ([]models.Task, error)
 }
 
 // userRepository - реализация UserRepository с использованием GORM
@@ -30,16 +32,16 @@ func NewUserRepository(db *gorm.DB) UserRepository {
 }
 
 // EmailExists проверяет, существует ли пользователь с указанным email
-func (r *userRepository) EmailExists(email string) (bool, error) {
+func (r *userRepository) EmailExists(ctx context.Context, email string) (bool, error) {
 	var count int64
-	err := r.db.Model(&models.User{}).Where("email = ?", email).Count(&count).Error
+	err := r.db.WithContext(ctx).Model(&models.User{}).Where("email = ?", email).Count(&count).Error
 	return count > 0, err
 }
 
 // Create создает нового пользователя в базе данных с уникальным email
-func (r *userRepository) Create(user *models.User) (*models.User, error) {
+func (r *userRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
 	// Проверяем не занят ли email
-	exists, err := r.EmailExists(user.Email)
+	exists, err := r.EmailExists(ctx, user.Email)
 	if err != nil {
 		return nil, fmt.Errorf("email check failed: %w", err)
 	}
@@ -48,45 +50,98 @@ func (r *userRepository) Create(user *models.User) (*models.User, error) {
 	}
 
 	// Создаем запись в базе данных
-	err = r.db.Create(user).Error
+	err = r.db.WithContext(ctx).Create(user).Error
 	return user, err
 }
 
 // GetByID находит пользователя по ID
-func (r *userRepository) GetByID(id string) (*models.User, error) {
+func (r *userRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
 	var user models.User
-	err := r.db.Where("id = ?", id).First(&user).Error
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&user).Error
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		return nil, ErrUserNotFound
 	}
 	return &user, err
 }
 
-// GetAll возвращает список всех пользователей в системе
-func (r *userRepository) GetAll() ([]models.User, error) {
+// GetByEmail находит пользователя по email
+// (Find вместо First: отсутствие пользователя здесь ожидаемо и не должно попадать в лог как ошибка)
+func (r *userRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
 	var users []models.User
-	err := r.db.Find(&users).Error
+	if err := r.db.WithContext(ctx).Where("email = ?", email).Limit(1).Find(&users).Error; err != nil {
+		return nil, fmt.Errorf("repo: could not find user by email: %w", err)
+	}
+	if len(users) == 0 {
+		return nil, ErrUserNotFound
+	}
+	return &users[0], nil
+}
+
+// GetAll возвращает страницу пользователей, упорядоченных по id
+func (r *userRepository) GetAll(ctx context.Context, page models.Page) ([]models.User, error) {
+	var users []models.User
+	query := r.db.WithContext(ctx).Order("id")
+	if page.Limit > 0 {
+		query = query.Limit(page.Limit)
+	}
+	if page.Offset > 0 {
+		query = query.Offset(page.Offset)
+	}
+	err := query.Find(&users).Error
 	return users, err
 }
 
-// Update обновляет данные пользователя в базе данных
-func (r *userRepository) Update(user *models.User) (*models.User, error) {
-	err := r.db.Save(user).Error
-	return user, err
+// Update обновляет данные пользователя в базе данных, если его версия
+// в БД совпадает с user.Version, иначе возвращает ErrVersionConflict
+func (r *userRepository) Update(ctx context.Context, user *models.User) (*models.User, error) {
+	result := r.db.WithContext(ctx).Model(&models.User{}).
+		Where("id = ? AND version = ?", user.ID, user.Version).
+		Updates(map[string]interface{}{
+			"email":             user.Email,
+			"password":          user.Password,
+			"is_admin":          user.IsAdmin,
+			"email_verified_at": user.EmailVerifiedAt,
+			"version":           gorm.Expr("version + 1"), // Каждое изменение увеличивает версию
+		})
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	if result.RowsAffected == 0 {
+		return nil, ErrVersionConflict
+	}
+
+	user.Version++
+	return user, nil
 }
 
-// Delete удаляет пользователя по его идентификатору
-func (r *userRepository) Delete(id string) error {
-	result := r.db.Delete(&models.User{}, "id = ?", id)
+// Delete удаляет пользователя по его идентификатору.
+// Если передана версия, пользователь удаляется только при её совпадении
+func (r *userRepository) Delete(ctx context.Context, id string, version *int64) error {
+	query := r.db.WithContext(ctx).Where("id = ?", id)
+	if version != nil {
+		query = query.Where("version = ?", *version)
+	}
+
+	result := query.Delete(&models.User{})
+	if result.Error != nil {
+		return result.Error
+	}
 	if result.RowsAffected == 0 {
-		return ErrUserNotFound
+		if version == nil {
+			return ErrUserNotFound
+		}
+		// Отличаем отсутствующего пользователя от устаревшей версии
+		if _, err := r.GetByID(ctx, id); err != nil {
+			return err
+		}
+		return ErrVersionConflict
 	}
-	return result.Error
+	return nil
 }
 
-func (r *userRepository) GetTasksForUser(userID string) ([]models.Task, error) {
+func (r *userRepository) GetTasksForUser(ctx context.Context, userID string) ([]models.Task, error) {
 	var tasks []models.Task
-	err := r.db.Where("user_id = ? AND deleted_at IS NULL", userID).Find(&tasks).Error
+	err := r.db.WithContext(ctx).Where("user_id = ? AND deleted_at IS NULL", userID).Find(&tasks).Error
 	if err != nil {
 		return nil, fmt.Errorf("repo: could not get tasks for user %s: %w", userID, err)
 	}
@@ -0,0 +1,146 @@
+package userService
+
+import (
+	"POSTnGETtrain/internal/models"
+	"context"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"gorm.io/gorm"
+)
+
+// memoryUserRepository Реализация UserRepository в памяти процесса.
+// Повторяет поведение репозитория на GORM: мягкое удаление, уникальный email, проверка версии.
+// Задачи пользователя берутся из репозитория задач, переданного в конструктор
+type memoryUserRepository struct {
+	mu    sync.RWMutex
+	users map[string]models.User // Все пользователи, включая мягко удаленных
+	tasks TaskLister
+}
+
+// TaskLister Источник задач пользователя для репозитория в памяти (TaskRepository подходит)
+type TaskLister interface {
+	GetByUserID(ctx context.Context, userID string) ([]models.Task, error)
+}
+
+// NewMemoryUserRepository Конструктор репозитория в памяти (для локального запуска и тестов)
+func NewMemoryUserRepository(tasks TaskLister) UserRepository {
+	return &memoryUserRepository{users: make(map[string]models.User), tasks: tasks}
+}
+
+// emailTaken Занят ли email неудаленным пользователем (вызывается под блокировкой)
+func (r *memoryUserRepository) emailTaken(email string) bool {
+	for _, user := range r.users {
+		if !user.DeletedAt.Valid && user.Email == email {
+			return true
+		}
+	}
+	return false
+}
+
+func (r *memoryUserRepository) EmailExists(ctx context.Context, email string) (bool, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.emailTaken(email), nil
+}
+
+func (r *memoryUserRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.emailTaken(user.Email) {
+		return nil, ErrEmailExists
+	}
+
+	now := time.Now()
+	user.CreatedAt, user.UpdatedAt = now, now
+	r.users[user.ID] = *user
+	return user, nil
+}
+
+func (r *memoryUserRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return nil, ErrUserNotFound
+	}
+	return &user, nil
+}
+
+func (r *memoryUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	for _, user := range r.users {
+		if !user.DeletedAt.Valid && user.Email == email {
+			return &user, nil
+		}
+	}
+	return nil, ErrUserNotFound
+}
+
+func (r *memoryUserRepository) GetAll(ctx context.Context, page models.Page) ([]models.User, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	users := make([]models.User, 0, len(r.users))
+	for _, user := range r.users {
+		if !user.DeletedAt.Valid {
+			users = append(users, user)
+		}
+	}
+	slices.SortFunc(users, func(a, b models.User) int { return strings.Compare(a.ID, b.ID) })
+
+	start := min(page.Offset, len(users))
+	end := len(users)
+	if page.Limit > 0 {
+		end = min(start+page.Limit, end)
+	}
+	return users[start:end], nil
+}
+
+func (r *memoryUserRepository) Update(ctx context.Context, user *models.User) (*models.User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	stored, ok := r.users[user.ID]
+	if !ok || stored.DeletedAt.Valid || stored.Version != user.Version {
+		return nil, ErrVersionConflict // Как и в GORM: обновлено 0 строк
+	}
+
+	// Email уникален и среди других пользователей (в БД это UNIQUE-ограничение)
+	if user.Email != stored.Email && r.emailTaken(user.Email) {
+		return nil, ErrEmailExists
+	}
+
+	updated := stored
+	updated.Email = user.Email
+	updated.Password = user.Password
+	updated.IsAdmin = user.IsAdmin
+	updated.EmailVerifiedAt = user.EmailVerifiedAt
+	updated.UpdatedAt = time.Now()
+	updated.Version++
+	r.users[user.ID] = updated
+
+	user.Version = updated.Version
+	return user, nil
+}
+
+func (r *memoryUserRepository) Delete(ctx context.Context, id string, version *int64) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok || user.DeletedAt.Valid {
+		return ErrUserNotFound
+	}
+	if version != nil && *version != user.Version {
+		return ErrVersionConflict
+	}
+
+	user.DeletedAt = gorm.DeletedAt{Time: time.Now(), Valid: true}
+	r.users[id] = user
+	return nil
+}
+
+func (r *memoryUserRepository) GetTasksForUser(ctx context.Context, userID string) ([]models.Task, error) {
+	return r.tasks.GetByUserID(ctx, userID)
+}
@@ -2,6 +2,7 @@ package userService
 
 import (
 	"POSTnGETtrain/internal/models"
+	"context"
 
 	"github.com/stretchr/testify/mock"
 )
@@ -10,15 +11,15 @@ type MockUserRepository struct {
 	mock.Mock
 }
 
-func (m *MockUserRepository) GetAll() ([]models.User, error) {
-	args := m.Called()
+func (m *MockUserRepository) GetAll(ctx context.Context, page models.Page) ([]models.User, error) {
+	args := m.Called(ctx, page)
 	if res := args.Get(0); res != nil {
 		return res.([]models.User), args.Error(1)
 	}
 	return []models.User{}, args.Error(1)
 }
-func (m *MockUserRepository) GetByID(id string) (*models.User, error) {
-	args := m.Called(id)
+func (m *MockUserRepository) GetByID(ctx context.Context, id string) (*models.User, error) {
+	args := m.Called(ctx, id)
 	var user *models.User
 	if res := args.Get(0); res != nil {
 		user = res.(*models.User)
@@ -26,8 +27,17 @@ func (m *MockUserRepository) GetByID(id string) (*models.User, error) {
 	return user, args.Error(1)
 }
 
-func (m *MockUserRepository) Create(user *models.User) (*models.User, error) {
-	args := m.Called(user)
+func (m *MockUserRepository) GetByEmail(ctx context.Context, email string) (*models.User, error) {
+	args := m.Called(ctx, email)
+	var user *models.User
+	if res := args.Get(0); res != nil {
+		user = res.(*models.User)
+	}
+	return user, args.Error(1)
+}
+
+func (m *MockUserRepository) Create(ctx context.Context, user *models.User) (*models.User, error) {
+	args := m.Called(ctx, user)
 	var u *models.User
 	if res := args.Get(0); res != nil {
 		u = res.(*models.User)
@@ -35,8 +45,8 @@ func (m *MockUserRepository) Create(user *models.User) (*models.User, error) {
 	return u, args.Error(1)
 }
 
-func (m *MockUserRepository) Update(user *models.User) (*models.User, error) {
-	args := m.Called(user)
+func (m *MockUserRepository) Update(ctx context.Context, user *models.User) (*models.User, error) {
+	args := m.Called(ctx, user)
 	var u *models.User
 	if res := args.Get(0); res != nil {
 		u = res.(*models.User)
@@ -44,26 +54,26 @@ func (m *MockUserRepository) Update(user *models.User) (*models.User, error) {
 	return u, args.Error(1)
 }
 
-func (m *MockUserRepository) Delete(id string) error {
-	args := m.Called(id)
+func (m *MockUserRepository) Delete(ctx context.Context, id string, version *int64) error {
+	args := m.Called(ctx, id, version)
 	return args.Error(0)
 }
 
-func (m *MockUserRepository) EmailExists(email string) (bool, error) {
-	args := m.Called(email)
+func (m *MockUserRepository) EmailExists(ctx context.Context, email string) (bool, error) {
+	args := m.Called(ctx, email)
 	return args.Bool(0), args.Error(1)
 }
 
-func (m *MockUserRepository) GetTasksForUser(userID string) ([]models.Task, error) {
-	args := m.Called(userID)
+func (m *MockUserRepository) GetTasksForUser(ctx context.Context, userID string) ([]models.Task, error) {
+	args := m.Called(ctx, userID)
 	if res := args.Get(0); res != nil {
 		return res.([]models.Task), args.Error(1)
 	}
 	return []models.Task{}, args.Error(1)
 }
 
-func (m *MockUserRepository) GetUserWithTasks(userID string) (*models.User, error) {
-	args := m.Called(userID)
+func (m *MockUserRepository) GetUserWithTasks(ctx context.Context, userID string) (*models.User, error) {
+	args := m.Called(ctx, userID)
 	var user *models.User
 	if res := args.Get(0); res != nil {
 		user = res.(*models.User)
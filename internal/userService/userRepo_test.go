@@ -0,0 +1,179 @@
+package userService
+
+import (
+	"POSTnGETtrain/internal/db/dbtest"
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/taskService"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// repoWithTasks Репозиторий пользователей и репозиторий задач поверх того же хранилища
+type repoWithTasks struct {
+	users UserRepository
+	tasks taskService.TaskRepository
+}
+
+// TestUserRepository Один и тот же набор проверок для всех реализаций UserRepository
+func TestUserRepository(t *testing.T) {
+	implementations := map[string]func(t *testing.T) repoWithTasks{
+		"memory": func(*testing.T) repoWithTasks {
+			tasks := taskService.NewMemoryTaskRepository()
+			return repoWithTasks{users: NewMemoryUserRepository(tasks), tasks: tasks}
+		},
+		"gorm": func(t *testing.T) repoWithTasks {
+			db := dbtest.Open(t)
+			return repoWithTasks{users: NewUserRepository(db), tasks: taskService.NewTaskRepository(db)}
+		},
+	}
+
+	for name, newRepo := range implementations {
+		t.Run(name, func(t *testing.T) {
+			t.Run("создание и получение", func(t *testing.T) {
+				repo := newRepo(t).users
+				_, err := repo.Create(t.Context(), &models.User{ID: "1", Email: "alice@example.com", Password: "secret", Version: 1})
+				require.NoError(t, err)
+
+				got, err := repo.GetByID(t.Context(), "1")
+				require.NoError(t, err)
+				assert.Equal(t, "alice@example.com", got.Email)
+				assert.Equal(t, int64(1), got.Version)
+
+				_, err = repo.GetByID(t.Context(), "missing")
+				assert.ErrorIs(t, err, ErrUserNotFound)
+			})
+
+			t.Run("email уникален", func(t *testing.T) {
+				repo := newRepo(t).users
+				_, err := repo.Create(t.Context(), &models.User{ID: "1", Email: "alice@example.com", Password: "secret", Version: 1})
+				require.NoError(t, err)
+
+				exists, err := repo.EmailExists(t.Context(), "alice@example.com")
+				require.NoError(t, err)
+				assert.True(t, exists)
+
+				_, err = repo.Create(t.Context(), &models.User{ID: "2", Email: "alice@example.com", Password: "secret", Version: 1})
+				assert.ErrorIs(t, err, ErrEmailExists)
+			})
+
+			t.Run("поиск по email и роль администратора", func(t *testing.T) {
+				repo := newRepo(t).users
+				_, err := repo.Create(t.Context(), &models.User{ID: "1", Email: "alice@example.com", Password: "secret", Version: 1})
+				require.NoError(t, err)
+
+				got, err := repo.GetByEmail(t.Context(), "alice@example.com")
+				require.NoError(t, err)
+				assert.Equal(t, "1", got.ID)
+				assert.False(t, got.IsAdmin)
+
+				got.IsAdmin = true
+				_, err = repo.Update(t.Context(), got)
+				require.NoError(t, err)
+				got, err = repo.GetByID(t.Context(), "1")
+				require.NoError(t, err)
+				assert.True(t, got.IsAdmin)
+
+				require.NoError(t, repo.Delete(t.Context(), "1", nil))
+				_, err = repo.GetByEmail(t.Context(), "alice@example.com")
+				assert.ErrorIs(t, err, ErrUserNotFound)
+			})
+
+			t.Run("подтверждение email", func(t *testing.T) {
+				repo := newRepo(t).users
+				_, err := repo.Create(t.Context(), &models.User{ID: "1", Email: "alice@example.com", Password: "secret", Version: 1})
+				require.NoError(t, err)
+				got, err := repo.GetByID(t.Context(), "1")
+				require.NoError(t, err)
+				assert.Nil(t, got.EmailVerifiedAt)
+
+				verifiedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+				got.EmailVerifiedAt = &verifiedAt
+				_, err = repo.Update(t.Context(), got)
+				require.NoError(t, err)
+				got, err = repo.GetByID(t.Context(), "1")
+				require.NoError(t, err)
+				require.NotNil(t, got.EmailVerifiedAt)
+				assert.True(t, verifiedAt.Equal(*got.EmailVerifiedAt))
+			})
+
+			t.Run("страницы упорядочены по id", func(t *testing.T) {
+				repo := newRepo(t).users
+				for _, id := range []string{"3", "1", "2"} {
+					_, err := repo.Create(t.Context(), &models.User{ID: id, Email: id + "@example.com", Password: "secret", Version: 1})
+					require.NoError(t, err)
+				}
+
+				page, err := repo.GetAll(t.Context(), models.Page{Limit: 2})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"1", "2"}, userIDs(page))
+
+				rest, err := repo.GetAll(t.Context(), models.Page{Limit: 2, Offset: 2})
+				require.NoError(t, err)
+				assert.Equal(t, []string{"3"}, userIDs(rest))
+			})
+
+			t.Run("обновление проверяет версию", func(t *testing.T) {
+				repo := newRepo(t).users
+				_, err := repo.Create(t.Context(), &models.User{ID: "1", Email: "alice@example.com", Password: "secret", Version: 1})
+				require.NoError(t, err)
+
+				updated, err := repo.Update(t.Context(), &models.User{ID: "1", Email: "alice@example.org", Password: "secret", Version: 1})
+				require.NoError(t, err)
+				assert.Equal(t, int64(2), updated.Version)
+
+				_, err = repo.Update(t.Context(), &models.User{ID: "1", Email: "alice@example.net", Password: "secret", Version: 1})
+				assert.ErrorIs(t, err, ErrVersionConflict)
+
+				got, err := repo.GetByID(t.Context(), "1")
+				require.NoError(t, err)
+				assert.Equal(t, "alice@example.org", got.Email)
+			})
+
+			t.Run("мягкое удаление", func(t *testing.T) {
+				repo := newRepo(t).users
+				_, err := repo.Create(t.Context(), &models.User{ID: "1", Email: "alice@example.com", Password: "secret", Version: 1})
+				require.NoError(t, err)
+
+				stale := int64(5)
+				assert.ErrorIs(t, repo.Delete(t.Context(), "1", &stale), ErrVersionConflict)
+				assert.ErrorIs(t, repo.Delete(t.Context(), "missing", nil), ErrUserNotFound)
+
+				require.NoError(t, repo.Delete(t.Context(), "1", nil))
+				assert.ErrorIs(t, repo.Delete(t.Context(), "1", nil), ErrUserNotFound)
+
+				_, err = repo.GetByID(t.Context(), "1")
+				assert.ErrorIs(t, err, ErrUserNotFound)
+				all, err := repo.GetAll(t.Context(), models.Page{})
+				require.NoError(t, err)
+				assert.Empty(t, all)
+			})
+
+			t.Run("задачи пользователя", func(t *testing.T) {
+				repo := newRepo(t)
+				_, err := repo.users.Create(t.Context(), &models.User{ID: "1", Email: "alice@example.com", Password: "secret", Version: 1})
+				require.NoError(t, err)
+				_, err = repo.tasks.Create(t.Context(), models.Task{ID: "t1", Name: "Buy milk", UserID: "1", Version: 1})
+				require.NoError(t, err)
+				_, err = repo.tasks.Create(t.Context(), models.Task{ID: "t2", Name: "Buy bread", UserID: "1", Version: 1})
+				require.NoError(t, err)
+				require.NoError(t, repo.tasks.Delete(t.Context(), "t2", nil))
+
+				tasks, err := repo.users.GetTasksForUser(t.Context(), "1")
+				require.NoError(t, err)
+				require.Len(t, tasks, 1)
+				assert.Equal(t, "t1", tasks[0].ID)
+			})
+		})
+	}
+}
+
+func userIDs(users []models.User) []string {
+	ids := make([]string, 0, len(users))
+	for _, user := range users {
+		ids = append(ids, user.ID)
+	}
+	return ids
+}
@@ -1,8 +1,16 @@
 package userService
 
 import (
+	"POSTnGETtrain/internal/logging"
+	"POSTnGETtrain/internal/metrics"
 	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/password"
+	"context"
 	"errors"
+	"fmt"
+	"log/slog"
+	"net/mail"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -11,81 +19,247 @@ import (
 var (
 	ErrEmailExists  = errors.New("email already exists")
 	ErrUserNotFound = errors.New("user not found")
+
+	ErrVersionConflict = errors.New("user version conflict") // Версия пользователя не совпала с ожидаемой
+	ErrInvalidUser     = errors.New("invalid user")          // Изменения нарушают обязательные поля пользователя
+	ErrEmailChanged    = errors.New("user email changed")    // Подтверждается не текущий email пользователя
 )
 
 // UserService Интерфейс сервиса для работы с пользователями
 type UserService interface {
-	GetAllUsers() ([]models.User, error)
-	CreateUser(email, password string) (*models.User, error)
-	UpdateUser(id string, email, password *string) (*models.User, error)
-	DeleteUser(id string) error
-	GetUserByID(id string) (*models.User, error)
-	GetTasksForUser(userID string) ([]models.Task, error)
+	GetAllUsers(ctx context.Context, page models.Page) ([]models.User, error)
+	CreateUser(ctx context.Context, email, password string) (*models.User, error)
+	UpdateUser(ctx context.Context, id string, version *int64, changes models.UserChanges) (*models.User, error)
+	DeleteUser(ctx context.Context, id string, version *int64) error
+	GetUserByID(ctx context.Context, id string) (*models.User, error)
+	GetUserByEmail(ctx context.Context, email string) (*models.User, error)
+	GetTasksForUser(ctx context.Context, userID string) ([]models.Task, error)
+
+	// Административные операции (server user ...)
+	CreateAdmin(ctx context.Context, email, password string) (*models.User, error)
+	PromoteUser(ctx context.Context, id string) (*models.User, error)
+	ResetPassword(ctx context.Context, id, password string) (*models.User, error)
+
+	// MarkEmailVerified Отмечает email пользователя подтвержденным
+	MarkEmailVerified(ctx context.Context, id, email string) (*models.User, error)
+	// ValidatePassword Проверяет пароль пользователя с адресом email по политике паролей
+	ValidatePassword(ctx context.Context, email, password string) error
 }
 
 // Реализация UserService
 type userService struct {
-	repo UserRepository // Репозиторий для работы с базой данных
+	repo      UserRepository  // Репозиторий для работы с базой данных
+	passwords password.Policy // Политика паролей
+}
+
+// Option Необязательная настройка сервиса
+type Option func(*userService)
+
+// WithPasswordPolicy Политика паролей. Без неё принимается любой непустой пароль
+func WithPasswordPolicy(policy password.Policy) Option {
+	return func(s *userService) { s.passwords = policy }
 }
 
 // NewUserService Конструктор сервиса
-func NewUserService(repo UserRepository) UserService {
-	return &userService{repo: repo}
+func NewUserService(repo UserRepository, opts ...Option) UserService {
+	s := &userService{repo: repo}
+	for _, opt := range opts {
+		opt(s)
+	}
+	return s
 }
 
-// GetAllUsers Получение всех пользователей
-func (s *userService) GetAllUsers() ([]models.User, error) {
-	return s.repo.GetAll() // Просто делегируем запрос в репозиторий
+// GetAllUsers Получение страницы пользователей
+func (s *userService) GetAllUsers(ctx context.Context, page models.Page) ([]models.User, error) {
+	return s.repo.GetAll(ctx, page) // Просто делегируем запрос в репозиторий
 }
 
 // CreateUser Создание пользователя
-func (s *userService) CreateUser(email, password string) (*models.User, error) {
+func (s *userService) CreateUser(ctx context.Context, email, password string) (*models.User, error) {
+	return s.create(ctx, email, password, false)
+}
+
+// CreateAdmin Создание пользователя с правами администратора.
+// Email администратора, созданного из командной строки, считается подтвержденным
+func (s *userService) CreateAdmin(ctx context.Context, email, password string) (*models.User, error) {
+	return s.create(ctx, email, password, true)
+}
+
+// create Создание пользователя с указанной ролью
+func (s *userService) create(ctx context.Context, email, secret string, admin bool) (*models.User, error) {
+	if email == "" {
+		return nil, fmt.Errorf("%w: email must not be empty", ErrInvalidUser)
+	}
+	if !validEmail(email) {
+		return nil, fmt.Errorf("%w: %q is not a valid email address", ErrInvalidUser, email)
+	}
+	if err := s.ValidatePassword(ctx, email, secret); err != nil {
+		return nil, err
+	}
 	user := &models.User{
 		ID:       uuid.New().String(), // Генерируем уникальный ID
 		Email:    email,               // Устанавливаем email
-		Password: password,            // Устанавливаем пароль
+		Password: secret,              // Устанавливаем пароль
+		Version:  1,                   // Первая версия пользователя
+		IsAdmin:  admin,
 	}
-	return s.repo.Create(user) // Передаем создание в репозиторий
+	if admin {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+	created, err := s.repo.Create(ctx, user) // Передаем создание в репозиторий
+	if err != nil {
+		return nil, err
+	}
+	metrics.UsersRegistered.Inc()
+	logging.FromContext(ctx).InfoContext(ctx, "user created", slog.Any("user", created))
+	return created, nil
 }
 
-// UpdateUser Обновление пользователя
-func (s *userService) UpdateUser(id string, email, password *string) (*models.User, error) {
+// UpdateUser Обновление пользователя набором изменений.
+// Если передана версия, пользователь обновляется только при её совпадении с текущей
+func (s *userService) UpdateUser(ctx context.Context, id string, version *int64, changes models.UserChanges) (*models.User, error) {
 	// Сначала получаем пользователя по ID
-	user, err := s.repo.GetByID(id)
+	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
 
-	// Обновляем поля, если они переданы
-	if email != nil {
-		user.Email = *email
+	// Клиент редактировал устаревшую версию пользователя
+	if version != nil && *version != user.Version {
+		logging.FromContext(ctx).DebugContext(ctx, "user version conflict",
+			slog.String("user_id", id), slog.Int64("expected", *version), slog.Int64("actual", user.Version))
+		return nil, ErrVersionConflict
 	}
-	if password != nil {
-		user.Password = *password
+
+	// Обновляем поля, если они переданы. Email и пароль обязательны - очистить их нельзя
+	if changes.Email.Set {
+		if changes.Email.Null || changes.Email.Value == "" {
+			return nil, fmt.Errorf("%w: email must not be empty", ErrInvalidUser)
+		}
+		if !validEmail(changes.Email.Value) {
+			return nil, fmt.Errorf("%w: %q is not a valid email address", ErrInvalidUser, changes.Email.Value)
+		}
+		// Новый адрес нужно подтвердить заново
+		if changes.Email.Value != user.Email {
+			user.EmailVerifiedAt = nil
+		}
+		user.Email = changes.Email.Value
+	}
+	// Пароль проверяется с учетом нового email
+	if changes.Password.Set {
+		if changes.Password.Null {
+			return nil, fmt.Errorf("%w: password must not be empty", ErrInvalidUser)
+		}
+		if err := s.ValidatePassword(ctx, user.Email, changes.Password.Value); err != nil {
+			return nil, err
+		}
+		user.Password = changes.Password.Value
 	}
 
 	// Сохраняем изменения через репозиторий
-	return s.repo.Update(user)
+	return s.repo.Update(ctx, user)
+}
+
+// DeleteUser Удаление пользователя (с проверкой версии, если она передана)
+func (s *userService) DeleteUser(ctx context.Context, id string, version *int64) error {
+	if err := s.repo.Delete(ctx, id, version); err != nil { // Удаляем через репозиторий
+		return err
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "user deleted", slog.String("user_id", id))
+	return nil
+}
+
+func (s *userService) GetUserByID(ctx context.Context, id string) (*models.User, error) {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return user, nil
 }
 
-// DeleteUser Удаление пользователя
-func (s *userService) DeleteUser(id string) error {
-	return s.repo.Delete(id) // Удаляем через репозиторий
+// GetUserByEmail Поиск пользователя по email
+func (s *userService) GetUserByEmail(ctx context.Context, email string) (*models.User, error) {
+	return s.repo.GetByEmail(ctx, email)
 }
 
-func (s *userService) GetUserByID(id string) (*models.User, error) {
-	user, err := s.repo.GetByID(id)
+// PromoteUser Выдача пользователю прав администратора. Повторный вызов ничего не меняет
+func (s *userService) PromoteUser(ctx context.Context, id string) (*models.User, error) {
+	user, err := s.repo.GetByID(ctx, id)
 	if err != nil {
 		return nil, err
 	}
+	if user.IsAdmin {
+		return user, nil
+	}
+	user.IsAdmin = true
+	if user, err = s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "user promoted to admin", slog.String("user_id", id))
 	return user, nil
 }
 
-func (s *userService) GetTasksForUser(userID string) ([]models.Task, error) {
-	_, err := s.repo.GetByID(userID)
+// ResetPassword Замена пароля пользователя без проверки версии
+func (s *userService) ResetPassword(ctx context.Context, id, secret string) (*models.User, error) {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.ValidatePassword(ctx, user.Email, secret); err != nil {
+		return nil, err
+	}
+	user.Password = secret
+	if user, err = s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "password reset", slog.String("user_id", id))
+	return user, nil
+}
+
+// MarkEmailVerified Подтверждение email пользователя. Если email с тех пор сменился,
+// возвращает ErrEmailChanged; повторное подтверждение ничего не меняет
+func (s *userService) MarkEmailVerified(ctx context.Context, id, email string) (*models.User, error) {
+	user, err := s.repo.GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if user.Email != email {
+		return nil, ErrEmailChanged
+	}
+	if user.EmailVerified() {
+		return user, nil
+	}
+	now := time.Now()
+	user.EmailVerifiedAt = &now
+	if user, err = s.repo.Update(ctx, user); err != nil {
+		return nil, err
+	}
+	logging.FromContext(ctx).InfoContext(ctx, "email verified", slog.String("user_id", id))
+	return user, nil
+}
+
+// ValidatePassword Проверка пароля по политике. Нарушения возвращаются как *password.ValidationError,
+// обернутая в ErrInvalidUser
+func (s *userService) ValidatePassword(ctx context.Context, email, secret string) error {
+	if err := s.passwords.Check(secret, email); err != nil {
+		logging.FromContext(ctx).DebugContext(ctx, "password rejected by policy", slog.String("error", err.Error()))
+		return fmt.Errorf("%w: %w", ErrInvalidUser, err)
+	}
+	return nil
+}
+
+// validEmail Похожа ли строка на адрес почты без имени и угловых скобок (user@example.com)
+func validEmail(email string) bool {
+	address, err := mail.ParseAddress(email)
+	return err == nil && address.Address == email
+}
+
+func (s *userService) GetTasksForUser(ctx context.Context, userID string) ([]models.Task, error) {
+	_, err := s.repo.GetByID(ctx, userID)
 	if err != nil {
 		return nil, err
 	}
 	// Получаем задачи через taskService
-	return s.repo.GetTasksForUser(userID)
+	return s.repo.GetTasksForUser(ctx, userID)
 }
@@ -2,11 +2,16 @@ package userService
 
 import (
 	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/password"
+	"POSTnGETtrain/internal/taskService"
+	"POSTnGETtrain/pkg/patch"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 func TestCreateUser(t *testing.T) {
@@ -23,7 +28,7 @@ func TestCreateUser(t *testing.T) {
 			email:    "test@mail.ru",
 			password: "password123",
 			mockSetup: func(m *MockUserRepository, email, password string) {
-				m.On("Create", mock.MatchedBy(func(user *models.User) bool {
+				m.On("Create", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
 					return user.Email == email && user.Password == password
 				})).Return(&models.User{
 					ID:       "test-id",
@@ -38,19 +43,35 @@ func TestCreateUser(t *testing.T) {
 			email:    "duplicate@mail.ru",
 			password: "pass1",
 			mockSetup: func(m *MockUserRepository, email, password string) {
-				m.On("Create", mock.MatchedBy(func(user *models.User) bool {
+				m.On("Create", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
 					return user.Email == email && user.Password == password
 				})).Return(nil, ErrEmailExists)
 			},
 			wantErr: true,
 			errMsg:  "email already exists",
 		},
+		{
+			name:      "некорректный email",
+			email:     "not an email",
+			password:  "pass1",
+			mockSetup: func(m *MockUserRepository, email, password string) {},
+			wantErr:   true,
+			errMsg:    "invalid user",
+		},
+		{
+			name:      "email с именем",
+			email:     "Alice <alice@example.com>",
+			password:  "pass1",
+			mockSetup: func(m *MockUserRepository, email, password string) {},
+			wantErr:   true,
+			errMsg:    "invalid user",
+		},
 		{
 			name:     "ошибка создания пользователя",
 			email:    "create-error@mail.ru",
 			password: "pass1",
 			mockSetup: func(m *MockUserRepository, email, password string) {
-				m.On("Create", mock.AnythingOfType("*models.User")).Return(nil, errors.New("create error"))
+				m.On("Create", mock.Anything, mock.AnythingOfType("*models.User")).Return(nil, errors.New("create error"))
 			},
 			wantErr: true,
 		},
@@ -62,7 +83,7 @@ func TestCreateUser(t *testing.T) {
 			tt.mockSetup(mockRepo, tt.email, tt.password)
 
 			service := NewUserService(mockRepo)
-			user, err := service.CreateUser(tt.email, tt.password)
+			user, err := service.CreateUser(t.Context(), tt.email, tt.password)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -73,6 +94,7 @@ func TestCreateUser(t *testing.T) {
 				assert.NoError(t, err)
 				assert.NotNil(t, user)
 				assert.Equal(t, tt.email, user.Email)
+				assert.False(t, user.EmailVerified(), "новый пользователь подтверждает email письмом")
 			}
 
 			mockRepo.AssertExpectations(t)
@@ -92,7 +114,7 @@ func TestGetUserByID(t *testing.T) {
 			name: "успешное получение",
 			id:   "user-id",
 			mockSetup: func(m *MockUserRepository, id string) {
-				m.On("GetByID", id).Return(&models.User{
+				m.On("GetByID", mock.Anything, id).Return(&models.User{
 					ID:       id,
 					Email:    "user@mail.ru",
 					Password: "secret",
@@ -109,7 +131,7 @@ func TestGetUserByID(t *testing.T) {
 			name: "ошибка получения",
 			id:   "nonexistent-id",
 			mockSetup: func(m *MockUserRepository, id string) {
-				m.On("GetByID", id).Return(nil, ErrUserNotFound)
+				m.On("GetByID", mock.Anything, id).Return(nil, ErrUserNotFound)
 			},
 			want:    nil,
 			wantErr: true,
@@ -122,7 +144,7 @@ func TestGetUserByID(t *testing.T) {
 			tt.mockSetup(mockRepo, tt.id)
 
 			service := NewUserService(mockRepo)
-			user, err := service.GetUserByID(tt.id)
+			user, err := service.GetUserByID(t.Context(), tt.id)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -146,7 +168,7 @@ func TestGetAllUsers(t *testing.T) {
 		{
 			name: "успешное получение всех юзеров",
 			mockSetup: func(m *MockUserRepository) {
-				m.On("GetAll").Return([]models.User{
+				m.On("GetAll", mock.Anything, models.Page{Limit: 2}).Return([]models.User{
 					{ID: "1", Email: "alabay@gmail.com", Password: "111"},
 					{ID: "2", Email: "barista@mail.ru", Password: "222"},
 				}, nil)
@@ -160,7 +182,7 @@ func TestGetAllUsers(t *testing.T) {
 		{
 			name: "ошибка репозитория",
 			mockSetup: func(m *MockUserRepository) {
-				m.On("GetAll").Return(nil, errors.New("db error"))
+				m.On("GetAll", mock.Anything, models.Page{Limit: 2}).Return(nil, errors.New("db error"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -174,7 +196,7 @@ func TestGetAllUsers(t *testing.T) {
 
 			service := NewUserService(mockRepo)
 
-			result, err := service.GetAllUsers()
+			result, err := service.GetAllUsers(t.Context(), models.Page{Limit: 2})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -192,28 +214,29 @@ func TestGetAllUsers(t *testing.T) {
 func TestUpdateUser(t *testing.T) {
 	newEmail := "new@mail.ru"
 	newPass := "newpass"
+	staleVersion := int64(1)
+	verifiedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
 
 	tests := []struct {
 		name      string
 		id        string
-		email     *string
-		password  *string
+		version   *int64
+		changes   models.UserChanges
 		mockSetup func(m *MockUserRepository, id string)
 		want      *models.User
 		wantErr   bool
 	}{
 		{
-			name:     "успешное обновление",
-			id:       "user-id",
-			email:    &newEmail,
-			password: &newPass,
+			name:    "успешное обновление",
+			id:      "user-id",
+			changes: models.UserChanges{Email: patch.Value(newEmail), Password: patch.Value(newPass)},
 			mockSetup: func(m *MockUserRepository, id string) {
-				m.On("GetByID", id).Return(&models.User{
+				m.On("GetByID", mock.Anything, id).Return(&models.User{
 					ID:       id,
 					Email:    "old@mail.ru",
 					Password: "oldpass",
 				}, nil)
-				m.On("Update", mock.MatchedBy(func(user *models.User) bool {
+				m.On("Update", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
 					return user.ID == id && user.Email == newEmail && user.Password == newPass
 				})).Return(&models.User{
 					ID:       id,
@@ -229,28 +252,26 @@ func TestUpdateUser(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:     "ошибка получения пользователя",
-			id:       "not_found",
-			email:    &newEmail,
-			password: &newPass,
+			name:    "ошибка получения пользователя",
+			id:      "not_found",
+			changes: models.UserChanges{Email: patch.Value(newEmail), Password: patch.Value(newPass)},
 			mockSetup: func(m *MockUserRepository, id string) {
-				m.On("GetByID", id).Return(nil, ErrUserNotFound)
+				m.On("GetByID", mock.Anything, id).Return(nil, ErrUserNotFound)
 			},
 			want:    nil,
 			wantErr: true,
 		},
 		{
-			name:     "обновление только email",
-			id:       "user-id",
-			email:    &newEmail,
-			password: nil,
+			name:    "обновление только email",
+			id:      "user-id",
+			changes: models.UserChanges{Email: patch.Value(newEmail)},
 			mockSetup: func(m *MockUserRepository, id string) {
-				m.On("GetByID", id).Return(&models.User{
+				m.On("GetByID", mock.Anything, id).Return(&models.User{
 					ID:       id,
 					Email:    "old@mail.ru",
 					Password: "oldpass",
 				}, nil)
-				m.On("Update", mock.MatchedBy(func(user *models.User) bool {
+				m.On("Update", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
 					return user.ID == id && user.Email == newEmail && user.Password == "oldpass"
 				})).Return(&models.User{
 					ID:       id,
@@ -266,16 +287,16 @@ func TestUpdateUser(t *testing.T) {
 			wantErr: false,
 		},
 		{
-			name:     "обновление только пароля",
-			id:       "user-id",
-			password: &newPass,
+			name:    "обновление только пароля",
+			id:      "user-id",
+			changes: models.UserChanges{Password: patch.Value(newPass)},
 			mockSetup: func(m *MockUserRepository, id string) {
-				m.On("GetByID", id).Return(&models.User{
+				m.On("GetByID", mock.Anything, id).Return(&models.User{
 					ID:       id,
 					Email:    "old@mail.ru",
 					Password: "oldpass",
 				}, nil)
-				m.On("Update", mock.MatchedBy(func(user *models.User) bool {
+				m.On("Update", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
 					return user.ID == id && user.Email == "old@mail.ru" && user.Password == newPass
 				})).Return(&models.User{
 					ID:       id,
@@ -290,6 +311,76 @@ func TestUpdateUser(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name:    "устаревшая версия",
+			id:      "user-id",
+			version: &staleVersion,
+			changes: models.UserChanges{Password: patch.Value(newPass)},
+			mockSetup: func(m *MockUserRepository, id string) {
+				m.On("GetByID", mock.Anything, id).Return(&models.User{
+					ID:       id,
+					Email:    "old@mail.ru",
+					Password: "oldpass",
+					Version:  2,
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "очистка email",
+			id:      "user-id",
+			changes: models.UserChanges{Email: patch.Null[string]()},
+			mockSetup: func(m *MockUserRepository, id string) {
+				m.On("GetByID", mock.Anything, id).Return(&models.User{
+					ID:       id,
+					Email:    "old@mail.ru",
+					Password: "oldpass",
+				}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "некорректный email",
+			id:      "user-id",
+			changes: models.UserChanges{Email: patch.Value("new at mail.ru")},
+			mockSetup: func(m *MockUserRepository, id string) {
+				m.On("GetByID", mock.Anything, id).Return(&models.User{ID: id, Email: "old@mail.ru", Password: "oldpass"}, nil)
+			},
+			want:    nil,
+			wantErr: true,
+		},
+		{
+			name:    "смена email сбрасывает подтверждение",
+			id:      "user-id",
+			changes: models.UserChanges{Email: patch.Value(newEmail)},
+			mockSetup: func(m *MockUserRepository, id string) {
+				m.On("GetByID", mock.Anything, id).Return(&models.User{
+					ID: id, Email: "old@mail.ru", Password: "oldpass", EmailVerifiedAt: &verifiedAt,
+				}, nil)
+				m.On("Update", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
+					return user.Email == newEmail && user.EmailVerifiedAt == nil
+				})).Return(&models.User{ID: id, Email: newEmail, Password: "oldpass"}, nil)
+			},
+			want:    &models.User{ID: "user-id", Email: newEmail, Password: "oldpass"},
+			wantErr: false,
+		},
+		{
+			name:    "тот же email остается подтвержденным",
+			id:      "user-id",
+			changes: models.UserChanges{Email: patch.Value("old@mail.ru"), Password: patch.Value(newPass)},
+			mockSetup: func(m *MockUserRepository, id string) {
+				m.On("GetByID", mock.Anything, id).Return(&models.User{
+					ID: id, Email: "old@mail.ru", Password: "oldpass", EmailVerifiedAt: &verifiedAt,
+				}, nil)
+				m.On("Update", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
+					return user.EmailVerifiedAt != nil
+				})).Return(&models.User{ID: id, Email: "old@mail.ru", Password: newPass, EmailVerifiedAt: &verifiedAt}, nil)
+			},
+			want:    &models.User{ID: "user-id", Email: "old@mail.ru", Password: newPass, EmailVerifiedAt: &verifiedAt},
+			wantErr: false,
+		},
 	}
 
 	for _, tt := range tests {
@@ -298,7 +389,7 @@ func TestUpdateUser(t *testing.T) {
 			tt.mockSetup(mockRepo, tt.id)
 
 			service := NewUserService(mockRepo)
-			result, err := service.UpdateUser(tt.id, tt.email, tt.password)
+			result, err := service.UpdateUser(t.Context(), tt.id, tt.version, tt.changes)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -323,7 +414,7 @@ func TestDeleteUser(t *testing.T) {
 			name: "успешное удаление",
 			id:   "user-id",
 			mockSetup: func(m *MockUserRepository, id string) {
-				m.On("Delete", id).Return(nil)
+				m.On("Delete", mock.Anything, id, (*int64)(nil)).Return(nil)
 			},
 			wantErr: false,
 		},
@@ -331,7 +422,7 @@ func TestDeleteUser(t *testing.T) {
 			name: "ошибка удаления",
 			id:   "not_found",
 			mockSetup: func(m *MockUserRepository, id string) {
-				m.On("Delete", id).Return(ErrUserNotFound)
+				m.On("Delete", mock.Anything, id, (*int64)(nil)).Return(ErrUserNotFound)
 			},
 			wantErr: true,
 		},
@@ -344,7 +435,7 @@ func TestDeleteUser(t *testing.T) {
 
 			service := NewUserService(mockRepo)
 
-			err := service.DeleteUser(tt.id)
+			err := service.DeleteUser(t.Context(), tt.id, nil)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -369,7 +460,7 @@ func TestEmailExists(t *testing.T) {
 			name:  "email существует",
 			email: "exists@mail.ru",
 			mockSetup: func(m *MockUserRepository, email string) {
-				m.On("EmailExists", email).Return(true, nil)
+				m.On("EmailExists", mock.Anything, email).Return(true, nil)
 			},
 			want:    true,
 			wantErr: false,
@@ -378,7 +469,7 @@ func TestEmailExists(t *testing.T) {
 			name:  "email не существует",
 			email: "notexists@mail.ru",
 			mockSetup: func(m *MockUserRepository, email string) {
-				m.On("EmailExists", email).Return(false, nil)
+				m.On("EmailExists", mock.Anything, email).Return(false, nil)
 			},
 			want:    false,
 			wantErr: false,
@@ -387,7 +478,7 @@ func TestEmailExists(t *testing.T) {
 			name:  "ошибка проверки email",
 			email: "error@mail.ru",
 			mockSetup: func(m *MockUserRepository, email string) {
-				m.On("EmailExists", email).Return(false, errors.New("db error"))
+				m.On("EmailExists", mock.Anything, email).Return(false, errors.New("db error"))
 			},
 			want:    false,
 			wantErr: true,
@@ -399,7 +490,7 @@ func TestEmailExists(t *testing.T) {
 			mockRepo := new(MockUserRepository)
 			tt.mockSetup(mockRepo, tt.email)
 
-			result, err := mockRepo.EmailExists(tt.email)
+			result, err := mockRepo.EmailExists(t.Context(), tt.email)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -425,12 +516,12 @@ func TestGetTasksForUser(t *testing.T) {
 			name:   "успешное получение задач пользователя",
 			userID: "user-id",
 			mockSetup: func(m *MockUserRepository, userID string) {
-				m.On("GetByID", userID).Return(&models.User{
+				m.On("GetByID", mock.Anything, userID).Return(&models.User{
 					ID:       userID,
 					Email:    "test@mail.ru",
 					Password: "pass123",
 				}, nil)
-				m.On("GetTasksForUser", userID).Return([]models.Task{
+				m.On("GetTasksForUser", mock.Anything, userID).Return([]models.Task{
 					{ID: "task-1", Name: "Task 1", IsDone: false},
 					{ID: "task-2", Name: "Task 2", IsDone: true},
 				}, nil)
@@ -445,7 +536,7 @@ func TestGetTasksForUser(t *testing.T) {
 			name:   "пользователь не найден",
 			userID: "nonexistent",
 			mockSetup: func(m *MockUserRepository, userID string) {
-				m.On("GetByID", userID).Return(nil, ErrUserNotFound)
+				m.On("GetByID", mock.Anything, userID).Return(nil, ErrUserNotFound)
 			},
 			want:    nil,
 			wantErr: true,
@@ -454,12 +545,12 @@ func TestGetTasksForUser(t *testing.T) {
 			name:   "ошибка получения задач",
 			userID: "user-1",
 			mockSetup: func(m *MockUserRepository, userID string) {
-				m.On("GetByID", userID).Return(&models.User{
+				m.On("GetByID", mock.Anything, userID).Return(&models.User{
 					ID:       userID,
 					Email:    "test@mail.ru",
 					Password: "pass123",
 				}, nil)
-				m.On("GetTasksForUser", userID).Return(nil, errors.New("db error"))
+				m.On("GetTasksForUser", mock.Anything, userID).Return(nil, errors.New("db error"))
 			},
 			want:    nil,
 			wantErr: true,
@@ -472,7 +563,7 @@ func TestGetTasksForUser(t *testing.T) {
 			tt.mockSetup(mockRepo, tt.userID)
 
 			service := NewUserService(mockRepo)
-			result, err := service.GetTasksForUser(tt.userID)
+			result, err := service.GetTasksForUser(t.Context(), tt.userID)
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -485,3 +576,233 @@ func TestGetTasksForUser(t *testing.T) {
 		})
 	}
 }
+
+func TestCreateAdmin(t *testing.T) {
+	mockRepo := new(MockUserRepository)
+	mockRepo.On("Create", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
+		// Email администратора из командной строки не подтверждается письмом
+		return user.Email == "root@example.com" && user.IsAdmin && user.EmailVerified()
+	})).Return(&models.User{ID: "1", Email: "root@example.com", IsAdmin: true}, nil)
+
+	service := NewUserService(mockRepo)
+	user, err := service.CreateAdmin(t.Context(), "root@example.com", "secret")
+	require.NoError(t, err)
+	assert.True(t, user.IsAdmin)
+
+	_, err = service.CreateAdmin(t.Context(), "root@example.com", "")
+	assert.ErrorIs(t, err, ErrInvalidUser)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestPasswordPolicy(t *testing.T) {
+	policy := password.Policy{MinLength: 10, Require: []password.Rule{password.RuleDigit}, DisallowEmail: true}
+	tests := []struct {
+		name      string
+		run       func(t *testing.T, s UserService, user *models.User) error
+		wantRules []password.Rule
+	}{
+		{
+			name: "создание со слабым паролем",
+			run: func(t *testing.T, s UserService, _ *models.User) error {
+				_, err := s.CreateUser(t.Context(), "bob@example.com", "bob")
+				return err
+			},
+			wantRules: []password.Rule{password.RuleMinLength, password.RuleDigit},
+		},
+		{
+			name: "смена пароля",
+			run: func(t *testing.T, s UserService, user *models.User) error {
+				_, err := s.UpdateUser(t.Context(), user.ID, nil, models.UserChanges{Password: patch.Value("short")})
+				return err
+			},
+			wantRules: []password.Rule{password.RuleMinLength, password.RuleDigit},
+		},
+		{
+			name: "пароль проверяется с новым email",
+			run: func(t *testing.T, s UserService, user *models.User) error {
+				_, err := s.UpdateUser(t.Context(), user.ID, nil, models.UserChanges{
+					Email:    patch.Value("carol@example.com"),
+					Password: patch.Value("carol-2026-xyz"),
+				})
+				return err
+			},
+			wantRules: []password.Rule{password.RuleNoEmail},
+		},
+		{
+			name: "сброс пароля",
+			run: func(t *testing.T, s UserService, user *models.User) error {
+				_, err := s.ResetPassword(t.Context(), user.ID, "alice-2026-xyz")
+				return err
+			},
+			wantRules: []password.Rule{password.RuleNoEmail},
+		},
+		{
+			name: "смена email без смены пароля",
+			run: func(t *testing.T, s UserService, user *models.User) error {
+				_, err := s.UpdateUser(t.Context(), user.ID, nil, models.UserChanges{Email: patch.Value("carol@example.com")})
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewUserService(NewMemoryUserRepository(taskService.NewMemoryTaskRepository()), WithPasswordPolicy(policy))
+			user, err := s.CreateUser(t.Context(), "alice@example.com", "correct-horse-42")
+			require.NoError(t, err)
+
+			err = tt.run(t, s, user)
+			if tt.wantRules == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, ErrInvalidUser)
+			var validationErr *password.ValidationError
+			require.ErrorAs(t, err, &validationErr)
+			rules := make([]password.Rule, len(validationErr.Violations))
+			for i, violation := range validationErr.Violations {
+				rules[i] = violation.Rule
+			}
+			assert.Equal(t, tt.wantRules, rules)
+
+			// Пароль остался прежним
+			stored, err := s.GetUserByID(t.Context(), user.ID)
+			require.NoError(t, err)
+			assert.Equal(t, "correct-horse-42", stored.Password)
+		})
+	}
+}
+
+func TestPromoteUser(t *testing.T) {
+	tests := []struct {
+		name       string
+		stored     *models.User
+		findErr    error
+		wantUpdate bool
+		wantErr    error
+	}{
+		{
+			name:       "обычный пользователь становится администратором",
+			stored:     &models.User{ID: "1", Version: 3},
+			wantUpdate: true,
+		},
+		{
+			name:   "администратор остается без изменений",
+			stored: &models.User{ID: "1", Version: 3, IsAdmin: true},
+		},
+		{
+			name:    "пользователь не найден",
+			findErr: ErrUserNotFound,
+			wantErr: ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			mockRepo.On("GetByID", mock.Anything, "1").Return(tt.stored, tt.findErr)
+			if tt.wantUpdate {
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
+					return user.IsAdmin && user.Version == 3
+				})).Return(&models.User{ID: "1", Version: 4, IsAdmin: true}, nil)
+			}
+
+			user, err := NewUserService(mockRepo).PromoteUser(t.Context(), "1")
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, user.IsAdmin)
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
+
+func TestResetPassword(t *testing.T) {
+	tests := []struct {
+		name     string
+		password string
+		wantErr  error
+	}{
+		{name: "новый пароль", password: "n3w-secret"},
+		{name: "пустой пароль", password: "", wantErr: ErrInvalidUser},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			mockRepo.On("GetByID", mock.Anything, "1").Return(&models.User{ID: "1", Password: "old", Version: 2}, nil).Maybe()
+			mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
+				return user.Password == tt.password
+			})).Return(&models.User{ID: "1", Password: tt.password, Version: 3}, nil).Maybe()
+
+			user, err := NewUserService(mockRepo).ResetPassword(t.Context(), "1", tt.password)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				mockRepo.AssertNotCalled(t, "Update", mock.Anything, mock.Anything)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.password, user.Password)
+		})
+	}
+}
+
+func TestMarkEmailVerified(t *testing.T) {
+	verifiedAt := time.Date(2026, 10, 1, 12, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name       string
+		stored     *models.User
+		findErr    error
+		email      string
+		wantUpdate bool
+		wantErr    error
+	}{
+		{
+			name:       "подтверждение текущего email",
+			stored:     &models.User{ID: "1", Email: "alice@example.com", Version: 2},
+			email:      "alice@example.com",
+			wantUpdate: true,
+		},
+		{
+			name:   "повторное подтверждение",
+			stored: &models.User{ID: "1", Email: "alice@example.com", Version: 2, EmailVerifiedAt: &verifiedAt},
+			email:  "alice@example.com",
+		},
+		{
+			name:    "email сменился после отправки письма",
+			stored:  &models.User{ID: "1", Email: "alice@example.org", Version: 3},
+			email:   "alice@example.com",
+			wantErr: ErrEmailChanged,
+		},
+		{
+			name:    "пользователь не найден",
+			findErr: ErrUserNotFound,
+			email:   "alice@example.com",
+			wantErr: ErrUserNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockRepo := new(MockUserRepository)
+			mockRepo.On("GetByID", mock.Anything, "1").Return(tt.stored, tt.findErr)
+			if tt.wantUpdate {
+				mockRepo.On("Update", mock.Anything, mock.MatchedBy(func(user *models.User) bool {
+					return user.EmailVerified() && user.Version == 2
+				})).Return(&models.User{ID: "1", Email: tt.email, Version: 3, EmailVerifiedAt: &verifiedAt}, nil)
+			}
+
+			user, err := NewUserService(mockRepo).MarkEmailVerified(t.Context(), "1", tt.email)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, user.EmailVerified())
+			mockRepo.AssertExpectations(t)
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package userService
+
+import (
+	"POSTnGETtrain/internal/models"
+	"POSTnGETtrain/internal/tracing"
+	"context"
+
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// tracedUserService Выполняет каждый метод сервиса в отдельном спане.
+// Email и пароли в атрибуты не попадают
+type tracedUserService struct {
+	next UserService
+}
+
+// NewTracedUserService Оборачивает сервис пользователей трассировкой
+func NewTracedUserService(s UserService) UserService {
+	return &tracedUserService{next: s}
+}
+
+func (s *tracedUserService) GetAllUsers(ctx context.Context, page models.Page) (users []models.User, err error) {
+	ctx, span := tracing.Start(ctx, "userService.GetAllUsers",
+		attribute.Int("page.limit", page.Limit), attribute.Int("page.offset", page.Offset))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetAllUsers(ctx, page)
+}
+
+func (s *tracedUserService) CreateUser(ctx context.Context, email, password string) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "userService.CreateUser")
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateUser(ctx, email, password)
+}
+
+func (s *tracedUserService) UpdateUser(ctx context.Context, id string, version *int64, changes models.UserChanges) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "userService.UpdateUser", attribute.String("user.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.UpdateUser(ctx, id, version, changes)
+}
+
+func (s *tracedUserService) DeleteUser(ctx context.Context, id string, version *int64) (err error) {
+	ctx, span := tracing.Start(ctx, "userService.DeleteUser", attribute.String("user.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.DeleteUser(ctx, id, version)
+}
+
+func (s *tracedUserService) GetUserByID(ctx context.Context, id string) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "userService.GetUserByID", attribute.String("user.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetUserByID(ctx, id)
+}
+
+func (s *tracedUserService) GetUserByEmail(ctx context.Context, email string) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "userService.GetUserByEmail")
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetUserByEmail(ctx, email)
+}
+
+func (s *tracedUserService) GetTasksForUser(ctx context.Context, userID string) (tasks []models.Task, err error) {
+	ctx, span := tracing.Start(ctx, "userService.GetTasksForUser", attribute.String("user.id", userID))
+	defer func() { tracing.End(span, err) }()
+	return s.next.GetTasksForUser(ctx, userID)
+}
+
+func (s *tracedUserService) CreateAdmin(ctx context.Context, email, password string) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "userService.CreateAdmin")
+	defer func() { tracing.End(span, err) }()
+	return s.next.CreateAdmin(ctx, email, password)
+}
+
+func (s *tracedUserService) PromoteUser(ctx context.Context, id string) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "userService.PromoteUser", attribute.String("user.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.PromoteUser(ctx, id)
+}
+
+func (s *tracedUserService) ResetPassword(ctx context.Context, id, password string) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "userService.ResetPassword", attribute.String("user.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.ResetPassword(ctx, id, password)
+}
+
+func (s *tracedUserService) MarkEmailVerified(ctx context.Context, id, email string) (user *models.User, err error) {
+	ctx, span := tracing.Start(ctx, "userService.MarkEmailVerified", attribute.String("user.id", id))
+	defer func() { tracing.End(span, err) }()
+	return s.next.MarkEmailVerified(ctx, id, email)
+}
+
+func (s *tracedUserService) ValidatePassword(ctx context.Context, email, password string) (err error) {
+	ctx, span := tracing.Start(ctx, "userService.ValidatePassword")
+	defer func() { tracing.End(span, err) }()
+	return s.next.ValidatePassword(ctx, email, password)
+}
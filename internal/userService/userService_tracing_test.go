@@ -0,0 +1,37 @@
+package userService
+
+import (
+	"POSTnGETtrain/internal/taskService"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestTracedUserService(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	service := NewTracedUserService(NewUserService(NewMemoryUserRepository(taskService.NewMemoryTaskRepository())))
+	user, err := service.CreateUser(t.Context(), "anna@example.com", "hunter2")
+	require.NoError(t, err)
+	_, err = service.ResetPassword(t.Context(), user.ID, "correct horse")
+	require.NoError(t, err)
+
+	spans := recorder.Ended()
+	require.Len(t, spans, 2)
+	assert.Equal(t, "userService.CreateUser", spans[0].Name())
+	assert.Equal(t, "userService.ResetPassword", spans[1].Name())
+
+	// В атрибуты не попадают ни email, ни пароль
+	for _, span := range spans {
+		for _, attr := range span.Attributes() {
+			assert.NotContains(t, []string{"anna@example.com", "hunter2", "correct horse"}, attr.Value.Emit())
+		}
+	}
+}
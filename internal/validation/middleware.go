@@ -0,0 +1,129 @@
+// Package validation проверяет запросы (и, в режиме разработки, ответы)
+// на соответствие спецификации openapi.yaml
+package validation
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/getkin/kin-openapi/openapi3filter"
+	"github.com/getkin/kin-openapi/routers/gorillamux"
+	"github.com/labstack/echo/v4"
+)
+
+func init() {
+	// kin-openapi не проверяет эти форматы, пока их не зарегистрировать
+	openapi3.DefineStringFormatValidator("uuid", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForUUIDOfRFC4122))
+	openapi3.DefineStringFormatValidator("email", openapi3.NewRegexpFormatValidator(openapi3.FormatOfStringForEmail))
+}
+
+// Config Настройки middleware
+type Config struct {
+	ValidateResponses bool // Проверять ответы сервера (для режима разработки)
+}
+
+// Middleware Возвращает Echo middleware, которое отклоняет с кодом 400 запросы,
+// не соответствующие спецификации. Пути, которых нет в спецификации, пропускаются без проверки
+func Middleware(spec *openapi3.T, cfg Config) (echo.MiddlewareFunc, error) {
+	// Адреса серверов из спецификации не должны влиять на сопоставление путей
+	routing := *spec
+	routing.Servers = nil
+
+	router, err := gorillamux.NewRouter(&routing)
+	if err != nil {
+		return nil, fmt.Errorf("validation: could not build router: %w", err)
+	}
+
+	options := &openapi3filter.Options{
+		AuthenticationFunc: openapi3filter.NoopAuthenticationFunc, // Авторизация проверяется не здесь
+	}
+
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			route, pathParams, err := router.FindRoute(req)
+			if err != nil {
+				return next(c) // Операция не описана в спецификации
+			}
+
+			input := &openapi3filter.RequestValidationInput{
+				Request:    req,
+				PathParams: pathParams,
+				Route:      route,
+				Options:    options,
+			}
+			if err := openapi3filter.ValidateRequest(req.Context(), input); err != nil {
+				return echo.NewHTTPError(http.StatusBadRequest, describe(err)).SetInternal(err)
+			}
+
+			if !cfg.ValidateResponses {
+				return next(c)
+			}
+			return validateResponse(c, next, input)
+		}
+	}, nil
+}
+
+// validateResponse Выполняет запрос и сверяет ответ со спецификацией.
+// Расхождения только логируются: клиент получает ответ как есть
+func validateResponse(c echo.Context, next echo.HandlerFunc, input *openapi3filter.RequestValidationInput) error {
+	response := c.Response()
+	recorder := &bodyRecorder{ResponseWriter: response.Writer}
+	response.Writer = recorder
+	defer func() { response.Writer = recorder.ResponseWriter }()
+
+	if err := next(c); err != nil {
+		return err
+	}
+
+	err := openapi3filter.ValidateResponse(c.Request().Context(), &openapi3filter.ResponseValidationInput{
+		RequestValidationInput: input,
+		Status:                 response.Status,
+		Header:                 response.Header(),
+		Body:                   io.NopCloser(bytes.NewReader(recorder.body.Bytes())),
+	})
+	if err != nil {
+		c.Logger().Errorf("response of %s %s does not match openapi spec: %v",
+			input.Request.Method, input.Route.Path, err)
+	}
+	return nil
+}
+
+// describe Превращает ошибку kin-openapi в короткое сообщение для клиента
+// (без дампа схемы), например: "request body: /name: minimum string length is 1"
+func describe(err error) string {
+	var requestErr *openapi3filter.RequestError
+	if !errors.As(err, &requestErr) {
+		return err.Error()
+	}
+
+	where := "request body"
+	if requestErr.Parameter != nil {
+		where = fmt.Sprintf("%s parameter %q", requestErr.Parameter.In, requestErr.Parameter.Name)
+	}
+
+	var schemaErr *openapi3.SchemaError
+	if errors.As(requestErr.Err, &schemaErr) {
+		return fmt.Sprintf("%s: /%s: %s", where, strings.Join(schemaErr.JSONPointer(), "/"), schemaErr.Reason)
+	}
+	if requestErr.Err != nil {
+		return fmt.Sprintf("%s: %v", where, requestErr.Err)
+	}
+	return fmt.Sprintf("%s: %s", where, requestErr.Reason)
+}
+
+// bodyRecorder Копирует тело ответа, пропуская его дальше клиенту
+type bodyRecorder struct {
+	http.ResponseWriter
+	body bytes.Buffer
+}
+
+func (r *bodyRecorder) Write(b []byte) (int, error) {
+	r.body.Write(b)
+	return r.ResponseWriter.Write(b)
+}
@@ -0,0 +1,80 @@
+package validation
+
+import (
+	"POSTnGETtrain/openapi"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const validID = "0b6e2d9c-3f44-4c55-9f6b-7c1d2e3f4a5b"
+
+func TestMiddleware(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+	validator, err := Middleware(spec, Config{})
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Use(validator)
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+	e.POST("/tasks", ok)
+	e.POST("/users", ok)
+	e.PATCH("/tasks/:id", ok)
+	e.GET("/health", ok)
+
+	tests := []struct {
+		name        string
+		method      string
+		path        string
+		contentType string
+		body        string
+		wantStatus  int
+		wantMessage string
+	}{
+		{name: "корректная задача", method: http.MethodPost, path: "/tasks",
+			body: `{"name":"Buy milk","user_id":"` + validID + `"}`, wantStatus: http.StatusOK},
+		{name: "пустое название", method: http.MethodPost, path: "/tasks",
+			body: `{"name":"","user_id":"` + validID + `"}`, wantStatus: http.StatusBadRequest,
+			wantMessage: "request body: /name: minimum string length is 1"},
+		{name: "слишком длинное название", method: http.MethodPost, path: "/tasks",
+			body:       `{"name":"` + strings.Repeat("a", 256) + `","user_id":"` + validID + `"}`,
+			wantStatus: http.StatusBadRequest},
+		{name: "user_id не uuid", method: http.MethodPost, path: "/tasks",
+			body: `{"name":"Buy milk","user_id":"42"}`, wantStatus: http.StatusBadRequest},
+		{name: "неизвестное поле", method: http.MethodPost, path: "/tasks",
+			body: `{"name":"Buy milk","user_id":"` + validID + `","priority":1}`, wantStatus: http.StatusBadRequest},
+		{name: "email без @", method: http.MethodPost, path: "/users",
+			body: `{"email":"not-an-email","password":"secret"}`, wantStatus: http.StatusBadRequest},
+		{name: "id в пути не uuid", method: http.MethodPatch, path: "/tasks/42",
+			body: `{"name":"Buy milk"}`, wantStatus: http.StatusBadRequest},
+		{name: "merge patch с null", method: http.MethodPatch, path: "/tasks/" + validID,
+			contentType: "application/merge-patch+json", body: `{"user_id":null}`, wantStatus: http.StatusOK},
+		{name: "путь вне спецификации", method: http.MethodGet, path: "/health", wantStatus: http.StatusOK},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			contentType := tt.contentType
+			if contentType == "" {
+				contentType = echo.MIMEApplicationJSON
+			}
+			if tt.body != "" {
+				req.Header.Set(echo.HeaderContentType, contentType)
+			}
+			rec := httptest.NewRecorder()
+			e.ServeHTTP(rec, req)
+
+			assert.Equal(t, tt.wantStatus, rec.Code, rec.Body.String())
+			if tt.wantMessage != "" {
+				assert.Contains(t, rec.Body.String(), tt.wantMessage)
+			}
+		})
+	}
+}
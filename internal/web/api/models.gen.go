@@ -0,0 +1,352 @@
+// Package api provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package api
+
+import (
+	"encoding/json"
+	"time"
+
+	"POSTnGETtrain/pkg/patch"
+)
+
+// Defines values for JSONPatchOperationOp.
+const (
+	Add     JSONPatchOperationOp = "add"
+	Copy    JSONPatchOperationOp = "copy"
+	Move    JSONPatchOperationOp = "move"
+	Remove  JSONPatchOperationOp = "remove"
+	Replace JSONPatchOperationOp = "replace"
+	Test    JSONPatchOperationOp = "test"
+)
+
+// Defines values for VerifyCredentialsResponseStatus.
+const (
+	TotpRequired VerifyCredentialsResponseStatus = "totp_required"
+	Verified     VerifyCredentialsResponseStatus = "verified"
+)
+
+// ConfirmTOTPRequest defines model for ConfirmTOTPRequest.
+type ConfirmTOTPRequest struct {
+	Code     string `json:"code"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Credentials defines model for Credentials.
+type Credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	Message string `json:"message"`
+
+	// RequestId X-Request-ID of the request that failed
+	RequestId *string `json:"request_id,omitempty"`
+
+	// Violations Password policy rules the submitted password breaks, one item per rule
+	Violations *[]PasswordViolation `json:"violations,omitempty"`
+}
+
+// ForgotPasswordRequest defines model for ForgotPasswordRequest.
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ID defines model for ID.
+type ID = string
+
+// JSONPatch JSON Patch (RFC 6902) document. The password is not part of the user representation,
+// so it is set with an add operation on /password
+type JSONPatch = []JSONPatchOperation
+
+// JSONPatchOperation defines model for JSONPatchOperation.
+type JSONPatchOperation struct {
+	From  *string              `json:"from,omitempty"`
+	Op    JSONPatchOperationOp `json:"op"`
+	Path  string               `json:"path"`
+	Value json.RawMessage      `json:"value"`
+}
+
+// JSONPatchOperationOp defines model for JSONPatchOperation.Op.
+type JSONPatchOperationOp string
+
+// PasswordViolation defines model for PasswordViolation.
+type PasswordViolation struct {
+	Message string `json:"message"`
+
+	// Rule Broken rule: min_length, max_length, uppercase, lowercase, digit, symbol,
+	// no_email (contains parts of the email address) or not_breached (found in a list of leaked passwords)
+	Rule string `json:"rule"`
+}
+
+// RecoveryCodes defines model for RecoveryCodes.
+type RecoveryCodes struct {
+	// RecoveryCodes One-time codes to use instead of a TOTP code when the authenticator is lost
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// ResendVerificationRequest defines model for ResendVerificationRequest.
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest defines model for ResetPasswordRequest.
+type ResetPasswordRequest struct {
+	Password string `json:"password"`
+	Token    string `json:"token"`
+
+	// TOTPCode TOTP code or recovery code; required for users with two-factor authentication
+	TOTPCode *string `json:"totp_code,omitempty"`
+}
+
+// TOTPEnrollment defines model for TOTPEnrollment.
+type TOTPEnrollment struct {
+	// OtpauthURI otpauth://totp/ URI to show as a QR code
+	OtpauthURI string `json:"otpauth_uri"`
+
+	// Secret Base32 secret for manual entry
+	Secret string `json:"secret"`
+}
+
+// Task defines model for Task.
+type Task struct {
+	ID     string `json:"id"`
+	IsDone bool   `json:"is_done"`
+	Name   string `json:"name"`
+	UserID string `json:"user_id"`
+}
+
+// TaskMergePatch JSON Merge Patch (RFC 7396) for a task; null clears the field
+type TaskMergePatch struct {
+	IsDone patch.Field[bool]   `json:"is_done,omitzero"`
+	Name   patch.Field[string] `json:"name,omitzero"`
+	UserID patch.Field[string] `json:"user_id,omitzero"`
+}
+
+// TaskRequest defines model for TaskRequest.
+type TaskRequest struct {
+	IsDone *bool  `json:"is_done,omitempty"`
+	Name   string `json:"name"`
+	UserID string `json:"user_id"`
+}
+
+// TaskUpdate defines model for TaskUpdate.
+type TaskUpdate struct {
+	IsDone *bool   `json:"is_done,omitempty"`
+	Name   *string `json:"name,omitempty"`
+	UserID *string `json:"user_id,omitempty"`
+}
+
+// User defines model for User.
+type User struct {
+	Email string `json:"email"`
+
+	// EmailVerifiedAt When the user verified their email; null until verified
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	ID              string     `json:"id"`
+}
+
+// UserMergePatch JSON Merge Patch (RFC 7396) for a user; null clears the field
+type UserMergePatch struct {
+	Email    patch.Field[string] `json:"email,omitzero"`
+	Password patch.Field[string] `json:"password,omitzero"`
+}
+
+// UserRequest defines model for UserRequest.
+type UserRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// UserUpdate defines model for UserUpdate.
+type UserUpdate struct {
+	Email    *string `json:"email,omitempty"`
+	Password *string `json:"password,omitempty"`
+}
+
+// UserWithTasks defines model for UserWithTasks.
+type UserWithTasks struct {
+	Email string `json:"email"`
+	Id    string `json:"id"`
+}
+
+// VerifyCredentialsRequest Either email and password (step one) or challenge and code (step two)
+type VerifyCredentialsRequest struct {
+	Challenge *string `json:"challenge,omitempty"`
+
+	// Code TOTP code or recovery code
+	Code     *string `json:"code,omitempty"`
+	Email    *string `json:"email,omitempty"`
+	Password *string `json:"password,omitempty"`
+}
+
+// VerifyCredentialsResponse defines model for VerifyCredentialsResponse.
+type VerifyCredentialsResponse struct {
+	// Challenge Send it back with a TOTP code; only with status totp_required
+	Challenge *string `json:"challenge,omitempty"`
+
+	// ExpiresAt When the challenge expires
+	ExpiresAt *time.Time                      `json:"expires_at,omitempty"`
+	Status    VerifyCredentialsResponseStatus `json:"status"`
+
+	// UserID The verified user; only with status verified
+	UserID *string `json:"user_id,omitempty"`
+}
+
+// VerifyCredentialsResponseStatus defines model for VerifyCredentialsResponse.Status.
+type VerifyCredentialsResponseStatus string
+
+// VerifyEmailRequest defines model for VerifyEmailRequest.
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
+// Limit defines model for Limit.
+type Limit = int
+
+// Offset defines model for Offset.
+type Offset = int
+
+// TOTPCode defines model for TOTPCode.
+type TOTPCode = string
+
+// GetTasksParams defines parameters for GetTasks.
+type GetTasksParams struct {
+	// Limit Maximum number of items to return; without it the whole list is returned
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip, ordered by id
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// PostTasksParams defines parameters for PostTasks.
+type PostTasksParams struct {
+	// IdempotencyKey Unique key of the request; retries with the same key get the stored response.
+	// Keys are scoped to the mTLS service, and reusing one with a different body is rejected.
+	// Anonymous clients get the stored response for a retry with the same key and body from any address;
+	// the same key with a different body is treated as a different request
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// DeleteTasksIdParams defines parameters for DeleteTasksId.
+type DeleteTasksIdParams struct {
+	// IfMatch ETag of the resource version the change is based on, "*" or a comma-separated list of strong ETags;
+	// the change is applied if any of them matches the current version
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetTasksIdParams defines parameters for GetTasksId.
+type GetTasksIdParams struct {
+	// IfNoneMatch ETags of the resource versions the client already has
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// PatchTasksIdParams defines parameters for PatchTasksId.
+type PatchTasksIdParams struct {
+	// IfMatch ETag of the resource version the change is based on, "*" or a comma-separated list of strong ETags;
+	// the change is applied if any of them matches the current version
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetUsersParams defines parameters for GetUsers.
+type GetUsersParams struct {
+	// Limit Maximum number of items to return; without it the whole list is returned
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip, ordered by id
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// PostUsersParams defines parameters for PostUsers.
+type PostUsersParams struct {
+	// IdempotencyKey Unique key of the request; retries with the same key get the stored response.
+	// Keys are scoped to the mTLS service, and reusing one with a different body is rejected.
+	// Anonymous clients get the stored response for a retry with the same key and body from any address;
+	// the same key with a different body is treated as a different request
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// DeleteUsersIdParams defines parameters for DeleteUsersId.
+type DeleteUsersIdParams struct {
+	// IfMatch ETag of the resource version the change is based on, "*" or a comma-separated list of strong ETags;
+	// the change is applied if any of them matches the current version
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+
+	// XTOTPCode Current code from the authenticator app or a recovery code; required for sensitive changes
+	// of users with two-factor authentication
+	XTOTPCode *TOTPCode `json:"X-TOTP-Code,omitempty"`
+}
+
+// GetUsersIdParams defines parameters for GetUsersId.
+type GetUsersIdParams struct {
+	// IfNoneMatch ETags of the resource versions the client already has
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// PatchUsersIdParams defines parameters for PatchUsersId.
+type PatchUsersIdParams struct {
+	// IfMatch ETag of the resource version the change is based on, "*" or a comma-separated list of strong ETags;
+	// the change is applied if any of them matches the current version
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+
+	// XTOTPCode Current code from the authenticator app or a recovery code; required for sensitive changes
+	// of users with two-factor authentication
+	XTOTPCode *TOTPCode `json:"X-TOTP-Code,omitempty"`
+}
+
+// PostAuthPasswordForgotJSONRequestBody defines body for PostAuthPasswordForgot for application/json ContentType.
+type PostAuthPasswordForgotJSONRequestBody = ForgotPasswordRequest
+
+// PostAuthPasswordResetJSONRequestBody defines body for PostAuthPasswordReset for application/json ContentType.
+type PostAuthPasswordResetJSONRequestBody = ResetPasswordRequest
+
+// PostAuthTotpConfirmJSONRequestBody defines body for PostAuthTotpConfirm for application/json ContentType.
+type PostAuthTotpConfirmJSONRequestBody = ConfirmTOTPRequest
+
+// PostAuthTotpEnrollJSONRequestBody defines body for PostAuthTotpEnroll for application/json ContentType.
+type PostAuthTotpEnrollJSONRequestBody = Credentials
+
+// PostAuthVerifyCredentialsJSONRequestBody defines body for PostAuthVerifyCredentials for application/json ContentType.
+type PostAuthVerifyCredentialsJSONRequestBody = VerifyCredentialsRequest
+
+// PostAuthVerifyEmailJSONRequestBody defines body for PostAuthVerifyEmail for application/json ContentType.
+type PostAuthVerifyEmailJSONRequestBody = VerifyEmailRequest
+
+// PostAuthVerifyEmailResendJSONRequestBody defines body for PostAuthVerifyEmailResend for application/json ContentType.
+type PostAuthVerifyEmailResendJSONRequestBody = ResendVerificationRequest
+
+// PostTasksJSONRequestBody defines body for PostTasks for application/json ContentType.
+type PostTasksJSONRequestBody = TaskRequest
+
+// PatchTasksIdJSONRequestBody defines body for PatchTasksId for application/json ContentType.
+type PatchTasksIdJSONRequestBody = TaskUpdate
+
+// PatchTasksIdApplicationJSONPatchPlusJSONRequestBody defines body for PatchTasksId for application/json-patch+json ContentType.
+type PatchTasksIdApplicationJSONPatchPlusJSONRequestBody = JSONPatch
+
+// PatchTasksIdApplicationMergePatchPlusJSONRequestBody defines body for PatchTasksId for application/merge-patch+json ContentType.
+type PatchTasksIdApplicationMergePatchPlusJSONRequestBody = TaskMergePatch
+
+// PostUsersJSONRequestBody defines body for PostUsers for application/json ContentType.
+type PostUsersJSONRequestBody = UserRequest
+
+// PatchUsersIdJSONRequestBody defines body for PatchUsersId for application/json ContentType.
+type PatchUsersIdJSONRequestBody = UserUpdate
+
+// PatchUsersIdApplicationJSONPatchPlusJSONRequestBody defines body for PatchUsersId for application/json-patch+json ContentType.
+type PatchUsersIdApplicationJSONPatchPlusJSONRequestBody = JSONPatch
+
+// PatchUsersIdApplicationMergePatchPlusJSONRequestBody defines body for PatchUsersId for application/merge-patch+json ContentType.
+type PatchUsersIdApplicationMergePatchPlusJSONRequestBody = UserMergePatch
@@ -0,0 +1,592 @@
+// Package auth provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	. "POSTnGETtrain/internal/web/api"
+
+	"github.com/labstack/echo/v4"
+	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
+)
+
+// ServerInterface represents all server handlers.
+type ServerInterface interface {
+	// Send a password reset email
+	// (POST /auth/password/forgot)
+	PostAuthPasswordForgot(ctx echo.Context) error
+	// Set a new password with the token from the password reset email
+	// (POST /auth/password/reset)
+	PostAuthPasswordReset(ctx echo.Context) error
+	// Enable two-factor authentication with the first code
+	// (POST /auth/totp/confirm)
+	PostAuthTotpConfirm(ctx echo.Context) error
+	// Start two-factor authentication enrollment
+	// (POST /auth/totp/enroll)
+	PostAuthTotpEnroll(ctx echo.Context) error
+	// Verify email and password, then a TOTP code for enrolled users
+	// (POST /auth/verify-credentials)
+	PostAuthVerifyCredentials(ctx echo.Context) error
+	// Verify email with the token from the verification email
+	// (POST /auth/verify-email)
+	PostAuthVerifyEmail(ctx echo.Context) error
+	// Send the verification email again
+	// (POST /auth/verify-email/resend)
+	PostAuthVerifyEmailResend(ctx echo.Context) error
+}
+
+// ServerInterfaceWrapper converts echo contexts to parameters.
+type ServerInterfaceWrapper struct {
+	Handler ServerInterface
+}
+
+// PostAuthPasswordForgot converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAuthPasswordForgot(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostAuthPasswordForgot(ctx)
+	return err
+}
+
+// PostAuthPasswordReset converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAuthPasswordReset(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostAuthPasswordReset(ctx)
+	return err
+}
+
+// PostAuthTotpConfirm converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAuthTotpConfirm(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostAuthTotpConfirm(ctx)
+	return err
+}
+
+// PostAuthTotpEnroll converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAuthTotpEnroll(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostAuthTotpEnroll(ctx)
+	return err
+}
+
+// PostAuthVerifyCredentials converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAuthVerifyCredentials(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostAuthVerifyCredentials(ctx)
+	return err
+}
+
+// PostAuthVerifyEmail converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAuthVerifyEmail(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostAuthVerifyEmail(ctx)
+	return err
+}
+
+// PostAuthVerifyEmailResend converts echo context to params.
+func (w *ServerInterfaceWrapper) PostAuthVerifyEmailResend(ctx echo.Context) error {
+	var err error
+
+	// Invoke the callback with all the unmarshaled arguments
+	err = w.Handler.PostAuthVerifyEmailResend(ctx)
+	return err
+}
+
+// This is a simple interface which specifies echo.Route addition functions which
+// are present on both echo.Echo and echo.Group, since we want to allow using
+// either of them for path registration
+type EchoRouter interface {
+	CONNECT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	HEAD(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	OPTIONS(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+	TRACE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route
+}
+
+// RegisterHandlers adds each server route to the EchoRouter.
+func RegisterHandlers(router EchoRouter, si ServerInterface) {
+	RegisterHandlersWithBaseURL(router, si, "")
+}
+
+// Registers handlers, and prepends BaseURL to the paths, so that the paths
+// can be served under a prefix.
+func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL string) {
+
+	wrapper := ServerInterfaceWrapper{
+		Handler: si,
+	}
+
+	router.POST(baseURL+"/auth/password/forgot", wrapper.PostAuthPasswordForgot)
+	router.POST(baseURL+"/auth/password/reset", wrapper.PostAuthPasswordReset)
+	router.POST(baseURL+"/auth/totp/confirm", wrapper.PostAuthTotpConfirm)
+	router.POST(baseURL+"/auth/totp/enroll", wrapper.PostAuthTotpEnroll)
+	router.POST(baseURL+"/auth/verify-credentials", wrapper.PostAuthVerifyCredentials)
+	router.POST(baseURL+"/auth/verify-email", wrapper.PostAuthVerifyEmail)
+	router.POST(baseURL+"/auth/verify-email/resend", wrapper.PostAuthVerifyEmailResend)
+
+}
+
+type PostAuthPasswordForgotRequestObject struct {
+	Body *PostAuthPasswordForgotJSONRequestBody
+}
+
+type PostAuthPasswordForgotResponseObject interface {
+	VisitPostAuthPasswordForgotResponse(w http.ResponseWriter) error
+}
+
+type PostAuthPasswordForgot202Response struct {
+}
+
+func (response PostAuthPasswordForgot202Response) VisitPostAuthPasswordForgotResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+type PostAuthPasswordResetRequestObject struct {
+	Body *PostAuthPasswordResetJSONRequestBody
+}
+
+type PostAuthPasswordResetResponseObject interface {
+	VisitPostAuthPasswordResetResponse(w http.ResponseWriter) error
+}
+
+type PostAuthPasswordReset204Response struct {
+}
+
+func (response PostAuthPasswordReset204Response) VisitPostAuthPasswordResetResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PostAuthPasswordReset403JSONResponse Error
+
+func (response PostAuthPasswordReset403JSONResponse) VisitPostAuthPasswordResetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthPasswordReset422JSONResponse Error
+
+func (response PostAuthPasswordReset422JSONResponse) VisitPostAuthPasswordResetResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthTotpConfirmRequestObject struct {
+	Body *PostAuthTotpConfirmJSONRequestBody
+}
+
+type PostAuthTotpConfirmResponseObject interface {
+	VisitPostAuthTotpConfirmResponse(w http.ResponseWriter) error
+}
+
+type PostAuthTotpConfirm200JSONResponse RecoveryCodes
+
+func (response PostAuthTotpConfirm200JSONResponse) VisitPostAuthTotpConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthTotpConfirm401JSONResponse Error
+
+func (response PostAuthTotpConfirm401JSONResponse) VisitPostAuthTotpConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthTotpConfirm409JSONResponse Error
+
+func (response PostAuthTotpConfirm409JSONResponse) VisitPostAuthTotpConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthTotpConfirm422JSONResponse Error
+
+func (response PostAuthTotpConfirm422JSONResponse) VisitPostAuthTotpConfirmResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthTotpEnrollRequestObject struct {
+	Body *PostAuthTotpEnrollJSONRequestBody
+}
+
+type PostAuthTotpEnrollResponseObject interface {
+	VisitPostAuthTotpEnrollResponse(w http.ResponseWriter) error
+}
+
+type PostAuthTotpEnroll200JSONResponse TOTPEnrollment
+
+func (response PostAuthTotpEnroll200JSONResponse) VisitPostAuthTotpEnrollResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthTotpEnroll401JSONResponse Error
+
+func (response PostAuthTotpEnroll401JSONResponse) VisitPostAuthTotpEnrollResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthTotpEnroll409JSONResponse Error
+
+func (response PostAuthTotpEnroll409JSONResponse) VisitPostAuthTotpEnrollResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(409)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthVerifyCredentialsRequestObject struct {
+	Body *PostAuthVerifyCredentialsJSONRequestBody
+}
+
+type PostAuthVerifyCredentialsResponseObject interface {
+	VisitPostAuthVerifyCredentialsResponse(w http.ResponseWriter) error
+}
+
+type PostAuthVerifyCredentials200JSONResponse VerifyCredentialsResponse
+
+func (response PostAuthVerifyCredentials200JSONResponse) VisitPostAuthVerifyCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(200)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthVerifyCredentials401JSONResponse Error
+
+func (response PostAuthVerifyCredentials401JSONResponse) VisitPostAuthVerifyCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(401)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthVerifyCredentials422JSONResponse Error
+
+func (response PostAuthVerifyCredentials422JSONResponse) VisitPostAuthVerifyCredentialsResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthVerifyEmailRequestObject struct {
+	Body *PostAuthVerifyEmailJSONRequestBody
+}
+
+type PostAuthVerifyEmailResponseObject interface {
+	VisitPostAuthVerifyEmailResponse(w http.ResponseWriter) error
+}
+
+type PostAuthVerifyEmail204Response struct {
+}
+
+func (response PostAuthVerifyEmail204Response) VisitPostAuthVerifyEmailResponse(w http.ResponseWriter) error {
+	w.WriteHeader(204)
+	return nil
+}
+
+type PostAuthVerifyEmail422JSONResponse Error
+
+func (response PostAuthVerifyEmail422JSONResponse) VisitPostAuthVerifyEmailResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PostAuthVerifyEmailResendRequestObject struct {
+	Body *PostAuthVerifyEmailResendJSONRequestBody
+}
+
+type PostAuthVerifyEmailResendResponseObject interface {
+	VisitPostAuthVerifyEmailResendResponse(w http.ResponseWriter) error
+}
+
+type PostAuthVerifyEmailResend202Response struct {
+}
+
+func (response PostAuthVerifyEmailResend202Response) VisitPostAuthVerifyEmailResendResponse(w http.ResponseWriter) error {
+	w.WriteHeader(202)
+	return nil
+}
+
+// StrictServerInterface represents all server handlers.
+type StrictServerInterface interface {
+	// Send a password reset email
+	// (POST /auth/password/forgot)
+	PostAuthPasswordForgot(ctx context.Context, request PostAuthPasswordForgotRequestObject) (PostAuthPasswordForgotResponseObject, error)
+	// Set a new password with the token from the password reset email
+	// (POST /auth/password/reset)
+	PostAuthPasswordReset(ctx context.Context, request PostAuthPasswordResetRequestObject) (PostAuthPasswordResetResponseObject, error)
+	// Enable two-factor authentication with the first code
+	// (POST /auth/totp/confirm)
+	PostAuthTotpConfirm(ctx context.Context, request PostAuthTotpConfirmRequestObject) (PostAuthTotpConfirmResponseObject, error)
+	// Start two-factor authentication enrollment
+	// (POST /auth/totp/enroll)
+	PostAuthTotpEnroll(ctx context.Context, request PostAuthTotpEnrollRequestObject) (PostAuthTotpEnrollResponseObject, error)
+	// Verify email and password, then a TOTP code for enrolled users
+	// (POST /auth/verify-credentials)
+	PostAuthVerifyCredentials(ctx context.Context, request PostAuthVerifyCredentialsRequestObject) (PostAuthVerifyCredentialsResponseObject, error)
+	// Verify email with the token from the verification email
+	// (POST /auth/verify-email)
+	PostAuthVerifyEmail(ctx context.Context, request PostAuthVerifyEmailRequestObject) (PostAuthVerifyEmailResponseObject, error)
+	// Send the verification email again
+	// (POST /auth/verify-email/resend)
+	PostAuthVerifyEmailResend(ctx context.Context, request PostAuthVerifyEmailResendRequestObject) (PostAuthVerifyEmailResendResponseObject, error)
+}
+
+type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
+type StrictMiddlewareFunc = strictecho.StrictEchoMiddlewareFunc
+
+func NewStrictHandler(ssi StrictServerInterface, middlewares []StrictMiddlewareFunc) ServerInterface {
+	return &strictHandler{ssi: ssi, middlewares: middlewares}
+}
+
+type strictHandler struct {
+	ssi         StrictServerInterface
+	middlewares []StrictMiddlewareFunc
+}
+
+// PostAuthPasswordForgot operation middleware
+func (sh *strictHandler) PostAuthPasswordForgot(ctx echo.Context) error {
+	var request PostAuthPasswordForgotRequestObject
+
+	var body PostAuthPasswordForgotJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthPasswordForgot(ctx.Request().Context(), request.(PostAuthPasswordForgotRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthPasswordForgot")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostAuthPasswordForgotResponseObject); ok {
+		return validResponse.VisitPostAuthPasswordForgotResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostAuthPasswordReset operation middleware
+func (sh *strictHandler) PostAuthPasswordReset(ctx echo.Context) error {
+	var request PostAuthPasswordResetRequestObject
+
+	var body PostAuthPasswordResetJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthPasswordReset(ctx.Request().Context(), request.(PostAuthPasswordResetRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthPasswordReset")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostAuthPasswordResetResponseObject); ok {
+		return validResponse.VisitPostAuthPasswordResetResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostAuthTotpConfirm operation middleware
+func (sh *strictHandler) PostAuthTotpConfirm(ctx echo.Context) error {
+	var request PostAuthTotpConfirmRequestObject
+
+	var body PostAuthTotpConfirmJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthTotpConfirm(ctx.Request().Context(), request.(PostAuthTotpConfirmRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthTotpConfirm")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostAuthTotpConfirmResponseObject); ok {
+		return validResponse.VisitPostAuthTotpConfirmResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostAuthTotpEnroll operation middleware
+func (sh *strictHandler) PostAuthTotpEnroll(ctx echo.Context) error {
+	var request PostAuthTotpEnrollRequestObject
+
+	var body PostAuthTotpEnrollJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthTotpEnroll(ctx.Request().Context(), request.(PostAuthTotpEnrollRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthTotpEnroll")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostAuthTotpEnrollResponseObject); ok {
+		return validResponse.VisitPostAuthTotpEnrollResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostAuthVerifyCredentials operation middleware
+func (sh *strictHandler) PostAuthVerifyCredentials(ctx echo.Context) error {
+	var request PostAuthVerifyCredentialsRequestObject
+
+	var body PostAuthVerifyCredentialsJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthVerifyCredentials(ctx.Request().Context(), request.(PostAuthVerifyCredentialsRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthVerifyCredentials")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostAuthVerifyCredentialsResponseObject); ok {
+		return validResponse.VisitPostAuthVerifyCredentialsResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostAuthVerifyEmail operation middleware
+func (sh *strictHandler) PostAuthVerifyEmail(ctx echo.Context) error {
+	var request PostAuthVerifyEmailRequestObject
+
+	var body PostAuthVerifyEmailJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthVerifyEmail(ctx.Request().Context(), request.(PostAuthVerifyEmailRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthVerifyEmail")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostAuthVerifyEmailResponseObject); ok {
+		return validResponse.VisitPostAuthVerifyEmailResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
+
+// PostAuthVerifyEmailResend operation middleware
+func (sh *strictHandler) PostAuthVerifyEmailResend(ctx echo.Context) error {
+	var request PostAuthVerifyEmailResendRequestObject
+
+	var body PostAuthVerifyEmailResendJSONRequestBody
+	if err := ctx.Bind(&body); err != nil {
+		return err
+	}
+	request.Body = &body
+
+	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
+		return sh.ssi.PostAuthVerifyEmailResend(ctx.Request().Context(), request.(PostAuthVerifyEmailResendRequestObject))
+	}
+	for _, middleware := range sh.middlewares {
+		handler = middleware(handler, "PostAuthVerifyEmailResend")
+	}
+
+	response, err := handler(ctx, request)
+
+	if err != nil {
+		return err
+	} else if validResponse, ok := response.(PostAuthVerifyEmailResendResponseObject); ok {
+		return validResponse.VisitPostAuthVerifyEmailResendResponse(ctx.Response())
+	} else if response != nil {
+		return fmt.Errorf("unexpected response type: %T", response)
+	}
+	return nil
+}
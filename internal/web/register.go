@@ -0,0 +1,21 @@
+// Package web собирает сгенерированные серверы всех тегов OpenAPI в один роутер.
+// Общие схемы лежат в пакете api, серверные интерфейсы - в пакетах тегов (tasks, users, auth)
+package web
+
+import (
+	"POSTnGETtrain/internal/web/auth"
+	"POSTnGETtrain/internal/web/tasks"
+	"POSTnGETtrain/internal/web/users"
+)
+
+// EchoRouter Роутер, в который регистрируются операции (одинаков для всех тегов)
+type EchoRouter = tasks.EchoRouter
+
+// RegisterHandlers Регистрирует операции всех тегов. У каждой операции спецификации
+// ровно один тег, поэтому пакеты тегов не регистрируют одинаковые маршруты
+func RegisterHandlers(router EchoRouter, taskServer tasks.ServerInterface, userServer users.ServerInterface,
+	authServer auth.ServerInterface) {
+	tasks.RegisterHandlers(router, taskServer)
+	users.RegisterHandlers(router, userServer)
+	auth.RegisterHandlers(router, authServer)
+}
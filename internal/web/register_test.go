@@ -0,0 +1,75 @@
+package web
+
+import (
+	"POSTnGETtrain/internal/web/auth"
+	"POSTnGETtrain/internal/web/tasks"
+	"POSTnGETtrain/internal/web/users"
+	"POSTnGETtrain/openapi"
+	"regexp"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countingRouter Считает, сколько раз регистрируется каждый маршрут
+type countingRouter struct {
+	*echo.Echo
+	registered map[string]int
+}
+
+func (r *countingRouter) add(method, path string) {
+	r.registered[method+" "+path]++
+}
+
+func (r *countingRouter) DELETE(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	r.add("DELETE", path)
+	return r.Echo.DELETE(path, h, m...)
+}
+
+func (r *countingRouter) GET(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	r.add("GET", path)
+	return r.Echo.GET(path, h, m...)
+}
+
+func (r *countingRouter) PATCH(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	r.add("PATCH", path)
+	return r.Echo.PATCH(path, h, m...)
+}
+
+func (r *countingRouter) POST(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	r.add("POST", path)
+	return r.Echo.POST(path, h, m...)
+}
+
+func (r *countingRouter) PUT(path string, h echo.HandlerFunc, m ...echo.MiddlewareFunc) *echo.Route {
+	r.add("PUT", path)
+	return r.Echo.PUT(path, h, m...)
+}
+
+// pathParam Переводит шаблон OpenAPI (/tasks/{id}) в шаблон Echo (/tasks/:id)
+var pathParam = regexp.MustCompile(`\{([^}]+)\}`)
+
+func TestEveryOperationRegisteredOnce(t *testing.T) {
+	spec, err := openapi.Load()
+	require.NoError(t, err)
+
+	router := &countingRouter{Echo: echo.New(), registered: map[string]int{}}
+	RegisterHandlers(router, tasks.NewStrictHandler(nil, nil), users.NewStrictHandler(nil, nil), auth.NewStrictHandler(nil, nil))
+
+	expected := map[string]bool{}
+	for path, item := range spec.Paths.Map() {
+		for method := range item.Operations() {
+			route := method + " " + pathParam.ReplaceAllString(path, ":$1")
+			expected[route] = true
+			assert.Equal(t, 1, router.registered[route], "operation %s must be registered exactly once", route)
+		}
+	}
+
+	// Лишних маршрутов, которых нет в спецификации, тоже быть не должно
+	for route := range router.registered {
+		assert.True(t, expected[route], "route %s is not described in openapi.yaml", route)
+	}
+	assert.Len(t, router.Routes(), len(expected))
+}
@@ -1,6 +1,6 @@
 // Package tasks provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/deepmap/oapi-codegen version v1.16.3 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
 package tasks
 
 import (
@@ -8,60 +8,32 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+
+	. "POSTnGETtrain/internal/web/api"
 
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/runtime"
 	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
 )
 
-// Task defines model for Task.
-type Task struct {
-	ID     string `json:"id"`
-	IsDone bool   `json:"is_done"`
-	Name   string `json:"name"`
-	UserID string `json:"user_id"`
-}
-
-// TaskRequest defines model for TaskRequest.
-type TaskRequest struct {
-	IsDone *bool  `json:"is_done,omitempty"`
-	Name   string `json:"name"`
-	UserID string `json:"user_id"`
-}
-
-// TaskUpdate defines model for TaskUpdate.
-type TaskUpdate struct {
-	IsDone *bool   `json:"is_done,omitempty"`
-	Name   *string `json:"name,omitempty"`
-	UserID *string `json:"user_id,omitempty"`
-}
-
-// PostTasksJSONRequestBody defines body for PostTasks for application/json ContentType.
-type PostTasksJSONRequestBody = TaskRequest
-
-// PatchTasksIdJSONRequestBody defines body for PatchTasksId for application/json ContentType.
-type PatchTasksIdJSONRequestBody = TaskUpdate
-
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Get all tasks
 	// (GET /tasks)
-	GetTasks(ctx echo.Context) error
+	GetTasks(ctx echo.Context, params GetTasksParams) error
 	// Create a new task
 	// (POST /tasks)
-	PostTasks(ctx echo.Context) error
+	PostTasks(ctx echo.Context, params PostTasksParams) error
 	// Delete task
 	// (DELETE /tasks/{id})
-	DeleteTasksId(ctx echo.Context, id string) error
+	DeleteTasksId(ctx echo.Context, id ID, params DeleteTasksIdParams) error
 	// Get task by ID
 	// (GET /tasks/{id})
-	GetTasksId(ctx echo.Context, id string) error
+	GetTasksId(ctx echo.Context, id ID, params GetTasksIdParams) error
 	// Update task
 	// (PATCH /tasks/{id})
-	PatchTasksId(ctx echo.Context, id string) error
-	// Get all tasks for individual user
-	// (GET /users/{id}/tasks)
-	GetUsersIdTasks(ctx echo.Context, id string) error
+	PatchTasksId(ctx echo.Context, id ID, params PatchTasksIdParams) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -73,8 +45,24 @@ type ServerInterfaceWrapper struct {
 func (w *ServerInterfaceWrapper) GetTasks(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTasksParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetTasks(ctx)
+	err = w.Handler.GetTasks(ctx, params)
 	return err
 }
 
@@ -82,8 +70,28 @@ func (w *ServerInterfaceWrapper) GetTasks(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) PostTasks(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostTasksParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Idempotency-Key, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Idempotency-Key: %s", err))
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostTasks(ctx)
+	err = w.Handler.PostTasks(ctx, params)
 	return err
 }
 
@@ -91,15 +99,35 @@ func (w *ServerInterfaceWrapper) PostTasks(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) DeleteTasksId(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id ID
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteTasksIdParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.DeleteTasksId(ctx, id)
+	err = w.Handler.DeleteTasksId(ctx, id, params)
 	return err
 }
 
@@ -107,15 +135,35 @@ func (w *ServerInterfaceWrapper) DeleteTasksId(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) GetTasksId(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id ID
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetTasksIdParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-None-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-None-Match: %s", err))
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetTasksId(ctx, id)
+	err = w.Handler.GetTasksId(ctx, id, params)
 	return err
 }
 
@@ -123,31 +171,35 @@ func (w *ServerInterfaceWrapper) GetTasksId(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) PatchTasksId(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id ID
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
-	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PatchTasksId(ctx, id)
-	return err
-}
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchTasksIdParams
 
-// GetUsersIdTasks converts echo context to params.
-func (w *ServerInterfaceWrapper) GetUsersIdTasks(ctx echo.Context) error {
-	var err error
-	// ------------- Path parameter "id" -------------
-	var id string
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
-	if err != nil {
-		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
 	}
 
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetUsersIdTasks(ctx, id)
+	err = w.Handler.PatchTasksId(ctx, id, params)
 	return err
 }
 
@@ -184,11 +236,11 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 	router.DELETE(baseURL+"/tasks/:id", wrapper.DeleteTasksId)
 	router.GET(baseURL+"/tasks/:id", wrapper.GetTasksId)
 	router.PATCH(baseURL+"/tasks/:id", wrapper.PatchTasksId)
-	router.GET(baseURL+"/users/:id/tasks", wrapper.GetUsersIdTasks)
 
 }
 
 type GetTasksRequestObject struct {
+	Params GetTasksParams
 }
 
 type GetTasksResponseObject interface {
@@ -205,7 +257,8 @@ func (response GetTasks200JSONResponse) VisitGetTasksResponse(w http.ResponseWri
 }
 
 type PostTasksRequestObject struct {
-	Body *PostTasksJSONRequestBody
+	Params PostTasksParams
+	Body   *PostTasksJSONRequestBody
 }
 
 type PostTasksResponseObject interface {
@@ -221,8 +274,18 @@ func (response PostTasks201JSONResponse) VisitPostTasksResponse(w http.ResponseW
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PostTasks403JSONResponse Error
+
+func (response PostTasks403JSONResponse) VisitPostTasksResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteTasksIdRequestObject struct {
-	Id string `json:"id"`
+	Id     ID `json:"id"`
+	Params DeleteTasksIdParams
 }
 
 type DeleteTasksIdResponseObject interface {
@@ -237,6 +300,15 @@ func (response DeleteTasksId204Response) VisitDeleteTasksIdResponse(w http.Respo
 	return nil
 }
 
+type DeleteTasksId403JSONResponse Error
+
+func (response DeleteTasksId403JSONResponse) VisitDeleteTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteTasksId404Response struct {
 }
 
@@ -245,21 +317,60 @@ func (response DeleteTasksId404Response) VisitDeleteTasksIdResponse(w http.Respo
 	return nil
 }
 
+type DeleteTasksId412Response struct {
+}
+
+func (response DeleteTasksId412Response) VisitDeleteTasksIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(412)
+	return nil
+}
+
+type DeleteTasksId428Response struct {
+}
+
+func (response DeleteTasksId428Response) VisitDeleteTasksIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(428)
+	return nil
+}
+
 type GetTasksIdRequestObject struct {
-	Id string `json:"id"`
+	Id     ID `json:"id"`
+	Params GetTasksIdParams
 }
 
 type GetTasksIdResponseObject interface {
 	VisitGetTasksIdResponse(w http.ResponseWriter) error
 }
 
-type GetTasksId200JSONResponse Task
+type GetTasksId200ResponseHeaders struct {
+	ETag string
+}
+
+type GetTasksId200JSONResponse struct {
+	Body    Task
+	Headers GetTasksId200ResponseHeaders
+}
 
 func (response GetTasksId200JSONResponse) VisitGetTasksIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetTasksId304ResponseHeaders struct {
+	ETag string
+}
+
+type GetTasksId304Response struct {
+	Headers GetTasksId304ResponseHeaders
+}
+
+func (response GetTasksId304Response) VisitGetTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
 }
 
 type GetTasksId404Response struct {
@@ -271,20 +382,40 @@ func (response GetTasksId404Response) VisitGetTasksIdResponse(w http.ResponseWri
 }
 
 type PatchTasksIdRequestObject struct {
-	Id   string `json:"id"`
-	Body *PatchTasksIdJSONRequestBody
+	Id                                ID `json:"id"`
+	Params                            PatchTasksIdParams
+	JSONBody                          *PatchTasksIdJSONRequestBody
+	ApplicationJSONPatchPlusJSONBody  *PatchTasksIdApplicationJSONPatchPlusJSONRequestBody
+	ApplicationMergePatchPlusJSONBody *PatchTasksIdApplicationMergePatchPlusJSONRequestBody
 }
 
 type PatchTasksIdResponseObject interface {
 	VisitPatchTasksIdResponse(w http.ResponseWriter) error
 }
 
-type PatchTasksId200JSONResponse Task
+type PatchTasksId200ResponseHeaders struct {
+	ETag string
+}
+
+type PatchTasksId200JSONResponse struct {
+	Body    Task
+	Headers PatchTasksId200ResponseHeaders
+}
 
 func (response PatchTasksId200JSONResponse) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(200)
 
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PatchTasksId403JSONResponse Error
+
+func (response PatchTasksId403JSONResponse) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
 	return json.NewEncoder(w).Encode(response)
 }
 
@@ -296,28 +427,36 @@ func (response PatchTasksId404Response) VisitPatchTasksIdResponse(w http.Respons
 	return nil
 }
 
-type GetUsersIdTasksRequestObject struct {
-	Id string `json:"id"`
+type PatchTasksId412Response struct {
+}
+
+func (response PatchTasksId412Response) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(412)
+	return nil
 }
 
-type GetUsersIdTasksResponseObject interface {
-	VisitGetUsersIdTasksResponse(w http.ResponseWriter) error
+type PatchTasksId415Response struct {
+}
+
+func (response PatchTasksId415Response) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(415)
+	return nil
 }
 
-type GetUsersIdTasks200JSONResponse []Task
+type PatchTasksId422JSONResponse Error
 
-func (response GetUsersIdTasks200JSONResponse) VisitGetUsersIdTasksResponse(w http.ResponseWriter) error {
+func (response PatchTasksId422JSONResponse) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
-	w.WriteHeader(200)
+	w.WriteHeader(422)
 
 	return json.NewEncoder(w).Encode(response)
 }
 
-type GetUsersIdTasks404Response struct {
+type PatchTasksId428Response struct {
 }
 
-func (response GetUsersIdTasks404Response) VisitGetUsersIdTasksResponse(w http.ResponseWriter) error {
-	w.WriteHeader(404)
+func (response PatchTasksId428Response) VisitPatchTasksIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(428)
 	return nil
 }
 
@@ -338,9 +477,6 @@ type StrictServerInterface interface {
 	// Update task
 	// (PATCH /tasks/{id})
 	PatchTasksId(ctx context.Context, request PatchTasksIdRequestObject) (PatchTasksIdResponseObject, error)
-	// Get all tasks for individual user
-	// (GET /users/{id}/tasks)
-	GetUsersIdTasks(ctx context.Context, request GetUsersIdTasksRequestObject) (GetUsersIdTasksResponseObject, error)
 }
 
 type StrictHandlerFunc = strictecho.StrictEchoHandlerFunc
@@ -356,9 +492,11 @@ type strictHandler struct {
 }
 
 // GetTasks operation middleware
-func (sh *strictHandler) GetTasks(ctx echo.Context) error {
+func (sh *strictHandler) GetTasks(ctx echo.Context, params GetTasksParams) error {
 	var request GetTasksRequestObject
 
+	request.Params = params
+
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.GetTasks(ctx.Request().Context(), request.(GetTasksRequestObject))
 	}
@@ -379,9 +517,11 @@ func (sh *strictHandler) GetTasks(ctx echo.Context) error {
 }
 
 // PostTasks operation middleware
-func (sh *strictHandler) PostTasks(ctx echo.Context) error {
+func (sh *strictHandler) PostTasks(ctx echo.Context, params PostTasksParams) error {
 	var request PostTasksRequestObject
 
+	request.Params = params
+
 	var body PostTasksJSONRequestBody
 	if err := ctx.Bind(&body); err != nil {
 		return err
@@ -408,10 +548,11 @@ func (sh *strictHandler) PostTasks(ctx echo.Context) error {
 }
 
 // DeleteTasksId operation middleware
-func (sh *strictHandler) DeleteTasksId(ctx echo.Context, id string) error {
+func (sh *strictHandler) DeleteTasksId(ctx echo.Context, id ID, params DeleteTasksIdParams) error {
 	var request DeleteTasksIdRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.DeleteTasksId(ctx.Request().Context(), request.(DeleteTasksIdRequestObject))
@@ -433,10 +574,11 @@ func (sh *strictHandler) DeleteTasksId(ctx echo.Context, id string) error {
 }
 
 // GetTasksId operation middleware
-func (sh *strictHandler) GetTasksId(ctx echo.Context, id string) error {
+func (sh *strictHandler) GetTasksId(ctx echo.Context, id ID, params GetTasksIdParams) error {
 	var request GetTasksIdRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.GetTasksId(ctx.Request().Context(), request.(GetTasksIdRequestObject))
@@ -458,16 +600,32 @@ func (sh *strictHandler) GetTasksId(ctx echo.Context, id string) error {
 }
 
 // PatchTasksId operation middleware
-func (sh *strictHandler) PatchTasksId(ctx echo.Context, id string) error {
+func (sh *strictHandler) PatchTasksId(ctx echo.Context, id ID, params PatchTasksIdParams) error {
 	var request PatchTasksIdRequestObject
 
 	request.Id = id
-
-	var body PatchTasksIdJSONRequestBody
-	if err := ctx.Bind(&body); err != nil {
-		return err
+	request.Params = params
+	if strings.HasPrefix(ctx.Request().Header.Get("Content-Type"), "application/json") {
+		var body PatchTasksIdJSONRequestBody
+		if err := ctx.Bind(&body); err != nil {
+			return err
+		}
+		request.JSONBody = &body
+	}
+	if strings.HasPrefix(ctx.Request().Header.Get("Content-Type"), "application/json-patch+json") {
+		var body PatchTasksIdApplicationJSONPatchPlusJSONRequestBody
+		if err := ctx.Bind(&body); err != nil {
+			return err
+		}
+		request.ApplicationJSONPatchPlusJSONBody = &body
+	}
+	if strings.HasPrefix(ctx.Request().Header.Get("Content-Type"), "application/merge-patch+json") {
+		var body PatchTasksIdApplicationMergePatchPlusJSONRequestBody
+		if err := ctx.Bind(&body); err != nil {
+			return err
+		}
+		request.ApplicationMergePatchPlusJSONBody = &body
 	}
-	request.Body = &body
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.PatchTasksId(ctx.Request().Context(), request.(PatchTasksIdRequestObject))
@@ -487,28 +645,3 @@ func (sh *strictHandler) PatchTasksId(ctx echo.Context, id string) error {
 	}
 	return nil
 }
-
-// GetUsersIdTasks operation middleware
-func (sh *strictHandler) GetUsersIdTasks(ctx echo.Context, id string) error {
-	var request GetUsersIdTasksRequestObject
-
-	request.Id = id
-
-	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
-		return sh.ssi.GetUsersIdTasks(ctx.Request().Context(), request.(GetUsersIdTasksRequestObject))
-	}
-	for _, middleware := range sh.middlewares {
-		handler = middleware(handler, "GetUsersIdTasks")
-	}
-
-	response, err := handler(ctx, request)
-
-	if err != nil {
-		return err
-	} else if validResponse, ok := response.(GetUsersIdTasksResponseObject); ok {
-		return validResponse.VisitGetUsersIdTasksResponse(ctx.Response())
-	} else if response != nil {
-		return fmt.Errorf("unexpected response type: %T", response)
-	}
-	return nil
-}
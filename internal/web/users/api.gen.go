@@ -1,6 +1,6 @@
 // Package users provides primitives to interact with the openapi HTTP API.
 //
-// Code generated by github.com/deepmap/oapi-codegen version v1.16.3 DO NOT EDIT.
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
 package users
 
 import (
@@ -8,65 +8,35 @@ import (
 	"encoding/json"
 	"fmt"
 	"net/http"
+	"strings"
+
+	. "POSTnGETtrain/internal/web/api"
 
 	"github.com/labstack/echo/v4"
 	"github.com/oapi-codegen/runtime"
 	strictecho "github.com/oapi-codegen/runtime/strictmiddleware/echo"
 )
 
-// Task defines model for Task.
-type Task struct {
-	ID     string `json:"id"`
-	IsDone bool   `json:"is_done"`
-	Name   string `json:"name"`
-	UserID string `json:"user_id"`
-}
-
-// User defines model for User.
-type User struct {
-	Email    string `json:"email"`
-	ID       string `json:"id"`
-	Password string `json:"password"`
-}
-
-// UserRequest defines model for UserRequest.
-type UserRequest struct {
-	Email    string `json:"email"`
-	Password string `json:"password"`
-}
-
-// UserUpdate defines model for UserUpdate.
-type UserUpdate struct {
-	Email    *string `json:"email,omitempty"`
-	Password *string `json:"password,omitempty"`
-}
-
-// PostUsersJSONRequestBody defines body for PostUsers for application/json ContentType.
-type PostUsersJSONRequestBody = UserRequest
-
-// PatchUsersIdJSONRequestBody defines body for PatchUsersId for application/json ContentType.
-type PatchUsersIdJSONRequestBody = UserUpdate
-
 // ServerInterface represents all server handlers.
 type ServerInterface interface {
 	// Get all users
 	// (GET /users)
-	GetUsers(ctx echo.Context) error
+	GetUsers(ctx echo.Context, params GetUsersParams) error
 	// Create a new user
 	// (POST /users)
-	PostUsers(ctx echo.Context) error
+	PostUsers(ctx echo.Context, params PostUsersParams) error
 	// Delete user by ID
 	// (DELETE /users/{id})
-	DeleteUsersId(ctx echo.Context, id string) error
+	DeleteUsersId(ctx echo.Context, id ID, params DeleteUsersIdParams) error
 	// Get user by ID
 	// (GET /users/{id})
-	GetUsersId(ctx echo.Context, id string) error
+	GetUsersId(ctx echo.Context, id ID, params GetUsersIdParams) error
 	// Update user by ID
 	// (PATCH /users/{id})
-	PatchUsersId(ctx echo.Context, id string) error
+	PatchUsersId(ctx echo.Context, id ID, params PatchUsersIdParams) error
 	// Get all tasks for individual user
 	// (GET /users/{id}/tasks)
-	GetUsersIdTasks(ctx echo.Context, id string) error
+	GetUsersIdTasks(ctx echo.Context, id ID) error
 }
 
 // ServerInterfaceWrapper converts echo contexts to parameters.
@@ -78,8 +48,24 @@ type ServerInterfaceWrapper struct {
 func (w *ServerInterfaceWrapper) GetUsers(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersParams
+	// ------------- Optional query parameter "limit" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "limit", ctx.QueryParams(), &params.Limit)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter limit: %s", err))
+	}
+
+	// ------------- Optional query parameter "offset" -------------
+
+	err = runtime.BindQueryParameter("form", true, false, "offset", ctx.QueryParams(), &params.Offset)
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter offset: %s", err))
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetUsers(ctx)
+	err = w.Handler.GetUsers(ctx, params)
 	return err
 }
 
@@ -87,8 +73,28 @@ func (w *ServerInterfaceWrapper) GetUsers(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) PostUsers(ctx echo.Context) error {
 	var err error
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PostUsersParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "Idempotency-Key" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("Idempotency-Key")]; found {
+		var IdempotencyKey IdempotencyKey
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for Idempotency-Key, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "Idempotency-Key", valueList[0], &IdempotencyKey, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter Idempotency-Key: %s", err))
+		}
+
+		params.IdempotencyKey = &IdempotencyKey
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PostUsers(ctx)
+	err = w.Handler.PostUsers(ctx, params)
 	return err
 }
 
@@ -96,15 +102,50 @@ func (w *ServerInterfaceWrapper) PostUsers(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) DeleteUsersId(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id ID
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params DeleteUsersIdParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+	// ------------- Optional header parameter "X-TOTP-Code" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-TOTP-Code")]; found {
+		var XTOTPCode TOTPCode
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-TOTP-Code, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-TOTP-Code", valueList[0], &XTOTPCode, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-TOTP-Code: %s", err))
+		}
+
+		params.XTOTPCode = &XTOTPCode
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.DeleteUsersId(ctx, id)
+	err = w.Handler.DeleteUsersId(ctx, id, params)
 	return err
 }
 
@@ -112,15 +153,35 @@ func (w *ServerInterfaceWrapper) DeleteUsersId(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) GetUsersId(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id ID
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params GetUsersIdParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-None-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-None-Match")]; found {
+		var IfNoneMatch IfNoneMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-None-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-None-Match", valueList[0], &IfNoneMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-None-Match: %s", err))
+		}
+
+		params.IfNoneMatch = &IfNoneMatch
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.GetUsersId(ctx, id)
+	err = w.Handler.GetUsersId(ctx, id, params)
 	return err
 }
 
@@ -128,15 +189,50 @@ func (w *ServerInterfaceWrapper) GetUsersId(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) PatchUsersId(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id ID
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
 
+	// Parameter object where we will unmarshal all parameters from the context
+	var params PatchUsersIdParams
+
+	headers := ctx.Request().Header
+	// ------------- Optional header parameter "If-Match" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("If-Match")]; found {
+		var IfMatch IfMatch
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for If-Match, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "If-Match", valueList[0], &IfMatch, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter If-Match: %s", err))
+		}
+
+		params.IfMatch = &IfMatch
+	}
+	// ------------- Optional header parameter "X-TOTP-Code" -------------
+	if valueList, found := headers[http.CanonicalHeaderKey("X-TOTP-Code")]; found {
+		var XTOTPCode TOTPCode
+		n := len(valueList)
+		if n != 1 {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Expected one value for X-TOTP-Code, got %d", n))
+		}
+
+		err = runtime.BindStyledParameterWithOptions("simple", "X-TOTP-Code", valueList[0], &XTOTPCode, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationHeader, Explode: false, Required: false})
+		if err != nil {
+			return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter X-TOTP-Code: %s", err))
+		}
+
+		params.XTOTPCode = &XTOTPCode
+	}
+
 	// Invoke the callback with all the unmarshaled arguments
-	err = w.Handler.PatchUsersId(ctx, id)
+	err = w.Handler.PatchUsersId(ctx, id, params)
 	return err
 }
 
@@ -144,9 +240,9 @@ func (w *ServerInterfaceWrapper) PatchUsersId(ctx echo.Context) error {
 func (w *ServerInterfaceWrapper) GetUsersIdTasks(ctx echo.Context) error {
 	var err error
 	// ------------- Path parameter "id" -------------
-	var id string
+	var id ID
 
-	err = runtime.BindStyledParameterWithLocation("simple", false, "id", runtime.ParamLocationPath, ctx.Param("id"), &id)
+	err = runtime.BindStyledParameterWithOptions("simple", "id", ctx.Param("id"), &id, runtime.BindStyledParameterOptions{ParamLocation: runtime.ParamLocationPath, Explode: false, Required: true})
 	if err != nil {
 		return echo.NewHTTPError(http.StatusBadRequest, fmt.Sprintf("Invalid format for parameter id: %s", err))
 	}
@@ -194,6 +290,7 @@ func RegisterHandlersWithBaseURL(router EchoRouter, si ServerInterface, baseURL
 }
 
 type GetUsersRequestObject struct {
+	Params GetUsersParams
 }
 
 type GetUsersResponseObject interface {
@@ -210,7 +307,8 @@ func (response GetUsers200JSONResponse) VisitGetUsersResponse(w http.ResponseWri
 }
 
 type PostUsersRequestObject struct {
-	Body *PostUsersJSONRequestBody
+	Params PostUsersParams
+	Body   *PostUsersJSONRequestBody
 }
 
 type PostUsersResponseObject interface {
@@ -226,8 +324,18 @@ func (response PostUsers201JSONResponse) VisitPostUsersResponse(w http.ResponseW
 	return json.NewEncoder(w).Encode(response)
 }
 
+type PostUsers422JSONResponse Error
+
+func (response PostUsers422JSONResponse) VisitPostUsersResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteUsersIdRequestObject struct {
-	Id string `json:"id"`
+	Id     ID `json:"id"`
+	Params DeleteUsersIdParams
 }
 
 type DeleteUsersIdResponseObject interface {
@@ -242,6 +350,15 @@ func (response DeleteUsersId204Response) VisitDeleteUsersIdResponse(w http.Respo
 	return nil
 }
 
+type DeleteUsersId403JSONResponse Error
+
+func (response DeleteUsersId403JSONResponse) VisitDeleteUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
 type DeleteUsersId404Response struct {
 }
 
@@ -250,21 +367,60 @@ func (response DeleteUsersId404Response) VisitDeleteUsersIdResponse(w http.Respo
 	return nil
 }
 
+type DeleteUsersId412Response struct {
+}
+
+func (response DeleteUsersId412Response) VisitDeleteUsersIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(412)
+	return nil
+}
+
+type DeleteUsersId428Response struct {
+}
+
+func (response DeleteUsersId428Response) VisitDeleteUsersIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(428)
+	return nil
+}
+
 type GetUsersIdRequestObject struct {
-	Id string `json:"id"`
+	Id     ID `json:"id"`
+	Params GetUsersIdParams
 }
 
 type GetUsersIdResponseObject interface {
 	VisitGetUsersIdResponse(w http.ResponseWriter) error
 }
 
-type GetUsersId200JSONResponse User
+type GetUsersId200ResponseHeaders struct {
+	ETag string
+}
+
+type GetUsersId200JSONResponse struct {
+	Body    User
+	Headers GetUsersId200ResponseHeaders
+}
 
 func (response GetUsersId200JSONResponse) VisitGetUsersIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(200)
 
-	return json.NewEncoder(w).Encode(response)
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type GetUsersId304ResponseHeaders struct {
+	ETag string
+}
+
+type GetUsersId304Response struct {
+	Headers GetUsersId304ResponseHeaders
+}
+
+func (response GetUsersId304Response) VisitGetUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
+	w.WriteHeader(304)
+	return nil
 }
 
 type GetUsersId404Response struct {
@@ -276,20 +432,40 @@ func (response GetUsersId404Response) VisitGetUsersIdResponse(w http.ResponseWri
 }
 
 type PatchUsersIdRequestObject struct {
-	Id   string `json:"id"`
-	Body *PatchUsersIdJSONRequestBody
+	Id                                ID `json:"id"`
+	Params                            PatchUsersIdParams
+	JSONBody                          *PatchUsersIdJSONRequestBody
+	ApplicationJSONPatchPlusJSONBody  *PatchUsersIdApplicationJSONPatchPlusJSONRequestBody
+	ApplicationMergePatchPlusJSONBody *PatchUsersIdApplicationMergePatchPlusJSONRequestBody
 }
 
 type PatchUsersIdResponseObject interface {
 	VisitPatchUsersIdResponse(w http.ResponseWriter) error
 }
 
-type PatchUsersId200JSONResponse User
+type PatchUsersId200ResponseHeaders struct {
+	ETag string
+}
+
+type PatchUsersId200JSONResponse struct {
+	Body    User
+	Headers PatchUsersId200ResponseHeaders
+}
 
 func (response PatchUsersId200JSONResponse) VisitPatchUsersIdResponse(w http.ResponseWriter) error {
 	w.Header().Set("Content-Type", "application/json")
+	w.Header().Set("ETag", fmt.Sprint(response.Headers.ETag))
 	w.WriteHeader(200)
 
+	return json.NewEncoder(w).Encode(response.Body)
+}
+
+type PatchUsersId403JSONResponse Error
+
+func (response PatchUsersId403JSONResponse) VisitPatchUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(403)
+
 	return json.NewEncoder(w).Encode(response)
 }
 
@@ -301,8 +477,41 @@ func (response PatchUsersId404Response) VisitPatchUsersIdResponse(w http.Respons
 	return nil
 }
 
+type PatchUsersId412Response struct {
+}
+
+func (response PatchUsersId412Response) VisitPatchUsersIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(412)
+	return nil
+}
+
+type PatchUsersId415Response struct {
+}
+
+func (response PatchUsersId415Response) VisitPatchUsersIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(415)
+	return nil
+}
+
+type PatchUsersId422JSONResponse Error
+
+func (response PatchUsersId422JSONResponse) VisitPatchUsersIdResponse(w http.ResponseWriter) error {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(422)
+
+	return json.NewEncoder(w).Encode(response)
+}
+
+type PatchUsersId428Response struct {
+}
+
+func (response PatchUsersId428Response) VisitPatchUsersIdResponse(w http.ResponseWriter) error {
+	w.WriteHeader(428)
+	return nil
+}
+
 type GetUsersIdTasksRequestObject struct {
-	Id string `json:"id"`
+	Id ID `json:"id"`
 }
 
 type GetUsersIdTasksResponseObject interface {
@@ -361,9 +570,11 @@ type strictHandler struct {
 }
 
 // GetUsers operation middleware
-func (sh *strictHandler) GetUsers(ctx echo.Context) error {
+func (sh *strictHandler) GetUsers(ctx echo.Context, params GetUsersParams) error {
 	var request GetUsersRequestObject
 
+	request.Params = params
+
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.GetUsers(ctx.Request().Context(), request.(GetUsersRequestObject))
 	}
@@ -384,9 +595,11 @@ func (sh *strictHandler) GetUsers(ctx echo.Context) error {
 }
 
 // PostUsers operation middleware
-func (sh *strictHandler) PostUsers(ctx echo.Context) error {
+func (sh *strictHandler) PostUsers(ctx echo.Context, params PostUsersParams) error {
 	var request PostUsersRequestObject
 
+	request.Params = params
+
 	var body PostUsersJSONRequestBody
 	if err := ctx.Bind(&body); err != nil {
 		return err
@@ -413,10 +626,11 @@ func (sh *strictHandler) PostUsers(ctx echo.Context) error {
 }
 
 // DeleteUsersId operation middleware
-func (sh *strictHandler) DeleteUsersId(ctx echo.Context, id string) error {
+func (sh *strictHandler) DeleteUsersId(ctx echo.Context, id ID, params DeleteUsersIdParams) error {
 	var request DeleteUsersIdRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.DeleteUsersId(ctx.Request().Context(), request.(DeleteUsersIdRequestObject))
@@ -438,10 +652,11 @@ func (sh *strictHandler) DeleteUsersId(ctx echo.Context, id string) error {
 }
 
 // GetUsersId operation middleware
-func (sh *strictHandler) GetUsersId(ctx echo.Context, id string) error {
+func (sh *strictHandler) GetUsersId(ctx echo.Context, id ID, params GetUsersIdParams) error {
 	var request GetUsersIdRequestObject
 
 	request.Id = id
+	request.Params = params
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.GetUsersId(ctx.Request().Context(), request.(GetUsersIdRequestObject))
@@ -463,16 +678,32 @@ func (sh *strictHandler) GetUsersId(ctx echo.Context, id string) error {
 }
 
 // PatchUsersId operation middleware
-func (sh *strictHandler) PatchUsersId(ctx echo.Context, id string) error {
+func (sh *strictHandler) PatchUsersId(ctx echo.Context, id ID, params PatchUsersIdParams) error {
 	var request PatchUsersIdRequestObject
 
 	request.Id = id
-
-	var body PatchUsersIdJSONRequestBody
-	if err := ctx.Bind(&body); err != nil {
-		return err
+	request.Params = params
+	if strings.HasPrefix(ctx.Request().Header.Get("Content-Type"), "application/json") {
+		var body PatchUsersIdJSONRequestBody
+		if err := ctx.Bind(&body); err != nil {
+			return err
+		}
+		request.JSONBody = &body
+	}
+	if strings.HasPrefix(ctx.Request().Header.Get("Content-Type"), "application/json-patch+json") {
+		var body PatchUsersIdApplicationJSONPatchPlusJSONRequestBody
+		if err := ctx.Bind(&body); err != nil {
+			return err
+		}
+		request.ApplicationJSONPatchPlusJSONBody = &body
+	}
+	if strings.HasPrefix(ctx.Request().Header.Get("Content-Type"), "application/merge-patch+json") {
+		var body PatchUsersIdApplicationMergePatchPlusJSONRequestBody
+		if err := ctx.Bind(&body); err != nil {
+			return err
+		}
+		request.ApplicationMergePatchPlusJSONBody = &body
 	}
-	request.Body = &body
 
 	handler := func(ctx echo.Context, request interface{}) (interface{}, error) {
 		return sh.ssi.PatchUsersId(ctx.Request().Context(), request.(PatchUsersIdRequestObject))
@@ -494,7 +725,7 @@ func (sh *strictHandler) PatchUsersId(ctx echo.Context, id string) error {
 }
 
 // GetUsersIdTasks operation middleware
-func (sh *strictHandler) GetUsersIdTasks(ctx echo.Context, id string) error {
+func (sh *strictHandler) GetUsersIdTasks(ctx echo.Context, id ID) error {
 	var request GetUsersIdTasksRequestObject
 
 	request.Id = id
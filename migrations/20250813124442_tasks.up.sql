@@ -2,8 +2,8 @@ CREATE TABLE IF NOT EXISTS tasks (
                                      id VARCHAR(50) PRIMARY KEY,
     name VARCHAR(255) NOT NULL CHECK (name <> ''),
     is_done BOOLEAN NOT NULL DEFAULT FALSE,
-    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP DEFAULT NULL,
     CONSTRAINT valid_dates CHECK (created_at <= updated_at)
     );
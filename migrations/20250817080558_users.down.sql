@@ -1 +1 @@
-DROP TABLE IF EXISTS users CASCADE;
\ No newline at end of file
+DROP TABLE IF EXISTS users;
\ No newline at end of file
@@ -2,8 +2,8 @@ CREATE TABLE IF NOT EXISTS users (
                                      id VARCHAR(50) PRIMARY KEY,
     email VARCHAR(255) NOT NULL UNIQUE CHECK (email <> ''),
     password VARCHAR(255) NOT NULL CHECK (password <> ''),
-    created_at TIMESTAMP NOT NULL DEFAULT NOW(),
-    updated_at TIMESTAMP NOT NULL DEFAULT NOW(),
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
     deleted_at TIMESTAMP DEFAULT NULL,
     CONSTRAINT valid_dates CHECK (created_at <= updated_at)
     );
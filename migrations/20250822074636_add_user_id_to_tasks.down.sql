@@ -1,2 +1,2 @@
-ALTER TABLE tasks DROP COLUMN IF EXISTS user_id;
-DROP INDEX IF EXISTS idx_tasks_user_id;
\ No newline at end of file
+DROP INDEX IF EXISTS idx_tasks_user_id;
+ALTER TABLE tasks DROP COLUMN user_id;
@@ -0,0 +1,6 @@
+CREATE TABLE IF NOT EXISTS rate_limits (
+    key VARCHAR(255) PRIMARY KEY,
+    full_at BIGINT NOT NULL
+    );
+
+CREATE INDEX IF NOT EXISTS idx_rate_limits_full_at ON rate_limits (full_at);
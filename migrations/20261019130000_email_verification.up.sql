@@ -0,0 +1,3 @@
+ALTER TABLE users ADD COLUMN email_verified_at TIMESTAMP NULL;
+-- Пользователи, зарегистрированные до подтверждения почты, считаются подтвержденными
+UPDATE users SET email_verified_at = created_at;
@@ -0,0 +1,2 @@
+ALTER TABLE user_totps DROP COLUMN last_attempt_at;
+ALTER TABLE user_totps DROP COLUMN failed_attempts;
@@ -0,0 +1,3 @@
+-- Попытки ввода кода TOTP подряд без принятого кода: после лимита проверка кодов блокируется
+ALTER TABLE user_totps ADD COLUMN failed_attempts INTEGER NOT NULL DEFAULT 0;
+ALTER TABLE user_totps ADD COLUMN last_attempt_at TIMESTAMP;
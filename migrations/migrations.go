@@ -0,0 +1,10 @@
+// Package migrations встраивает SQL-миграции в бинарник.
+// Миграции пишутся на подмножестве SQL, общем для Postgres и SQLite
+package migrations
+
+import "embed"
+
+// FS Файлы миграций вида <версия>_<название>.up.sql и .down.sql
+//
+//go:embed *.sql
+var FS embed.FS
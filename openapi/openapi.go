@@ -0,0 +1,29 @@
+// Package openapi встраивает спецификацию API в бинарник,
+// чтобы сервер мог проверять запросы по ней без чтения файлов с диска
+package openapi
+
+import (
+	"context"
+	_ "embed" // Для go:embed
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// Spec Исходный текст openapi.yaml
+//
+//go:embed openapi.yaml
+var Spec []byte
+
+// Load Разбирает встроенную спецификацию и проверяет её корректность
+func Load() (*openapi3.T, error) {
+	loader := openapi3.NewLoader()
+	doc, err := loader.LoadFromData(Spec)
+	if err != nil {
+		return nil, fmt.Errorf("openapi: could not parse spec: %w", err)
+	}
+	if err := doc.Validate(context.Background()); err != nil {
+		return nil, fmt.Errorf("openapi: invalid spec: %w", err)
+	}
+	return doc, nil
+}
@@ -0,0 +1,47 @@
+package client_test
+
+import (
+	"POSTnGETtrain/internal/idempotency"
+	"POSTnGETtrain/internal/seed"
+	"POSTnGETtrain/internal/taskService"
+	"POSTnGETtrain/internal/userService"
+	"POSTnGETtrain/pkg/client"
+	"context"
+	"testing"
+)
+
+// BenchmarkTasks Чтение всех задач постранично на наборе в 5000 задач
+func BenchmarkTasks(b *testing.B) {
+	taskRepo := taskService.NewMemoryTaskRepository()
+	userRepo := userService.NewMemoryUserRepository(taskRepo)
+
+	cfg := seed.DefaultConfig()
+	cfg.Users, cfg.Tasks = 100, 5000
+	dataset, err := seed.Generate(cfg)
+	if err != nil {
+		b.Fatal(err)
+	}
+	if _, err := seed.Load(b.Context(), dataset, userService.NewUserService(userRepo), taskService.NewTaskService(taskRepo)); err != nil {
+		b.Fatal(err)
+	}
+
+	server := newServer(taskRepo, userRepo, idempotency.NewMemoryStore())
+	defer server.Close()
+	api, err := client.New(server.URL)
+	if err != nil {
+		b.Fatal(err)
+	}
+
+	for b.Loop() {
+		count := 0
+		for _, err := range api.Tasks(context.Background()) {
+			if err != nil {
+				b.Fatal(err)
+			}
+			count++
+		}
+		if count != cfg.Tasks {
+			b.Fatalf("got %d tasks, want %d", count, cfg.Tasks)
+		}
+	}
+}
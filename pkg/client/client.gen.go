@@ -0,0 +1,3213 @@
+// Package client provides primitives to interact with the openapi HTTP API.
+//
+// Code generated by github.com/oapi-codegen/oapi-codegen/v2 version v2.5.0 DO NOT EDIT.
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"POSTnGETtrain/pkg/patch"
+
+	"github.com/oapi-codegen/runtime"
+)
+
+// Defines values for JSONPatchOperationOp.
+const (
+	Add     JSONPatchOperationOp = "add"
+	Copy    JSONPatchOperationOp = "copy"
+	Move    JSONPatchOperationOp = "move"
+	Remove  JSONPatchOperationOp = "remove"
+	Replace JSONPatchOperationOp = "replace"
+	Test    JSONPatchOperationOp = "test"
+)
+
+// Defines values for VerifyCredentialsResponseStatus.
+const (
+	TotpRequired VerifyCredentialsResponseStatus = "totp_required"
+	Verified     VerifyCredentialsResponseStatus = "verified"
+)
+
+// ConfirmTOTPRequest defines model for ConfirmTOTPRequest.
+type ConfirmTOTPRequest struct {
+	Code     string `json:"code"`
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Credentials defines model for Credentials.
+type Credentials struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// Error defines model for Error.
+type Error struct {
+	Message string `json:"message"`
+
+	// RequestId X-Request-ID of the request that failed
+	RequestId *string `json:"request_id,omitempty"`
+
+	// Violations Password policy rules the submitted password breaks, one item per rule
+	Violations *[]PasswordViolation `json:"violations,omitempty"`
+}
+
+// ForgotPasswordRequest defines model for ForgotPasswordRequest.
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+// ID defines model for ID.
+type ID = string
+
+// JSONPatch JSON Patch (RFC 6902) document. The password is not part of the user representation,
+// so it is set with an add operation on /password
+type JSONPatch = []JSONPatchOperation
+
+// JSONPatchOperation defines model for JSONPatchOperation.
+type JSONPatchOperation struct {
+	From  *string              `json:"from,omitempty"`
+	Op    JSONPatchOperationOp `json:"op"`
+	Path  string               `json:"path"`
+	Value json.RawMessage      `json:"value"`
+}
+
+// JSONPatchOperationOp defines model for JSONPatchOperation.Op.
+type JSONPatchOperationOp string
+
+// PasswordViolation defines model for PasswordViolation.
+type PasswordViolation struct {
+	Message string `json:"message"`
+
+	// Rule Broken rule: min_length, max_length, uppercase, lowercase, digit, symbol,
+	// no_email (contains parts of the email address) or not_breached (found in a list of leaked passwords)
+	Rule string `json:"rule"`
+}
+
+// RecoveryCodes defines model for RecoveryCodes.
+type RecoveryCodes struct {
+	// RecoveryCodes One-time codes to use instead of a TOTP code when the authenticator is lost
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+// ResendVerificationRequest defines model for ResendVerificationRequest.
+type ResendVerificationRequest struct {
+	Email string `json:"email"`
+}
+
+// ResetPasswordRequest defines model for ResetPasswordRequest.
+type ResetPasswordRequest struct {
+	Password string `json:"password"`
+	Token    string `json:"token"`
+
+	// TOTPCode TOTP code or recovery code; required for users with two-factor authentication
+	TOTPCode *string `json:"totp_code,omitempty"`
+}
+
+// TOTPEnrollment defines model for TOTPEnrollment.
+type TOTPEnrollment struct {
+	// OtpauthURI otpauth://totp/ URI to show as a QR code
+	OtpauthURI string `json:"otpauth_uri"`
+
+	// Secret Base32 secret for manual entry
+	Secret string `json:"secret"`
+}
+
+// Task defines model for Task.
+type Task struct {
+	ID     string `json:"id"`
+	IsDone bool   `json:"is_done"`
+	Name   string `json:"name"`
+	UserID string `json:"user_id"`
+}
+
+// TaskMergePatch JSON Merge Patch (RFC 7396) for a task; null clears the field
+type TaskMergePatch struct {
+	IsDone patch.Field[bool]   `json:"is_done,omitzero"`
+	Name   patch.Field[string] `json:"name,omitzero"`
+	UserID patch.Field[string] `json:"user_id,omitzero"`
+}
+
+// TaskRequest defines model for TaskRequest.
+type TaskRequest struct {
+	IsDone *bool  `json:"is_done,omitempty"`
+	Name   string `json:"name"`
+	UserID string `json:"user_id"`
+}
+
+// TaskUpdate defines model for TaskUpdate.
+type TaskUpdate struct {
+	IsDone *bool   `json:"is_done,omitempty"`
+	Name   *string `json:"name,omitempty"`
+	UserID *string `json:"user_id,omitempty"`
+}
+
+// User defines model for User.
+type User struct {
+	Email string `json:"email"`
+
+	// EmailVerifiedAt When the user verified their email; null until verified
+	EmailVerifiedAt *time.Time `json:"email_verified_at"`
+	ID              string     `json:"id"`
+}
+
+// UserMergePatch JSON Merge Patch (RFC 7396) for a user; null clears the field
+type UserMergePatch struct {
+	Email    patch.Field[string] `json:"email,omitzero"`
+	Password patch.Field[string] `json:"password,omitzero"`
+}
+
+// UserRequest defines model for UserRequest.
+type UserRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+// UserUpdate defines model for UserUpdate.
+type UserUpdate struct {
+	Email    *string `json:"email,omitempty"`
+	Password *string `json:"password,omitempty"`
+}
+
+// VerifyCredentialsRequest Either email and password (step one) or challenge and code (step two)
+type VerifyCredentialsRequest struct {
+	Challenge *string `json:"challenge,omitempty"`
+
+	// Code TOTP code or recovery code
+	Code     *string `json:"code,omitempty"`
+	Email    *string `json:"email,omitempty"`
+	Password *string `json:"password,omitempty"`
+}
+
+// VerifyCredentialsResponse defines model for VerifyCredentialsResponse.
+type VerifyCredentialsResponse struct {
+	// Challenge Send it back with a TOTP code; only with status totp_required
+	Challenge *string `json:"challenge,omitempty"`
+
+	// ExpiresAt When the challenge expires
+	ExpiresAt *time.Time                      `json:"expires_at,omitempty"`
+	Status    VerifyCredentialsResponseStatus `json:"status"`
+
+	// UserID The verified user; only with status verified
+	UserID *string `json:"user_id,omitempty"`
+}
+
+// VerifyCredentialsResponseStatus defines model for VerifyCredentialsResponse.Status.
+type VerifyCredentialsResponseStatus string
+
+// VerifyEmailRequest defines model for VerifyEmailRequest.
+type VerifyEmailRequest struct {
+	Token string `json:"token"`
+}
+
+// IdempotencyKey defines model for IdempotencyKey.
+type IdempotencyKey = string
+
+// IfMatch defines model for IfMatch.
+type IfMatch = string
+
+// IfNoneMatch defines model for IfNoneMatch.
+type IfNoneMatch = string
+
+// Limit defines model for Limit.
+type Limit = int
+
+// Offset defines model for Offset.
+type Offset = int
+
+// TOTPCode defines model for TOTPCode.
+type TOTPCode = string
+
+// GetTasksParams defines parameters for GetTasks.
+type GetTasksParams struct {
+	// Limit Maximum number of items to return; without it the whole list is returned
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip, ordered by id
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// PostTasksParams defines parameters for PostTasks.
+type PostTasksParams struct {
+	// IdempotencyKey Unique key of the request; retries with the same key get the stored response.
+	// Keys are scoped to the mTLS service, and reusing one with a different body is rejected.
+	// Anonymous clients get the stored response for a retry with the same key and body from any address;
+	// the same key with a different body is treated as a different request
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// DeleteTasksIdParams defines parameters for DeleteTasksId.
+type DeleteTasksIdParams struct {
+	// IfMatch ETag of the resource version the change is based on, "*" or a comma-separated list of strong ETags;
+	// the change is applied if any of them matches the current version
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetTasksIdParams defines parameters for GetTasksId.
+type GetTasksIdParams struct {
+	// IfNoneMatch ETags of the resource versions the client already has
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// PatchTasksIdParams defines parameters for PatchTasksId.
+type PatchTasksIdParams struct {
+	// IfMatch ETag of the resource version the change is based on, "*" or a comma-separated list of strong ETags;
+	// the change is applied if any of them matches the current version
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+}
+
+// GetUsersParams defines parameters for GetUsers.
+type GetUsersParams struct {
+	// Limit Maximum number of items to return; without it the whole list is returned
+	Limit *Limit `form:"limit,omitempty" json:"limit,omitempty"`
+
+	// Offset Number of items to skip, ordered by id
+	Offset *Offset `form:"offset,omitempty" json:"offset,omitempty"`
+}
+
+// PostUsersParams defines parameters for PostUsers.
+type PostUsersParams struct {
+	// IdempotencyKey Unique key of the request; retries with the same key get the stored response.
+	// Keys are scoped to the mTLS service, and reusing one with a different body is rejected.
+	// Anonymous clients get the stored response for a retry with the same key and body from any address;
+	// the same key with a different body is treated as a different request
+	IdempotencyKey *IdempotencyKey `json:"Idempotency-Key,omitempty"`
+}
+
+// DeleteUsersIdParams defines parameters for DeleteUsersId.
+type DeleteUsersIdParams struct {
+	// IfMatch ETag of the resource version the change is based on, "*" or a comma-separated list of strong ETags;
+	// the change is applied if any of them matches the current version
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+
+	// XTOTPCode Current code from the authenticator app or a recovery code; required for sensitive changes
+	// of users with two-factor authentication
+	XTOTPCode *TOTPCode `json:"X-TOTP-Code,omitempty"`
+}
+
+// GetUsersIdParams defines parameters for GetUsersId.
+type GetUsersIdParams struct {
+	// IfNoneMatch ETags of the resource versions the client already has
+	IfNoneMatch *IfNoneMatch `json:"If-None-Match,omitempty"`
+}
+
+// PatchUsersIdParams defines parameters for PatchUsersId.
+type PatchUsersIdParams struct {
+	// IfMatch ETag of the resource version the change is based on, "*" or a comma-separated list of strong ETags;
+	// the change is applied if any of them matches the current version
+	IfMatch *IfMatch `json:"If-Match,omitempty"`
+
+	// XTOTPCode Current code from the authenticator app or a recovery code; required for sensitive changes
+	// of users with two-factor authentication
+	XTOTPCode *TOTPCode `json:"X-TOTP-Code,omitempty"`
+}
+
+// PostAuthPasswordForgotJSONRequestBody defines body for PostAuthPasswordForgot for application/json ContentType.
+type PostAuthPasswordForgotJSONRequestBody = ForgotPasswordRequest
+
+// PostAuthPasswordResetJSONRequestBody defines body for PostAuthPasswordReset for application/json ContentType.
+type PostAuthPasswordResetJSONRequestBody = ResetPasswordRequest
+
+// PostAuthTotpConfirmJSONRequestBody defines body for PostAuthTotpConfirm for application/json ContentType.
+type PostAuthTotpConfirmJSONRequestBody = ConfirmTOTPRequest
+
+// PostAuthTotpEnrollJSONRequestBody defines body for PostAuthTotpEnroll for application/json ContentType.
+type PostAuthTotpEnrollJSONRequestBody = Credentials
+
+// PostAuthVerifyCredentialsJSONRequestBody defines body for PostAuthVerifyCredentials for application/json ContentType.
+type PostAuthVerifyCredentialsJSONRequestBody = VerifyCredentialsRequest
+
+// PostAuthVerifyEmailJSONRequestBody defines body for PostAuthVerifyEmail for application/json ContentType.
+type PostAuthVerifyEmailJSONRequestBody = VerifyEmailRequest
+
+// PostAuthVerifyEmailResendJSONRequestBody defines body for PostAuthVerifyEmailResend for application/json ContentType.
+type PostAuthVerifyEmailResendJSONRequestBody = ResendVerificationRequest
+
+// PostTasksJSONRequestBody defines body for PostTasks for application/json ContentType.
+type PostTasksJSONRequestBody = TaskRequest
+
+// PatchTasksIdJSONRequestBody defines body for PatchTasksId for application/json ContentType.
+type PatchTasksIdJSONRequestBody = TaskUpdate
+
+// PatchTasksIdApplicationJSONPatchPlusJSONRequestBody defines body for PatchTasksId for application/json-patch+json ContentType.
+type PatchTasksIdApplicationJSONPatchPlusJSONRequestBody = JSONPatch
+
+// PatchTasksIdApplicationMergePatchPlusJSONRequestBody defines body for PatchTasksId for application/merge-patch+json ContentType.
+type PatchTasksIdApplicationMergePatchPlusJSONRequestBody = TaskMergePatch
+
+// PostUsersJSONRequestBody defines body for PostUsers for application/json ContentType.
+type PostUsersJSONRequestBody = UserRequest
+
+// PatchUsersIdJSONRequestBody defines body for PatchUsersId for application/json ContentType.
+type PatchUsersIdJSONRequestBody = UserUpdate
+
+// PatchUsersIdApplicationJSONPatchPlusJSONRequestBody defines body for PatchUsersId for application/json-patch+json ContentType.
+type PatchUsersIdApplicationJSONPatchPlusJSONRequestBody = JSONPatch
+
+// PatchUsersIdApplicationMergePatchPlusJSONRequestBody defines body for PatchUsersId for application/merge-patch+json ContentType.
+type PatchUsersIdApplicationMergePatchPlusJSONRequestBody = UserMergePatch
+
+// RequestEditorFn  is the function signature for the RequestEditor callback function
+type RequestEditorFn func(ctx context.Context, req *http.Request) error
+
+// Doer performs HTTP requests.
+//
+// The standard http.Client implements this interface.
+type HttpRequestDoer interface {
+	Do(req *http.Request) (*http.Response, error)
+}
+
+// Client which conforms to the OpenAPI3 specification for this service.
+type Client struct {
+	// The endpoint of the server conforming to this interface, with scheme,
+	// https://api.deepmap.com for example. This can contain a path relative
+	// to the server, such as https://api.deepmap.com/dev-test, and all the
+	// paths in the swagger spec will be appended to the server.
+	Server string
+
+	// Doer for performing requests, typically a *http.Client with any
+	// customized settings, such as certificate chains.
+	Client HttpRequestDoer
+
+	// A list of callbacks for modifying requests which are generated before sending over
+	// the network.
+	RequestEditors []RequestEditorFn
+}
+
+// ClientOption allows setting custom parameters during construction
+type ClientOption func(*Client) error
+
+// Creates a new Client, with reasonable defaults
+func NewClient(server string, opts ...ClientOption) (*Client, error) {
+	// create a client with sane default values
+	client := Client{
+		Server: server,
+	}
+	// mutate client and add all optional params
+	for _, o := range opts {
+		if err := o(&client); err != nil {
+			return nil, err
+		}
+	}
+	// ensure the server URL always has a trailing slash
+	if !strings.HasSuffix(client.Server, "/") {
+		client.Server += "/"
+	}
+	// create httpClient, if not already present
+	if client.Client == nil {
+		client.Client = &http.Client{}
+	}
+	return &client, nil
+}
+
+// WithHTTPClient allows overriding the default Doer, which is
+// automatically created using http.Client. This is useful for tests.
+func WithHTTPClient(doer HttpRequestDoer) ClientOption {
+	return func(c *Client) error {
+		c.Client = doer
+		return nil
+	}
+}
+
+// WithRequestEditorFn allows setting up a callback function, which will be
+// called right before sending the request. This can be used to mutate the request.
+func WithRequestEditorFn(fn RequestEditorFn) ClientOption {
+	return func(c *Client) error {
+		c.RequestEditors = append(c.RequestEditors, fn)
+		return nil
+	}
+}
+
+// The interface specification for the client above.
+type ClientInterface interface {
+	// PostAuthPasswordForgotWithBody request with any body
+	PostAuthPasswordForgotWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthPasswordForgot(ctx context.Context, body PostAuthPasswordForgotJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthPasswordResetWithBody request with any body
+	PostAuthPasswordResetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthPasswordReset(ctx context.Context, body PostAuthPasswordResetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthTotpConfirmWithBody request with any body
+	PostAuthTotpConfirmWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthTotpConfirm(ctx context.Context, body PostAuthTotpConfirmJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthTotpEnrollWithBody request with any body
+	PostAuthTotpEnrollWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthTotpEnroll(ctx context.Context, body PostAuthTotpEnrollJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthVerifyCredentialsWithBody request with any body
+	PostAuthVerifyCredentialsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthVerifyCredentials(ctx context.Context, body PostAuthVerifyCredentialsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthVerifyEmailWithBody request with any body
+	PostAuthVerifyEmailWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthVerifyEmail(ctx context.Context, body PostAuthVerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostAuthVerifyEmailResendWithBody request with any body
+	PostAuthVerifyEmailResendWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostAuthVerifyEmailResend(ctx context.Context, body PostAuthVerifyEmailResendJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTasks request
+	GetTasks(ctx context.Context, params *GetTasksParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostTasksWithBody request with any body
+	PostTasksWithBody(ctx context.Context, params *PostTasksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostTasks(ctx context.Context, params *PostTasksParams, body PostTasksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteTasksId request
+	DeleteTasksId(ctx context.Context, id ID, params *DeleteTasksIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetTasksId request
+	GetTasksId(ctx context.Context, id ID, params *GetTasksIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchTasksIdWithBody request with any body
+	PatchTasksIdWithBody(ctx context.Context, id ID, params *PatchTasksIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchTasksId(ctx context.Context, id ID, params *PatchTasksIdParams, body PatchTasksIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchTasksIdWithApplicationJSONPatchPlusJSONBody(ctx context.Context, id ID, params *PatchTasksIdParams, body PatchTasksIdApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchTasksIdWithApplicationMergePatchPlusJSONBody(ctx context.Context, id ID, params *PatchTasksIdParams, body PatchTasksIdApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUsers request
+	GetUsers(ctx context.Context, params *GetUsersParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PostUsersWithBody request with any body
+	PostUsersWithBody(ctx context.Context, params *PostUsersParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PostUsers(ctx context.Context, params *PostUsersParams, body PostUsersJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// DeleteUsersId request
+	DeleteUsersId(ctx context.Context, id ID, params *DeleteUsersIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUsersId request
+	GetUsersId(ctx context.Context, id ID, params *GetUsersIdParams, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// PatchUsersIdWithBody request with any body
+	PatchUsersIdWithBody(ctx context.Context, id ID, params *PatchUsersIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchUsersId(ctx context.Context, id ID, params *PatchUsersIdParams, body PatchUsersIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchUsersIdWithApplicationJSONPatchPlusJSONBody(ctx context.Context, id ID, params *PatchUsersIdParams, body PatchUsersIdApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	PatchUsersIdWithApplicationMergePatchPlusJSONBody(ctx context.Context, id ID, params *PatchUsersIdParams, body PatchUsersIdApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error)
+
+	// GetUsersIdTasks request
+	GetUsersIdTasks(ctx context.Context, id ID, reqEditors ...RequestEditorFn) (*http.Response, error)
+}
+
+func (c *Client) PostAuthPasswordForgotWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthPasswordForgotRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthPasswordForgot(ctx context.Context, body PostAuthPasswordForgotJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthPasswordForgotRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthPasswordResetWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthPasswordResetRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthPasswordReset(ctx context.Context, body PostAuthPasswordResetJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthPasswordResetRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthTotpConfirmWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthTotpConfirmRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthTotpConfirm(ctx context.Context, body PostAuthTotpConfirmJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthTotpConfirmRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthTotpEnrollWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthTotpEnrollRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthTotpEnroll(ctx context.Context, body PostAuthTotpEnrollJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthTotpEnrollRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthVerifyCredentialsWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthVerifyCredentialsRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthVerifyCredentials(ctx context.Context, body PostAuthVerifyCredentialsJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthVerifyCredentialsRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthVerifyEmailWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthVerifyEmailRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthVerifyEmail(ctx context.Context, body PostAuthVerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthVerifyEmailRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthVerifyEmailResendWithBody(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthVerifyEmailResendRequestWithBody(c.Server, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostAuthVerifyEmailResend(ctx context.Context, body PostAuthVerifyEmailResendJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostAuthVerifyEmailResendRequest(c.Server, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTasks(ctx context.Context, params *GetTasksParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTasksRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTasksWithBody(ctx context.Context, params *PostTasksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTasksRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostTasks(ctx context.Context, params *PostTasksParams, body PostTasksJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostTasksRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteTasksId(ctx context.Context, id ID, params *DeleteTasksIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteTasksIdRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetTasksId(ctx context.Context, id ID, params *GetTasksIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetTasksIdRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchTasksIdWithBody(ctx context.Context, id ID, params *PatchTasksIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchTasksIdRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchTasksId(ctx context.Context, id ID, params *PatchTasksIdParams, body PatchTasksIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchTasksIdRequest(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchTasksIdWithApplicationJSONPatchPlusJSONBody(ctx context.Context, id ID, params *PatchTasksIdParams, body PatchTasksIdApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchTasksIdRequestWithApplicationJSONPatchPlusJSONBody(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchTasksIdWithApplicationMergePatchPlusJSONBody(ctx context.Context, id ID, params *PatchTasksIdParams, body PatchTasksIdApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchTasksIdRequestWithApplicationMergePatchPlusJSONBody(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUsers(ctx context.Context, params *GetUsersParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUsersRequest(c.Server, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsersWithBody(ctx context.Context, params *PostUsersParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersRequestWithBody(c.Server, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PostUsers(ctx context.Context, params *PostUsersParams, body PostUsersJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPostUsersRequest(c.Server, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) DeleteUsersId(ctx context.Context, id ID, params *DeleteUsersIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewDeleteUsersIdRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUsersId(ctx context.Context, id ID, params *GetUsersIdParams, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUsersIdRequest(c.Server, id, params)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchUsersIdWithBody(ctx context.Context, id ID, params *PatchUsersIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchUsersIdRequestWithBody(c.Server, id, params, contentType, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchUsersId(ctx context.Context, id ID, params *PatchUsersIdParams, body PatchUsersIdJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchUsersIdRequest(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchUsersIdWithApplicationJSONPatchPlusJSONBody(ctx context.Context, id ID, params *PatchUsersIdParams, body PatchUsersIdApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchUsersIdRequestWithApplicationJSONPatchPlusJSONBody(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) PatchUsersIdWithApplicationMergePatchPlusJSONBody(ctx context.Context, id ID, params *PatchUsersIdParams, body PatchUsersIdApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewPatchUsersIdRequestWithApplicationMergePatchPlusJSONBody(c.Server, id, params, body)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+func (c *Client) GetUsersIdTasks(ctx context.Context, id ID, reqEditors ...RequestEditorFn) (*http.Response, error) {
+	req, err := NewGetUsersIdTasksRequest(c.Server, id)
+	if err != nil {
+		return nil, err
+	}
+	req = req.WithContext(ctx)
+	if err := c.applyEditors(ctx, req, reqEditors); err != nil {
+		return nil, err
+	}
+	return c.Client.Do(req)
+}
+
+// NewPostAuthPasswordForgotRequest calls the generic PostAuthPasswordForgot builder with application/json body
+func NewPostAuthPasswordForgotRequest(server string, body PostAuthPasswordForgotJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthPasswordForgotRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthPasswordForgotRequestWithBody generates requests for PostAuthPasswordForgot with any type of body
+func NewPostAuthPasswordForgotRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/password/forgot")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthPasswordResetRequest calls the generic PostAuthPasswordReset builder with application/json body
+func NewPostAuthPasswordResetRequest(server string, body PostAuthPasswordResetJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthPasswordResetRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthPasswordResetRequestWithBody generates requests for PostAuthPasswordReset with any type of body
+func NewPostAuthPasswordResetRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/password/reset")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthTotpConfirmRequest calls the generic PostAuthTotpConfirm builder with application/json body
+func NewPostAuthTotpConfirmRequest(server string, body PostAuthTotpConfirmJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthTotpConfirmRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthTotpConfirmRequestWithBody generates requests for PostAuthTotpConfirm with any type of body
+func NewPostAuthTotpConfirmRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/totp/confirm")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthTotpEnrollRequest calls the generic PostAuthTotpEnroll builder with application/json body
+func NewPostAuthTotpEnrollRequest(server string, body PostAuthTotpEnrollJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthTotpEnrollRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthTotpEnrollRequestWithBody generates requests for PostAuthTotpEnroll with any type of body
+func NewPostAuthTotpEnrollRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/totp/enroll")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthVerifyCredentialsRequest calls the generic PostAuthVerifyCredentials builder with application/json body
+func NewPostAuthVerifyCredentialsRequest(server string, body PostAuthVerifyCredentialsJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthVerifyCredentialsRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthVerifyCredentialsRequestWithBody generates requests for PostAuthVerifyCredentials with any type of body
+func NewPostAuthVerifyCredentialsRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/verify-credentials")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthVerifyEmailRequest calls the generic PostAuthVerifyEmail builder with application/json body
+func NewPostAuthVerifyEmailRequest(server string, body PostAuthVerifyEmailJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthVerifyEmailRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthVerifyEmailRequestWithBody generates requests for PostAuthVerifyEmail with any type of body
+func NewPostAuthVerifyEmailRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/verify-email")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewPostAuthVerifyEmailResendRequest calls the generic PostAuthVerifyEmailResend builder with application/json body
+func NewPostAuthVerifyEmailResendRequest(server string, body PostAuthVerifyEmailResendJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostAuthVerifyEmailResendRequestWithBody(server, "application/json", bodyReader)
+}
+
+// NewPostAuthVerifyEmailResendRequestWithBody generates requests for PostAuthVerifyEmailResend with any type of body
+func NewPostAuthVerifyEmailResendRequestWithBody(server string, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/auth/verify-email/resend")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	return req, nil
+}
+
+// NewGetTasksRequest generates requests for GetTasks
+func NewGetTasksRequest(server string, params *GetTasksParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tasks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostTasksRequest calls the generic PostTasks builder with application/json body
+func NewPostTasksRequest(server string, params *PostTasksParams, body PostTasksJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostTasksRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostTasksRequestWithBody generates requests for PostTasks with any type of body
+func NewPostTasksRequestWithBody(server string, params *PostTasksParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tasks")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteTasksIdRequest generates requests for DeleteTasksId
+func NewDeleteTasksIdRequest(server string, id ID, params *DeleteTasksIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tasks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetTasksIdRequest generates requests for GetTasksId
+func NewGetTasksIdRequest(server string, id ID, params *GetTasksIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tasks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPatchTasksIdRequest calls the generic PatchTasksId builder with application/json body
+func NewPatchTasksIdRequest(server string, id ID, params *PatchTasksIdParams, body PatchTasksIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchTasksIdRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewPatchTasksIdRequestWithApplicationJSONPatchPlusJSONBody calls the generic PatchTasksId builder with application/json-patch+json body
+func NewPatchTasksIdRequestWithApplicationJSONPatchPlusJSONBody(server string, id ID, params *PatchTasksIdParams, body PatchTasksIdApplicationJSONPatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchTasksIdRequestWithBody(server, id, params, "application/json-patch+json", bodyReader)
+}
+
+// NewPatchTasksIdRequestWithApplicationMergePatchPlusJSONBody calls the generic PatchTasksId builder with application/merge-patch+json body
+func NewPatchTasksIdRequestWithApplicationMergePatchPlusJSONBody(server string, id ID, params *PatchTasksIdParams, body PatchTasksIdApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchTasksIdRequestWithBody(server, id, params, "application/merge-patch+json", bodyReader)
+}
+
+// NewPatchTasksIdRequestWithBody generates requests for PatchTasksId with any type of body
+func NewPatchTasksIdRequestWithBody(server string, id ID, params *PatchTasksIdParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/tasks/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetUsersRequest generates requests for GetUsers
+func NewGetUsersRequest(server string, params *GetUsersParams) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+		queryValues := queryURL.Query()
+
+		if params.Limit != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "limit", runtime.ParamLocationQuery, *params.Limit); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		if params.Offset != nil {
+
+			if queryFrag, err := runtime.StyleParamWithLocation("form", true, "offset", runtime.ParamLocationQuery, *params.Offset); err != nil {
+				return nil, err
+			} else if parsed, err := url.ParseQuery(queryFrag); err != nil {
+				return nil, err
+			} else {
+				for k, v := range parsed {
+					for _, v2 := range v {
+						queryValues.Add(k, v2)
+					}
+				}
+			}
+
+		}
+
+		queryURL.RawQuery = queryValues.Encode()
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+// NewPostUsersRequest calls the generic PostUsers builder with application/json body
+func NewPostUsersRequest(server string, params *PostUsersParams, body PostUsersJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPostUsersRequestWithBody(server, params, "application/json", bodyReader)
+}
+
+// NewPostUsersRequestWithBody generates requests for PostUsers with any type of body
+func NewPostUsersRequestWithBody(server string, params *PostUsersParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users")
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("POST", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IdempotencyKey != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "Idempotency-Key", runtime.ParamLocationHeader, *params.IdempotencyKey)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("Idempotency-Key", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewDeleteUsersIdRequest generates requests for DeleteUsersId
+func NewDeleteUsersIdRequest(server string, id ID, params *DeleteUsersIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("DELETE", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+		if params.XTOTPCode != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-TOTP-Code", runtime.ParamLocationHeader, *params.XTOTPCode)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-TOTP-Code", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetUsersIdRequest generates requests for GetUsersId
+func NewGetUsersIdRequest(server string, id ID, params *GetUsersIdParams) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	if params != nil {
+
+		if params.IfNoneMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-None-Match", runtime.ParamLocationHeader, *params.IfNoneMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-None-Match", headerParam0)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewPatchUsersIdRequest calls the generic PatchUsersId builder with application/json body
+func NewPatchUsersIdRequest(server string, id ID, params *PatchUsersIdParams, body PatchUsersIdJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchUsersIdRequestWithBody(server, id, params, "application/json", bodyReader)
+}
+
+// NewPatchUsersIdRequestWithApplicationJSONPatchPlusJSONBody calls the generic PatchUsersId builder with application/json-patch+json body
+func NewPatchUsersIdRequestWithApplicationJSONPatchPlusJSONBody(server string, id ID, params *PatchUsersIdParams, body PatchUsersIdApplicationJSONPatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchUsersIdRequestWithBody(server, id, params, "application/json-patch+json", bodyReader)
+}
+
+// NewPatchUsersIdRequestWithApplicationMergePatchPlusJSONBody calls the generic PatchUsersId builder with application/merge-patch+json body
+func NewPatchUsersIdRequestWithApplicationMergePatchPlusJSONBody(server string, id ID, params *PatchUsersIdParams, body PatchUsersIdApplicationMergePatchPlusJSONRequestBody) (*http.Request, error) {
+	var bodyReader io.Reader
+	buf, err := json.Marshal(body)
+	if err != nil {
+		return nil, err
+	}
+	bodyReader = bytes.NewReader(buf)
+	return NewPatchUsersIdRequestWithBody(server, id, params, "application/merge-patch+json", bodyReader)
+}
+
+// NewPatchUsersIdRequestWithBody generates requests for PatchUsersId with any type of body
+func NewPatchUsersIdRequestWithBody(server string, id ID, params *PatchUsersIdParams, contentType string, body io.Reader) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/%s", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("PATCH", queryURL.String(), body)
+	if err != nil {
+		return nil, err
+	}
+
+	req.Header.Add("Content-Type", contentType)
+
+	if params != nil {
+
+		if params.IfMatch != nil {
+			var headerParam0 string
+
+			headerParam0, err = runtime.StyleParamWithLocation("simple", false, "If-Match", runtime.ParamLocationHeader, *params.IfMatch)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("If-Match", headerParam0)
+		}
+
+		if params.XTOTPCode != nil {
+			var headerParam1 string
+
+			headerParam1, err = runtime.StyleParamWithLocation("simple", false, "X-TOTP-Code", runtime.ParamLocationHeader, *params.XTOTPCode)
+			if err != nil {
+				return nil, err
+			}
+
+			req.Header.Set("X-TOTP-Code", headerParam1)
+		}
+
+	}
+
+	return req, nil
+}
+
+// NewGetUsersIdTasksRequest generates requests for GetUsersIdTasks
+func NewGetUsersIdTasksRequest(server string, id ID) (*http.Request, error) {
+	var err error
+
+	var pathParam0 string
+
+	pathParam0, err = runtime.StyleParamWithLocation("simple", false, "id", runtime.ParamLocationPath, id)
+	if err != nil {
+		return nil, err
+	}
+
+	serverURL, err := url.Parse(server)
+	if err != nil {
+		return nil, err
+	}
+
+	operationPath := fmt.Sprintf("/users/%s/tasks", pathParam0)
+	if operationPath[0] == '/' {
+		operationPath = "." + operationPath
+	}
+
+	queryURL, err := serverURL.Parse(operationPath)
+	if err != nil {
+		return nil, err
+	}
+
+	req, err := http.NewRequest("GET", queryURL.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return req, nil
+}
+
+func (c *Client) applyEditors(ctx context.Context, req *http.Request, additionalEditors []RequestEditorFn) error {
+	for _, r := range c.RequestEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	for _, r := range additionalEditors {
+		if err := r(ctx, req); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ClientWithResponses builds on ClientInterface to offer response payloads
+type ClientWithResponses struct {
+	ClientInterface
+}
+
+// NewClientWithResponses creates a new ClientWithResponses, which wraps
+// Client with return type handling
+func NewClientWithResponses(server string, opts ...ClientOption) (*ClientWithResponses, error) {
+	client, err := NewClient(server, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return &ClientWithResponses{client}, nil
+}
+
+// WithBaseURL overrides the baseURL.
+func WithBaseURL(baseURL string) ClientOption {
+	return func(c *Client) error {
+		newBaseURL, err := url.Parse(baseURL)
+		if err != nil {
+			return err
+		}
+		c.Server = newBaseURL.String()
+		return nil
+	}
+}
+
+// ClientWithResponsesInterface is the interface specification for the client with responses above.
+type ClientWithResponsesInterface interface {
+	// PostAuthPasswordForgotWithBodyWithResponse request with any body
+	PostAuthPasswordForgotWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthPasswordForgotResponse, error)
+
+	PostAuthPasswordForgotWithResponse(ctx context.Context, body PostAuthPasswordForgotJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthPasswordForgotResponse, error)
+
+	// PostAuthPasswordResetWithBodyWithResponse request with any body
+	PostAuthPasswordResetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthPasswordResetResponse, error)
+
+	PostAuthPasswordResetWithResponse(ctx context.Context, body PostAuthPasswordResetJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthPasswordResetResponse, error)
+
+	// PostAuthTotpConfirmWithBodyWithResponse request with any body
+	PostAuthTotpConfirmWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthTotpConfirmResponse, error)
+
+	PostAuthTotpConfirmWithResponse(ctx context.Context, body PostAuthTotpConfirmJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthTotpConfirmResponse, error)
+
+	// PostAuthTotpEnrollWithBodyWithResponse request with any body
+	PostAuthTotpEnrollWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthTotpEnrollResponse, error)
+
+	PostAuthTotpEnrollWithResponse(ctx context.Context, body PostAuthTotpEnrollJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthTotpEnrollResponse, error)
+
+	// PostAuthVerifyCredentialsWithBodyWithResponse request with any body
+	PostAuthVerifyCredentialsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthVerifyCredentialsResponse, error)
+
+	PostAuthVerifyCredentialsWithResponse(ctx context.Context, body PostAuthVerifyCredentialsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthVerifyCredentialsResponse, error)
+
+	// PostAuthVerifyEmailWithBodyWithResponse request with any body
+	PostAuthVerifyEmailWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthVerifyEmailResponse, error)
+
+	PostAuthVerifyEmailWithResponse(ctx context.Context, body PostAuthVerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthVerifyEmailResponse, error)
+
+	// PostAuthVerifyEmailResendWithBodyWithResponse request with any body
+	PostAuthVerifyEmailResendWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthVerifyEmailResendResponse, error)
+
+	PostAuthVerifyEmailResendWithResponse(ctx context.Context, body PostAuthVerifyEmailResendJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthVerifyEmailResendResponse, error)
+
+	// GetTasksWithResponse request
+	GetTasksWithResponse(ctx context.Context, params *GetTasksParams, reqEditors ...RequestEditorFn) (*GetTasksResponse, error)
+
+	// PostTasksWithBodyWithResponse request with any body
+	PostTasksWithBodyWithResponse(ctx context.Context, params *PostTasksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTasksResponse, error)
+
+	PostTasksWithResponse(ctx context.Context, params *PostTasksParams, body PostTasksJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTasksResponse, error)
+
+	// DeleteTasksIdWithResponse request
+	DeleteTasksIdWithResponse(ctx context.Context, id ID, params *DeleteTasksIdParams, reqEditors ...RequestEditorFn) (*DeleteTasksIdResponse, error)
+
+	// GetTasksIdWithResponse request
+	GetTasksIdWithResponse(ctx context.Context, id ID, params *GetTasksIdParams, reqEditors ...RequestEditorFn) (*GetTasksIdResponse, error)
+
+	// PatchTasksIdWithBodyWithResponse request with any body
+	PatchTasksIdWithBodyWithResponse(ctx context.Context, id ID, params *PatchTasksIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchTasksIdResponse, error)
+
+	PatchTasksIdWithResponse(ctx context.Context, id ID, params *PatchTasksIdParams, body PatchTasksIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchTasksIdResponse, error)
+
+	PatchTasksIdWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, id ID, params *PatchTasksIdParams, body PatchTasksIdApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchTasksIdResponse, error)
+
+	PatchTasksIdWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, id ID, params *PatchTasksIdParams, body PatchTasksIdApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchTasksIdResponse, error)
+
+	// GetUsersWithResponse request
+	GetUsersWithResponse(ctx context.Context, params *GetUsersParams, reqEditors ...RequestEditorFn) (*GetUsersResponse, error)
+
+	// PostUsersWithBodyWithResponse request with any body
+	PostUsersWithBodyWithResponse(ctx context.Context, params *PostUsersParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersResponse, error)
+
+	PostUsersWithResponse(ctx context.Context, params *PostUsersParams, body PostUsersJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersResponse, error)
+
+	// DeleteUsersIdWithResponse request
+	DeleteUsersIdWithResponse(ctx context.Context, id ID, params *DeleteUsersIdParams, reqEditors ...RequestEditorFn) (*DeleteUsersIdResponse, error)
+
+	// GetUsersIdWithResponse request
+	GetUsersIdWithResponse(ctx context.Context, id ID, params *GetUsersIdParams, reqEditors ...RequestEditorFn) (*GetUsersIdResponse, error)
+
+	// PatchUsersIdWithBodyWithResponse request with any body
+	PatchUsersIdWithBodyWithResponse(ctx context.Context, id ID, params *PatchUsersIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchUsersIdResponse, error)
+
+	PatchUsersIdWithResponse(ctx context.Context, id ID, params *PatchUsersIdParams, body PatchUsersIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchUsersIdResponse, error)
+
+	PatchUsersIdWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, id ID, params *PatchUsersIdParams, body PatchUsersIdApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchUsersIdResponse, error)
+
+	PatchUsersIdWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, id ID, params *PatchUsersIdParams, body PatchUsersIdApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchUsersIdResponse, error)
+
+	// GetUsersIdTasksWithResponse request
+	GetUsersIdTasksWithResponse(ctx context.Context, id ID, reqEditors ...RequestEditorFn) (*GetUsersIdTasksResponse, error)
+}
+
+type PostAuthPasswordForgotResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthPasswordForgotResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthPasswordForgotResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthPasswordResetResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON403      *Error
+	JSON422      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthPasswordResetResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthPasswordResetResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthTotpConfirmResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *RecoveryCodes
+	JSON401      *Error
+	JSON409      *Error
+	JSON422      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthTotpConfirmResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthTotpConfirmResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthTotpEnrollResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *TOTPEnrollment
+	JSON401      *Error
+	JSON409      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthTotpEnrollResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthTotpEnrollResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthVerifyCredentialsResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *VerifyCredentialsResponse
+	JSON401      *Error
+	JSON422      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthVerifyCredentialsResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthVerifyCredentialsResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthVerifyEmailResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON422      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthVerifyEmailResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthVerifyEmailResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostAuthVerifyEmailResendResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+}
+
+// Status returns HTTPResponse.Status
+func (r PostAuthVerifyEmailResendResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostAuthVerifyEmailResendResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTasksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Task
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTasksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTasksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostTasksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *Task
+	JSON403      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostTasksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostTasksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteTasksIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON403      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteTasksIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteTasksIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetTasksIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Task
+}
+
+// Status returns HTTPResponse.Status
+func (r GetTasksIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetTasksIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchTasksIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *Task
+	JSON403      *Error
+	JSON422      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchTasksIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchTasksIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUsersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]User
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUsersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUsersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PostUsersResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON201      *User
+	JSON422      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PostUsersResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PostUsersResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type DeleteUsersIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON403      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r DeleteUsersIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r DeleteUsersIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUsersIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *User
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUsersIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUsersIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type PatchUsersIdResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *User
+	JSON403      *Error
+	JSON422      *Error
+}
+
+// Status returns HTTPResponse.Status
+func (r PatchUsersIdResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r PatchUsersIdResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+type GetUsersIdTasksResponse struct {
+	Body         []byte
+	HTTPResponse *http.Response
+	JSON200      *[]Task
+}
+
+// Status returns HTTPResponse.Status
+func (r GetUsersIdTasksResponse) Status() string {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.Status
+	}
+	return http.StatusText(0)
+}
+
+// StatusCode returns HTTPResponse.StatusCode
+func (r GetUsersIdTasksResponse) StatusCode() int {
+	if r.HTTPResponse != nil {
+		return r.HTTPResponse.StatusCode
+	}
+	return 0
+}
+
+// PostAuthPasswordForgotWithBodyWithResponse request with arbitrary body returning *PostAuthPasswordForgotResponse
+func (c *ClientWithResponses) PostAuthPasswordForgotWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthPasswordForgotResponse, error) {
+	rsp, err := c.PostAuthPasswordForgotWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthPasswordForgotResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthPasswordForgotWithResponse(ctx context.Context, body PostAuthPasswordForgotJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthPasswordForgotResponse, error) {
+	rsp, err := c.PostAuthPasswordForgot(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthPasswordForgotResponse(rsp)
+}
+
+// PostAuthPasswordResetWithBodyWithResponse request with arbitrary body returning *PostAuthPasswordResetResponse
+func (c *ClientWithResponses) PostAuthPasswordResetWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthPasswordResetResponse, error) {
+	rsp, err := c.PostAuthPasswordResetWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthPasswordResetResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthPasswordResetWithResponse(ctx context.Context, body PostAuthPasswordResetJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthPasswordResetResponse, error) {
+	rsp, err := c.PostAuthPasswordReset(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthPasswordResetResponse(rsp)
+}
+
+// PostAuthTotpConfirmWithBodyWithResponse request with arbitrary body returning *PostAuthTotpConfirmResponse
+func (c *ClientWithResponses) PostAuthTotpConfirmWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthTotpConfirmResponse, error) {
+	rsp, err := c.PostAuthTotpConfirmWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthTotpConfirmResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthTotpConfirmWithResponse(ctx context.Context, body PostAuthTotpConfirmJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthTotpConfirmResponse, error) {
+	rsp, err := c.PostAuthTotpConfirm(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthTotpConfirmResponse(rsp)
+}
+
+// PostAuthTotpEnrollWithBodyWithResponse request with arbitrary body returning *PostAuthTotpEnrollResponse
+func (c *ClientWithResponses) PostAuthTotpEnrollWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthTotpEnrollResponse, error) {
+	rsp, err := c.PostAuthTotpEnrollWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthTotpEnrollResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthTotpEnrollWithResponse(ctx context.Context, body PostAuthTotpEnrollJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthTotpEnrollResponse, error) {
+	rsp, err := c.PostAuthTotpEnroll(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthTotpEnrollResponse(rsp)
+}
+
+// PostAuthVerifyCredentialsWithBodyWithResponse request with arbitrary body returning *PostAuthVerifyCredentialsResponse
+func (c *ClientWithResponses) PostAuthVerifyCredentialsWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthVerifyCredentialsResponse, error) {
+	rsp, err := c.PostAuthVerifyCredentialsWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthVerifyCredentialsResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthVerifyCredentialsWithResponse(ctx context.Context, body PostAuthVerifyCredentialsJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthVerifyCredentialsResponse, error) {
+	rsp, err := c.PostAuthVerifyCredentials(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthVerifyCredentialsResponse(rsp)
+}
+
+// PostAuthVerifyEmailWithBodyWithResponse request with arbitrary body returning *PostAuthVerifyEmailResponse
+func (c *ClientWithResponses) PostAuthVerifyEmailWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthVerifyEmailResponse, error) {
+	rsp, err := c.PostAuthVerifyEmailWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthVerifyEmailResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthVerifyEmailWithResponse(ctx context.Context, body PostAuthVerifyEmailJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthVerifyEmailResponse, error) {
+	rsp, err := c.PostAuthVerifyEmail(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthVerifyEmailResponse(rsp)
+}
+
+// PostAuthVerifyEmailResendWithBodyWithResponse request with arbitrary body returning *PostAuthVerifyEmailResendResponse
+func (c *ClientWithResponses) PostAuthVerifyEmailResendWithBodyWithResponse(ctx context.Context, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostAuthVerifyEmailResendResponse, error) {
+	rsp, err := c.PostAuthVerifyEmailResendWithBody(ctx, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthVerifyEmailResendResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostAuthVerifyEmailResendWithResponse(ctx context.Context, body PostAuthVerifyEmailResendJSONRequestBody, reqEditors ...RequestEditorFn) (*PostAuthVerifyEmailResendResponse, error) {
+	rsp, err := c.PostAuthVerifyEmailResend(ctx, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostAuthVerifyEmailResendResponse(rsp)
+}
+
+// GetTasksWithResponse request returning *GetTasksResponse
+func (c *ClientWithResponses) GetTasksWithResponse(ctx context.Context, params *GetTasksParams, reqEditors ...RequestEditorFn) (*GetTasksResponse, error) {
+	rsp, err := c.GetTasks(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTasksResponse(rsp)
+}
+
+// PostTasksWithBodyWithResponse request with arbitrary body returning *PostTasksResponse
+func (c *ClientWithResponses) PostTasksWithBodyWithResponse(ctx context.Context, params *PostTasksParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostTasksResponse, error) {
+	rsp, err := c.PostTasksWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTasksResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostTasksWithResponse(ctx context.Context, params *PostTasksParams, body PostTasksJSONRequestBody, reqEditors ...RequestEditorFn) (*PostTasksResponse, error) {
+	rsp, err := c.PostTasks(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostTasksResponse(rsp)
+}
+
+// DeleteTasksIdWithResponse request returning *DeleteTasksIdResponse
+func (c *ClientWithResponses) DeleteTasksIdWithResponse(ctx context.Context, id ID, params *DeleteTasksIdParams, reqEditors ...RequestEditorFn) (*DeleteTasksIdResponse, error) {
+	rsp, err := c.DeleteTasksId(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteTasksIdResponse(rsp)
+}
+
+// GetTasksIdWithResponse request returning *GetTasksIdResponse
+func (c *ClientWithResponses) GetTasksIdWithResponse(ctx context.Context, id ID, params *GetTasksIdParams, reqEditors ...RequestEditorFn) (*GetTasksIdResponse, error) {
+	rsp, err := c.GetTasksId(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetTasksIdResponse(rsp)
+}
+
+// PatchTasksIdWithBodyWithResponse request with arbitrary body returning *PatchTasksIdResponse
+func (c *ClientWithResponses) PatchTasksIdWithBodyWithResponse(ctx context.Context, id ID, params *PatchTasksIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchTasksIdResponse, error) {
+	rsp, err := c.PatchTasksIdWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchTasksIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchTasksIdWithResponse(ctx context.Context, id ID, params *PatchTasksIdParams, body PatchTasksIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchTasksIdResponse, error) {
+	rsp, err := c.PatchTasksId(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchTasksIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchTasksIdWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, id ID, params *PatchTasksIdParams, body PatchTasksIdApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchTasksIdResponse, error) {
+	rsp, err := c.PatchTasksIdWithApplicationJSONPatchPlusJSONBody(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchTasksIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchTasksIdWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, id ID, params *PatchTasksIdParams, body PatchTasksIdApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchTasksIdResponse, error) {
+	rsp, err := c.PatchTasksIdWithApplicationMergePatchPlusJSONBody(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchTasksIdResponse(rsp)
+}
+
+// GetUsersWithResponse request returning *GetUsersResponse
+func (c *ClientWithResponses) GetUsersWithResponse(ctx context.Context, params *GetUsersParams, reqEditors ...RequestEditorFn) (*GetUsersResponse, error) {
+	rsp, err := c.GetUsers(ctx, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUsersResponse(rsp)
+}
+
+// PostUsersWithBodyWithResponse request with arbitrary body returning *PostUsersResponse
+func (c *ClientWithResponses) PostUsersWithBodyWithResponse(ctx context.Context, params *PostUsersParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PostUsersResponse, error) {
+	rsp, err := c.PostUsersWithBody(ctx, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersResponse(rsp)
+}
+
+func (c *ClientWithResponses) PostUsersWithResponse(ctx context.Context, params *PostUsersParams, body PostUsersJSONRequestBody, reqEditors ...RequestEditorFn) (*PostUsersResponse, error) {
+	rsp, err := c.PostUsers(ctx, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePostUsersResponse(rsp)
+}
+
+// DeleteUsersIdWithResponse request returning *DeleteUsersIdResponse
+func (c *ClientWithResponses) DeleteUsersIdWithResponse(ctx context.Context, id ID, params *DeleteUsersIdParams, reqEditors ...RequestEditorFn) (*DeleteUsersIdResponse, error) {
+	rsp, err := c.DeleteUsersId(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseDeleteUsersIdResponse(rsp)
+}
+
+// GetUsersIdWithResponse request returning *GetUsersIdResponse
+func (c *ClientWithResponses) GetUsersIdWithResponse(ctx context.Context, id ID, params *GetUsersIdParams, reqEditors ...RequestEditorFn) (*GetUsersIdResponse, error) {
+	rsp, err := c.GetUsersId(ctx, id, params, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUsersIdResponse(rsp)
+}
+
+// PatchUsersIdWithBodyWithResponse request with arbitrary body returning *PatchUsersIdResponse
+func (c *ClientWithResponses) PatchUsersIdWithBodyWithResponse(ctx context.Context, id ID, params *PatchUsersIdParams, contentType string, body io.Reader, reqEditors ...RequestEditorFn) (*PatchUsersIdResponse, error) {
+	rsp, err := c.PatchUsersIdWithBody(ctx, id, params, contentType, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchUsersIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchUsersIdWithResponse(ctx context.Context, id ID, params *PatchUsersIdParams, body PatchUsersIdJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchUsersIdResponse, error) {
+	rsp, err := c.PatchUsersId(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchUsersIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchUsersIdWithApplicationJSONPatchPlusJSONBodyWithResponse(ctx context.Context, id ID, params *PatchUsersIdParams, body PatchUsersIdApplicationJSONPatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchUsersIdResponse, error) {
+	rsp, err := c.PatchUsersIdWithApplicationJSONPatchPlusJSONBody(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchUsersIdResponse(rsp)
+}
+
+func (c *ClientWithResponses) PatchUsersIdWithApplicationMergePatchPlusJSONBodyWithResponse(ctx context.Context, id ID, params *PatchUsersIdParams, body PatchUsersIdApplicationMergePatchPlusJSONRequestBody, reqEditors ...RequestEditorFn) (*PatchUsersIdResponse, error) {
+	rsp, err := c.PatchUsersIdWithApplicationMergePatchPlusJSONBody(ctx, id, params, body, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParsePatchUsersIdResponse(rsp)
+}
+
+// GetUsersIdTasksWithResponse request returning *GetUsersIdTasksResponse
+func (c *ClientWithResponses) GetUsersIdTasksWithResponse(ctx context.Context, id ID, reqEditors ...RequestEditorFn) (*GetUsersIdTasksResponse, error) {
+	rsp, err := c.GetUsersIdTasks(ctx, id, reqEditors...)
+	if err != nil {
+		return nil, err
+	}
+	return ParseGetUsersIdTasksResponse(rsp)
+}
+
+// ParsePostAuthPasswordForgotResponse parses an HTTP response from a PostAuthPasswordForgotWithResponse call
+func ParsePostAuthPasswordForgotResponse(rsp *http.Response) (*PostAuthPasswordForgotResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthPasswordForgotResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthPasswordResetResponse parses an HTTP response from a PostAuthPasswordResetWithResponse call
+func ParsePostAuthPasswordResetResponse(rsp *http.Response) (*PostAuthPasswordResetResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthPasswordResetResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthTotpConfirmResponse parses an HTTP response from a PostAuthTotpConfirmWithResponse call
+func ParsePostAuthTotpConfirmResponse(rsp *http.Response) (*PostAuthTotpConfirmResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthTotpConfirmResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest RecoveryCodes
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthTotpEnrollResponse parses an HTTP response from a PostAuthTotpEnrollWithResponse call
+func ParsePostAuthTotpEnrollResponse(rsp *http.Response) (*PostAuthTotpEnrollResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthTotpEnrollResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest TOTPEnrollment
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 409:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON409 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthVerifyCredentialsResponse parses an HTTP response from a PostAuthVerifyCredentialsWithResponse call
+func ParsePostAuthVerifyCredentialsResponse(rsp *http.Response) (*PostAuthVerifyCredentialsResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthVerifyCredentialsResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest VerifyCredentialsResponse
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 401:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON401 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthVerifyEmailResponse parses an HTTP response from a PostAuthVerifyEmailWithResponse call
+func ParsePostAuthVerifyEmailResponse(rsp *http.Response) (*PostAuthVerifyEmailResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthVerifyEmailResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostAuthVerifyEmailResendResponse parses an HTTP response from a PostAuthVerifyEmailResendWithResponse call
+func ParsePostAuthVerifyEmailResendResponse(rsp *http.Response) (*PostAuthVerifyEmailResendResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostAuthVerifyEmailResendResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	return response, nil
+}
+
+// ParseGetTasksResponse parses an HTTP response from a GetTasksWithResponse call
+func ParseGetTasksResponse(rsp *http.Response) (*GetTasksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTasksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Task
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostTasksResponse parses an HTTP response from a PostTasksWithResponse call
+func ParsePostTasksResponse(rsp *http.Response) (*PostTasksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostTasksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest Task
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteTasksIdResponse parses an HTTP response from a DeleteTasksIdWithResponse call
+func ParseDeleteTasksIdResponse(rsp *http.Response) (*DeleteTasksIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteTasksIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetTasksIdResponse parses an HTTP response from a GetTasksIdWithResponse call
+func ParseGetTasksIdResponse(rsp *http.Response) (*GetTasksIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetTasksIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Task
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchTasksIdResponse parses an HTTP response from a PatchTasksIdWithResponse call
+func ParsePatchTasksIdResponse(rsp *http.Response) (*PatchTasksIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchTasksIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest Task
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetUsersResponse parses an HTTP response from a GetUsersWithResponse call
+func ParseGetUsersResponse(rsp *http.Response) (*GetUsersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUsersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePostUsersResponse parses an HTTP response from a PostUsersWithResponse call
+func ParsePostUsersResponse(rsp *http.Response) (*PostUsersResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PostUsersResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 201:
+		var dest User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON201 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseDeleteUsersIdResponse parses an HTTP response from a DeleteUsersIdWithResponse call
+func ParseDeleteUsersIdResponse(rsp *http.Response) (*DeleteUsersIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &DeleteUsersIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetUsersIdResponse parses an HTTP response from a GetUsersIdWithResponse call
+func ParseGetUsersIdResponse(rsp *http.Response) (*GetUsersIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUsersIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParsePatchUsersIdResponse parses an HTTP response from a PatchUsersIdWithResponse call
+func ParsePatchUsersIdResponse(rsp *http.Response) (*PatchUsersIdResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &PatchUsersIdResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest User
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 403:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON403 = &dest
+
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 422:
+		var dest Error
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON422 = &dest
+
+	}
+
+	return response, nil
+}
+
+// ParseGetUsersIdTasksResponse parses an HTTP response from a GetUsersIdTasksWithResponse call
+func ParseGetUsersIdTasksResponse(rsp *http.Response) (*GetUsersIdTasksResponse, error) {
+	bodyBytes, err := io.ReadAll(rsp.Body)
+	defer func() { _ = rsp.Body.Close() }()
+	if err != nil {
+		return nil, err
+	}
+
+	response := &GetUsersIdTasksResponse{
+		Body:         bodyBytes,
+		HTTPResponse: rsp,
+	}
+
+	switch {
+	case strings.Contains(rsp.Header.Get("Content-Type"), "json") && rsp.StatusCode == 200:
+		var dest []Task
+		if err := json.Unmarshal(bodyBytes, &dest); err != nil {
+			return nil, err
+		}
+		response.JSON200 = &dest
+
+	}
+
+	return response, nil
+}
@@ -0,0 +1,280 @@
+// Package client - Go-клиент для Tasks API.
+//
+// Типы и низкоуровневый клиент (Client, ClientWithResponses) сгенерированы из
+// openapi/openapi.yaml командой make gen. API поверх них добавляет повторы
+// запросов, авторизацию по токену, итераторы по страницам списков и типизированные ошибки:
+//
+//	api, err := client.New("http://localhost:8080", client.WithToken(token))
+//	for task, err := range api.Tasks(ctx) {
+//		...
+//	}
+package client
+
+import (
+	"context"
+	"fmt"
+	"iter"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// DefaultPageSize Размер страницы, которой итераторы читают списки
+const DefaultPageSize = 100
+
+// API Клиент Tasks API
+type API struct {
+	raw      *ClientWithResponses
+	pageSize int
+}
+
+// options Настройки, собираемые из Option
+type options struct {
+	httpClient  HttpRequestDoer
+	tokenSource func(ctx context.Context) (string, error)
+	retry       RetryPolicy
+	pageSize    int
+	editors     []RequestEditorFn
+}
+
+// Option Настройка клиента
+type Option func(*options)
+
+// WithDoer Отправлять запросы через свой http.Client (таймауты, транспорт, прокси).
+// Повторы выполняются поверх него
+func WithDoer(doer HttpRequestDoer) Option {
+	return func(o *options) { o.httpClient = doer }
+}
+
+// WithToken Добавлять ко всем запросам заголовок Authorization: Bearer <token>
+func WithToken(token string) Option {
+	return WithTokenSource(func(context.Context) (string, error) { return token, nil })
+}
+
+// WithTokenSource Получать токен перед каждым запросом (например, обновляемый OAuth-токен)
+func WithTokenSource(source func(ctx context.Context) (string, error)) Option {
+	return func(o *options) { o.tokenSource = source }
+}
+
+// WithRetry Политика повторов. RetryPolicy{MaxAttempts: 1} отключает повторы
+func WithRetry(policy RetryPolicy) Option {
+	return func(o *options) { o.retry = policy }
+}
+
+// WithPageSize Размер страницы для итераторов Tasks и Users
+func WithPageSize(size int) Option {
+	return func(o *options) { o.pageSize = size }
+}
+
+// WithRequestEditor Изменять каждый запрос перед отправкой (свои заголовки, трассировка)
+func WithRequestEditor(fn RequestEditorFn) Option {
+	return func(o *options) { o.editors = append(o.editors, fn) }
+}
+
+// New Создает клиент для сервера по адресу server (например, http://localhost:8080)
+func New(server string, opts ...Option) (*API, error) {
+	o := options{
+		httpClient: http.DefaultClient,
+		retry:      DefaultRetryPolicy,
+		pageSize:   DefaultPageSize,
+	}
+	for _, opt := range opts {
+		opt(&o)
+	}
+	if o.pageSize < 1 {
+		return nil, fmt.Errorf("client: page size must be positive, got %d", o.pageSize)
+	}
+
+	clientOpts := []ClientOption{WithHTTPClient(&retryDoer{next: o.httpClient, policy: o.retry})}
+	if o.tokenSource != nil {
+		clientOpts = append(clientOpts, WithRequestEditorFn(bearerToken(o.tokenSource)))
+	}
+	for _, editor := range o.editors {
+		clientOpts = append(clientOpts, WithRequestEditorFn(editor))
+	}
+
+	raw, err := NewClientWithResponses(server, clientOpts...)
+	if err != nil {
+		return nil, fmt.Errorf("client: %w", err)
+	}
+	return &API{raw: raw, pageSize: o.pageSize}, nil
+}
+
+// Raw Сгенерированный клиент для запросов, которых нет в API (например, JSON Patch)
+func (a *API) Raw() *ClientWithResponses {
+	return a.raw
+}
+
+// bearerToken Подставляет токен в заголовок Authorization
+func bearerToken(source func(ctx context.Context) (string, error)) RequestEditorFn {
+	return func(ctx context.Context, req *http.Request) error {
+		token, err := source(ctx)
+		if err != nil {
+			return fmt.Errorf("client: could not get token: %w", err)
+		}
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+		return nil
+	}
+}
+
+// ifMatch Параметр If-Match из ETag. Пустой ETag - изменение без проверки версии
+func ifMatch(etag string) *IfMatch {
+	if etag == "" {
+		return nil
+	}
+	return &etag
+}
+
+// newIdempotencyKey Ключ для POST-запроса: с ним сервер не создаст дубликат при повторе
+func newIdempotencyKey() *IdempotencyKey {
+	key := uuid.NewString()
+	return &key
+}
+
+// ListTasks Возвращает одну страницу задач
+func (a *API) ListTasks(ctx context.Context, limit, offset int) ([]Task, error) {
+	resp, err := a.raw.GetTasksWithResponse(ctx, &GetTasksParams{Limit: &limit, Offset: &offset})
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, nil
+}
+
+// Tasks Итератор по всем задачам. Страницы запрашиваются по мере чтения;
+// после ошибки итерация заканчивается
+func (a *API) Tasks(ctx context.Context) iter.Seq2[Task, error] {
+	return pages(ctx, a.pageSize, a.ListTasks)
+}
+
+// GetTask Возвращает задачу и её ETag
+func (a *API) GetTask(ctx context.Context, id string) (Task, string, error) {
+	resp, err := a.raw.GetTasksIdWithResponse(ctx, id, &GetTasksIdParams{})
+	if err != nil {
+		return Task{}, "", err
+	}
+	if resp.JSON200 == nil {
+		return Task{}, "", newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, resp.HTTPResponse.Header.Get("ETag"), nil
+}
+
+// CreateTask Создает задачу. Запрос отправляется с Idempotency-Key, поэтому повторы безопасны
+func (a *API) CreateTask(ctx context.Context, task TaskRequest) (Task, error) {
+	resp, err := a.raw.PostTasksWithResponse(ctx, &PostTasksParams{IdempotencyKey: newIdempotencyKey()}, task)
+	if err != nil {
+		return Task{}, err
+	}
+	if resp.JSON201 == nil {
+		return Task{}, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON201, nil
+}
+
+// UpdateTask Применяет JSON Merge Patch к задаче и возвращает её новый ETag.
+// Если etag не пустой, задача меняется только если её не изменили с момента чтения
+func (a *API) UpdateTask(ctx context.Context, id, etag string, changes TaskMergePatch) (Task, string, error) {
+	resp, err := a.raw.PatchTasksIdWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, id,
+		&PatchTasksIdParams{IfMatch: ifMatch(etag)}, changes)
+	if err != nil {
+		return Task{}, "", err
+	}
+	if resp.JSON200 == nil {
+		return Task{}, "", newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, resp.HTTPResponse.Header.Get("ETag"), nil
+}
+
+// DeleteTask Удаляет задачу. Непустой etag удаляет её только в этой версии
+func (a *API) DeleteTask(ctx context.Context, id, etag string) error {
+	resp, err := a.raw.DeleteTasksIdWithResponse(ctx, id, &DeleteTasksIdParams{IfMatch: ifMatch(etag)})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusNoContent {
+		return newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return nil
+}
+
+// ListUsers Возвращает одну страницу пользователей
+func (a *API) ListUsers(ctx context.Context, limit, offset int) ([]User, error) {
+	resp, err := a.raw.GetUsersWithResponse(ctx, &GetUsersParams{Limit: &limit, Offset: &offset})
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, nil
+}
+
+// Users Итератор по всем пользователям
+func (a *API) Users(ctx context.Context) iter.Seq2[User, error] {
+	return pages(ctx, a.pageSize, a.ListUsers)
+}
+
+// GetUser Возвращает пользователя и его ETag
+func (a *API) GetUser(ctx context.Context, id string) (User, string, error) {
+	resp, err := a.raw.GetUsersIdWithResponse(ctx, id, &GetUsersIdParams{})
+	if err != nil {
+		return User{}, "", err
+	}
+	if resp.JSON200 == nil {
+		return User{}, "", newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, resp.HTTPResponse.Header.Get("ETag"), nil
+}
+
+// CreateUser Создает пользователя (с Idempotency-Key, как CreateTask)
+func (a *API) CreateUser(ctx context.Context, user UserRequest) (User, error) {
+	resp, err := a.raw.PostUsersWithResponse(ctx, &PostUsersParams{IdempotencyKey: newIdempotencyKey()}, user)
+	if err != nil {
+		return User{}, err
+	}
+	if resp.JSON201 == nil {
+		return User{}, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON201, nil
+}
+
+// UpdateUser Применяет JSON Merge Patch к пользователю и возвращает его новый ETag
+func (a *API) UpdateUser(ctx context.Context, id, etag string, changes UserMergePatch) (User, string, error) {
+	resp, err := a.raw.PatchUsersIdWithApplicationMergePatchPlusJSONBodyWithResponse(ctx, id,
+		&PatchUsersIdParams{IfMatch: ifMatch(etag)}, changes)
+	if err != nil {
+		return User{}, "", err
+	}
+	if resp.JSON200 == nil {
+		return User{}, "", newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, resp.HTTPResponse.Header.Get("ETag"), nil
+}
+
+// DeleteUser Удаляет пользователя. Непустой etag удаляет его только в этой версии
+func (a *API) DeleteUser(ctx context.Context, id, etag string) error {
+	resp, err := a.raw.DeleteUsersIdWithResponse(ctx, id, &DeleteUsersIdParams{IfMatch: ifMatch(etag)})
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode() != http.StatusNoContent {
+		return newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return nil
+}
+
+// UserTasks Возвращает все задачи пользователя
+func (a *API) UserTasks(ctx context.Context, userID string) ([]Task, error) {
+	resp, err := a.raw.GetUsersIdTasksWithResponse(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if resp.JSON200 == nil {
+		return nil, newAPIError(resp.HTTPResponse, resp.Body)
+	}
+	return *resp.JSON200, nil
+}
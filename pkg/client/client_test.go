@@ -0,0 +1,126 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// fastRetry Политика без заметных пауз, чтобы тесты не ждали
+var fastRetry = RetryPolicy{MaxAttempts: 3, BaseDelay: time.Millisecond, MaxDelay: time.Millisecond}
+
+func TestRetry(t *testing.T) {
+	tests := []struct {
+		name      string
+		statuses  []int // Ответы сервера по порядку, последний повторяется
+		call      func(api *API) error
+		wantCalls int32
+		wantErr   error
+	}{
+		{
+			name:      "GET повторяется после 503",
+			statuses:  []int{http.StatusServiceUnavailable, http.StatusOK},
+			call:      func(api *API) error { _, err := api.ListTasks(context.Background(), 10, 0); return err },
+			wantCalls: 2,
+		},
+		{
+			name:      "попытки заканчиваются",
+			statuses:  []int{http.StatusServiceUnavailable},
+			call:      func(api *API) error { _, err := api.ListTasks(context.Background(), 10, 0); return err },
+			wantCalls: 3,
+			wantErr:   ErrServer,
+		},
+		{
+			name:     "POST с Idempotency-Key повторяется",
+			statuses: []int{http.StatusBadGateway, http.StatusCreated},
+			call: func(api *API) error {
+				_, err := api.CreateTask(context.Background(), TaskRequest{Name: "Buy milk"})
+				return err
+			},
+			wantCalls: 2,
+		},
+		{
+			name:     "PATCH не повторяется",
+			statuses: []int{http.StatusServiceUnavailable, http.StatusOK},
+			call: func(api *API) error {
+				_, _, err := api.UpdateTask(context.Background(), "1", "", TaskMergePatch{})
+				return err
+			},
+			wantCalls: 1,
+			wantErr:   ErrServer,
+		},
+		{
+			name:      "ошибка клиента не повторяется",
+			statuses:  []int{http.StatusNotFound},
+			call:      func(api *API) error { _, _, err := api.GetTask(context.Background(), "1"); return err },
+			wantCalls: 1,
+			wantErr:   ErrNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls atomic.Int32
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				n := int(calls.Add(1))
+				status := tt.statuses[min(n, len(tt.statuses))-1]
+				w.Header().Set("Content-Type", "application/json")
+				w.WriteHeader(status)
+				switch {
+				case status >= 400:
+					_, _ = w.Write([]byte(`{"message":"try later"}`))
+				case r.Method == http.MethodGet && r.URL.Path == "/tasks":
+					_, _ = w.Write([]byte(`[]`))
+				default:
+					_, _ = w.Write([]byte(`{"id":"1","name":"Buy milk","is_done":false,"user_id":"1"}`))
+				}
+			}))
+			defer server.Close()
+
+			api, err := New(server.URL, WithRetry(fastRetry))
+			require.NoError(t, err)
+
+			err = tt.call(api)
+			assert.Equal(t, tt.wantCalls, calls.Load())
+			if tt.wantErr == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.ErrorIs(t, err, tt.wantErr)
+
+			var apiErr *APIError
+			require.ErrorAs(t, err, &apiErr)
+			assert.Equal(t, "try later", apiErr.Message)
+		})
+	}
+}
+
+func TestToken(t *testing.T) {
+	var got string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = r.Header.Get("Authorization")
+		w.Header().Set("Content-Type", "application/json")
+		_, _ = w.Write([]byte(`[]`))
+	}))
+	defer server.Close()
+
+	api, err := New(server.URL, WithToken("secret"))
+	require.NoError(t, err)
+	_, err = api.ListUsers(context.Background(), 10, 0)
+	require.NoError(t, err)
+	assert.Equal(t, "Bearer secret", got)
+
+	// Ошибка источника токена не дает отправить запрос
+	failing := errors.New("token expired")
+	api, err = New(server.URL, WithTokenSource(func(context.Context) (string, error) { return "", failing }))
+	require.NoError(t, err)
+	_, err = api.ListUsers(context.Background(), 10, 0)
+	assert.ErrorIs(t, err, failing)
+}
@@ -0,0 +1,69 @@
+package client
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Классы ошибок сервера. Проверяются через errors.Is:
+//
+//	if errors.Is(err, client.ErrNotFound) { ... }
+var (
+	ErrInvalidRequest       = errors.New("invalid request")       // 400, 415, 422: запрос не прошел проверку
+	ErrUnauthorized         = errors.New("unauthorized")          // 401, 403
+	ErrNotFound             = errors.New("not found")             // 404
+	ErrConflict             = errors.New("conflict")              // 409: запрос с тем же Idempotency-Key еще выполняется
+	ErrPreconditionFailed   = errors.New("precondition failed")   // 412: ресурс изменили после чтения (устаревший ETag)
+	ErrPreconditionRequired = errors.New("precondition required") // 428: сервер требует If-Match
+	ErrRateLimited          = errors.New("rate limited")          // 429
+	ErrServer               = errors.New("server error")          // 5xx
+)
+
+// APIError Ответ сервера с кодом ошибки. Message берется из тела
+// в формате схемы Error ({"message": "..."}), если сервер его прислал
+type APIError struct {
+	StatusCode int
+	Message    string
+	Body       []byte
+}
+
+// newAPIError Собирает ошибку из неуспешного ответа
+func newAPIError(resp *http.Response, body []byte) *APIError {
+	apiErr := &APIError{StatusCode: resp.StatusCode, Body: body}
+
+	var model Error
+	if json.Unmarshal(body, &model) == nil {
+		apiErr.Message = model.Message
+	}
+	return apiErr
+}
+
+func (e *APIError) Error() string {
+	if e.Message == "" {
+		return fmt.Sprintf("client: %d %s", e.StatusCode, http.StatusText(e.StatusCode))
+	}
+	return fmt.Sprintf("client: %d %s: %s", e.StatusCode, http.StatusText(e.StatusCode), e.Message)
+}
+
+// Is Сопоставляет код ответа с классом ошибки
+func (e *APIError) Is(target error) bool {
+	switch e.StatusCode {
+	case http.StatusBadRequest, http.StatusUnsupportedMediaType, http.StatusUnprocessableEntity:
+		return target == ErrInvalidRequest
+	case http.StatusUnauthorized, http.StatusForbidden:
+		return target == ErrUnauthorized
+	case http.StatusNotFound:
+		return target == ErrNotFound
+	case http.StatusConflict:
+		return target == ErrConflict
+	case http.StatusPreconditionFailed:
+		return target == ErrPreconditionFailed
+	case http.StatusPreconditionRequired:
+		return target == ErrPreconditionRequired
+	case http.StatusTooManyRequests:
+		return target == ErrRateLimited
+	}
+	return e.StatusCode >= 500 && target == ErrServer
+}
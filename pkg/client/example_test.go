@@ -0,0 +1,111 @@
+package client_test
+
+import (
+	"POSTnGETtrain/pkg/client"
+	"POSTnGETtrain/pkg/patch"
+	"context"
+	"errors"
+	"fmt"
+)
+
+func Example() {
+	server := newTestServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	api, err := client.New(server.URL, client.WithToken("secret"))
+	if err != nil {
+		panic(err)
+	}
+
+	user, err := api.CreateUser(ctx, client.UserRequest{Email: "alice@example.com", Password: "secret"})
+	if err != nil {
+		panic(err)
+	}
+	task, err := api.CreateTask(ctx, client.TaskRequest{Name: "Buy milk", UserID: user.ID})
+	if err != nil {
+		panic(err)
+	}
+
+	fetched, etag, err := api.GetTask(ctx, task.ID)
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(fetched.Name, fetched.IsDone, etag)
+	// Output: Buy milk false "1"
+}
+
+func ExampleAPI_Tasks() {
+	server := newTestServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	// Маленькая страница, чтобы итератор сделал несколько запросов
+	api, err := client.New(server.URL, client.WithPageSize(2))
+	if err != nil {
+		panic(err)
+	}
+	user, err := api.CreateUser(ctx, client.UserRequest{Email: "bob@example.com", Password: "secret"})
+	if err != nil {
+		panic(err)
+	}
+	for _, name := range []string{"one", "two", "three", "four", "five"} {
+		if _, err := api.CreateTask(ctx, client.TaskRequest{Name: name, UserID: user.ID}); err != nil {
+			panic(err)
+		}
+	}
+
+	count := 0
+	for _, err := range api.Tasks(ctx) {
+		if err != nil {
+			panic(err)
+		}
+		count++
+	}
+	fmt.Println(count)
+	// Output: 5
+}
+
+func ExampleAPI_UpdateTask() {
+	server := newTestServer()
+	defer server.Close()
+	ctx := context.Background()
+
+	api, err := client.New(server.URL)
+	if err != nil {
+		panic(err)
+	}
+	user, err := api.CreateUser(ctx, client.UserRequest{Email: "carol@example.com", Password: "secret"})
+	if err != nil {
+		panic(err)
+	}
+	task, err := api.CreateTask(ctx, client.TaskRequest{Name: "Buy milk", UserID: user.ID})
+	if err != nil {
+		panic(err)
+	}
+	_, etag, err := api.GetTask(ctx, task.ID)
+	if err != nil {
+		panic(err)
+	}
+
+	updated, newETag, err := api.UpdateTask(ctx, task.ID, etag, client.TaskMergePatch{IsDone: patch.Value(true)})
+	if err != nil {
+		panic(err)
+	}
+	fmt.Println(updated.IsDone, newETag)
+
+	// Второе изменение по старому ETag отклоняется: задачу уже изменили
+	_, _, err = api.UpdateTask(ctx, task.ID, etag, client.TaskMergePatch{Name: patch.Value("Buy bread")})
+	fmt.Println(errors.Is(err, client.ErrPreconditionFailed))
+
+	err = api.DeleteTask(ctx, task.ID, newETag)
+	fmt.Println(err)
+
+	_, _, err = api.GetTask(ctx, task.ID)
+	fmt.Println(errors.Is(err, client.ErrNotFound))
+	// Output:
+	// true "2"
+	// true
+	// <nil>
+	// true
+}
@@ -0,0 +1,79 @@
+package client_test
+
+import (
+	"POSTnGETtrain/internal/db/dbtest"
+	"POSTnGETtrain/internal/idempotency"
+	"POSTnGETtrain/internal/taskService"
+	"POSTnGETtrain/internal/userService"
+	"POSTnGETtrain/pkg/client"
+	"POSTnGETtrain/pkg/patch"
+	"context"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestIntegration Сценарий работы с API через клиент на каждом хранилище
+func TestIntegration(t *testing.T) {
+	storages := map[string]func(t *testing.T) *httptest.Server{
+		"memory": func(*testing.T) *httptest.Server { return newTestServer() },
+		"database": func(t *testing.T) *httptest.Server {
+			db := dbtest.Open(t)
+			return newServer(taskService.NewTaskRepository(db), userService.NewUserRepository(db),
+				idempotency.NewGormStore(db))
+		},
+	}
+
+	for name, newStorageServer := range storages {
+		t.Run(name, func(t *testing.T) {
+			server := newStorageServer(t)
+			defer server.Close()
+			ctx := context.Background()
+
+			api, err := client.New(server.URL, client.WithPageSize(2))
+			require.NoError(t, err)
+
+			user, err := api.CreateUser(ctx, client.UserRequest{Email: "alice@example.com", Password: "secret"})
+			require.NoError(t, err)
+			_, err = api.CreateUser(ctx, client.UserRequest{Email: "not-an-email", Password: "secret"})
+			assert.ErrorIs(t, err, client.ErrInvalidRequest)
+
+			created := make([]string, 0, 3)
+			for _, name := range []string{"one", "two", "three"} {
+				task, err := api.CreateTask(ctx, client.TaskRequest{Name: name, UserID: user.ID})
+				require.NoError(t, err)
+				created = append(created, task.ID)
+			}
+
+			// Итератор читает все страницы
+			listed := make([]string, 0, 3)
+			for task, err := range api.Tasks(ctx) {
+				require.NoError(t, err)
+				listed = append(listed, task.ID)
+			}
+			assert.ElementsMatch(t, created, listed)
+
+			// Изменение по ETag и отказ по устаревшему ETag
+			_, etag, err := api.GetTask(ctx, created[0])
+			require.NoError(t, err)
+			updated, newETag, err := api.UpdateTask(ctx, created[0], etag, client.TaskMergePatch{IsDone: patch.Value(true)})
+			require.NoError(t, err)
+			assert.True(t, updated.IsDone)
+			assert.NotEqual(t, etag, newETag)
+			_, _, err = api.UpdateTask(ctx, created[0], etag, client.TaskMergePatch{IsDone: patch.Value(false)})
+			assert.ErrorIs(t, err, client.ErrPreconditionFailed)
+
+			// Удаленная задача пропадает из списка задач пользователя
+			require.NoError(t, api.DeleteTask(ctx, created[1], ""))
+			userTasks, err := api.UserTasks(ctx, user.ID)
+			require.NoError(t, err)
+			assert.Len(t, userTasks, 2)
+
+			require.NoError(t, api.DeleteUser(ctx, user.ID, ""))
+			_, _, err = api.GetUser(ctx, user.ID)
+			assert.ErrorIs(t, err, client.ErrNotFound)
+		})
+	}
+}
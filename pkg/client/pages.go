@@ -0,0 +1,29 @@
+package client
+
+import (
+	"context"
+	"iter"
+)
+
+// pages Обходит список постранично: следующая страница запрашивается, когда
+// прочитана предыдущая, а неполная страница означает конец списка
+func pages[T any](ctx context.Context, size int, list func(ctx context.Context, limit, offset int) ([]T, error)) iter.Seq2[T, error] {
+	return func(yield func(T, error) bool) {
+		for offset := 0; ; offset += size {
+			page, err := list(ctx, size, offset)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, item := range page {
+				if !yield(item, nil) {
+					return
+				}
+			}
+			if len(page) < size {
+				return
+			}
+		}
+	}
+}
@@ -0,0 +1,104 @@
+package client
+
+import (
+	"context"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy Когда и как долго повторять запрос
+type RetryPolicy struct {
+	MaxAttempts int           // Всего попыток, включая первую (1 - без повторов)
+	BaseDelay   time.Duration // Пауза перед первым повтором, дальше удваивается
+	MaxDelay    time.Duration // Верхняя граница паузы (и Retry-After от сервера)
+}
+
+// DefaultRetryPolicy Три попытки с паузами около 100 и 200 мс
+var DefaultRetryPolicy = RetryPolicy{MaxAttempts: 3, BaseDelay: 100 * time.Millisecond, MaxDelay: 2 * time.Second}
+
+// retryDoer Повторяет запросы при сетевых ошибках и ответах 429, 502, 503, 504.
+// Повторяются только запросы, которые безопасно выполнить дважды:
+// GET, HEAD, OPTIONS, PUT, DELETE и POST с заголовком Idempotency-Key
+type retryDoer struct {
+	next   HttpRequestDoer
+	policy RetryPolicy
+}
+
+func (d *retryDoer) Do(req *http.Request) (*http.Response, error) {
+	if !retryable(req) {
+		return d.next.Do(req)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := d.next.Do(req)
+		if attempt >= d.policy.MaxAttempts || !shouldRetry(req.Context(), resp, err) {
+			return resp, err
+		}
+
+		delay := d.delay(attempt, resp)
+		if resp != nil {
+			// Тело нужно дочитать, чтобы соединение вернулось в пул
+			_, _ = io.Copy(io.Discard, resp.Body)
+			_ = resp.Body.Close()
+		}
+
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(delay):
+		}
+
+		if req.GetBody != nil {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			req.Body = body
+		}
+	}
+}
+
+// retryable Можно ли отправить запрос повторно
+func retryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false // Тело нельзя перечитать
+	}
+	switch req.Method {
+	case http.MethodGet, http.MethodHead, http.MethodOptions, http.MethodPut, http.MethodDelete:
+		return true
+	case http.MethodPost:
+		return req.Header.Get("Idempotency-Key") != ""
+	}
+	return false
+}
+
+// shouldRetry Временная ли ошибка
+func shouldRetry(ctx context.Context, resp *http.Response, err error) bool {
+	if err != nil {
+		return ctx.Err() == nil // Отмену вызывающим не повторяем
+	}
+	switch resp.StatusCode {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// delay Пауза перед следующей попыткой: Retry-After сервера или экспонента со случайным разбросом
+func (d *retryDoer) delay(attempt int, resp *http.Response) time.Duration {
+	if resp != nil {
+		if seconds, err := strconv.Atoi(resp.Header.Get("Retry-After")); err == nil && seconds >= 0 {
+			return min(time.Duration(seconds)*time.Second, d.policy.MaxDelay)
+		}
+	}
+
+	backoff := min(d.policy.BaseDelay<<min(attempt-1, 30), d.policy.MaxDelay)
+	if backoff <= 0 {
+		return 0
+	}
+	// Разброс в пределах [backoff/2, backoff], чтобы клиенты не повторяли запросы синхронно
+	return backoff/2 + rand.N(backoff/2+1)
+}
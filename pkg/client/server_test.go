@@ -0,0 +1,61 @@
+package client_test
+
+import (
+	"POSTnGETtrain/internal/authService"
+	"POSTnGETtrain/internal/handlers"
+	"POSTnGETtrain/internal/idempotency"
+	"POSTnGETtrain/internal/mailer/mailtest"
+	"POSTnGETtrain/internal/taskService"
+	"POSTnGETtrain/internal/userService"
+	"POSTnGETtrain/internal/validation"
+	"POSTnGETtrain/internal/web"
+	"POSTnGETtrain/internal/web/auth"
+	"POSTnGETtrain/internal/web/tasks"
+	"POSTnGETtrain/internal/web/users"
+	"POSTnGETtrain/openapi"
+	"POSTnGETtrain/pkg/patch"
+	"net/http/httptest"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// newTestServer Поднимает настоящие обработчики API поверх репозиториев в памяти
+func newTestServer() *httptest.Server {
+	taskRepo := taskService.NewMemoryTaskRepository()
+	return newServer(taskRepo, userService.NewMemoryUserRepository(taskRepo), idempotency.NewMemoryStore())
+}
+
+// newServer Поднимает настоящие обработчики API поверх переданных хранилищ
+func newServer(taskRepo taskService.TaskRepository, userRepo userService.UserRepository,
+	store idempotency.Store) *httptest.Server {
+	e := echo.New()
+	e.Binder = patch.NewBinder()
+
+	spec, err := openapi.Load()
+	if err != nil {
+		panic(err)
+	}
+	validator, err := validation.Middleware(spec, validation.Config{})
+	if err != nil {
+		panic(err)
+	}
+	e.Use(validator)
+
+	usersSvc := userService.NewUserService(userRepo)
+	authSvc, err := authService.NewAuthService(usersSvc, authService.NewMemoryResetTokenRepository(), authService.NewMemoryTOTPRepository(), &mailtest.Outbox{}, authService.Config{})
+	if err != nil {
+		panic(err)
+	}
+	taskHandler := handlers.NewHandler(taskService.NewTaskService(taskRepo), false)
+	userHandler := handlers.NewUserHandler(usersSvc, false)
+	authHandler := handlers.NewAuthHandler(authSvc)
+	idempotent := idempotency.Wrap(e, idempotency.Middleware(idempotency.Config{
+		Store: store,
+		TTL:   time.Hour,
+	}))
+	web.RegisterHandlers(idempotent, tasks.NewStrictHandler(taskHandler, nil), users.NewStrictHandler(userHandler, nil),
+		auth.NewStrictHandler(authHandler, nil))
+
+	return httptest.NewServer(e)
+}
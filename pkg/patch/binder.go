@@ -0,0 +1,89 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"mime"
+	"net/http"
+	"reflect"
+	"strings"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Binder Расширяет стандартный биндер Echo поддержкой медиатипов вида
+// application/*+json (merge-patch+json, json-patch+json), которые DefaultBinder отклоняет.
+//
+// Сгенерированный strict-сервер выбирает тело по префиксу Content-Type, поэтому для
+// application/json-patch+json он пытается заполнить и тело application/json. Чтобы массив
+// операций не ломал привязку объекта, тело декодируется только в цель подходящего вида
+// (массив - в срез, объект - в структуру или map), а сам запрос можно привязывать несколько раз
+type Binder struct {
+	echo.DefaultBinder
+}
+
+// NewBinder Конструктор биндера
+func NewBinder() *Binder {
+	return &Binder{}
+}
+
+// Bind Привязывает параметры и тело запроса к i
+func (b *Binder) Bind(i interface{}, c echo.Context) error {
+	mediaType, _, err := mime.ParseMediaType(c.Request().Header.Get(echo.HeaderContentType))
+	if err != nil || !isStructuredJSON(mediaType) {
+		return b.DefaultBinder.Bind(i, c)
+	}
+
+	if err := b.BindPathParams(c, i); err != nil {
+		return err
+	}
+
+	body, err := readBody(c.Request())
+	if err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+	if len(bytes.TrimSpace(body)) == 0 || !sameKind(body, i) {
+		return nil
+	}
+
+	if err := json.Unmarshal(body, i); err != nil {
+		return echo.NewHTTPError(http.StatusBadRequest, err.Error()).SetInternal(err)
+	}
+	return nil
+}
+
+// isStructuredJSON Медиатип с суффиксом +json (RFC 6839)
+func isStructuredJSON(mediaType string) bool {
+	return strings.HasPrefix(mediaType, "application/") && strings.HasSuffix(mediaType, "+json")
+}
+
+// readBody Читает тело и возвращает его обратно в запрос для повторной привязки
+func readBody(req *http.Request) ([]byte, error) {
+	if req.Body == nil {
+		return nil, nil
+	}
+	body, err := io.ReadAll(req.Body)
+	if err != nil {
+		return nil, err
+	}
+	req.Body = io.NopCloser(bytes.NewReader(body))
+	return body, nil
+}
+
+// sameKind Совпадает ли вид JSON-документа (массив/объект) с видом цели привязки
+func sameKind(body []byte, i interface{}) bool {
+	target := reflect.TypeOf(i)
+	for target.Kind() == reflect.Pointer {
+		target = target.Elem()
+	}
+
+	switch bytes.TrimSpace(body)[0] {
+	case '[':
+		return target.Kind() == reflect.Slice
+	case '{':
+		return target.Kind() == reflect.Struct || target.Kind() == reflect.Map
+	default:
+		return true
+	}
+}
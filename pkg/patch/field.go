@@ -0,0 +1,60 @@
+// Package patch содержит типы и функции для частичного обновления ресурсов:
+// JSON Merge Patch (RFC 7396) и JSON Patch (RFC 6902)
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+)
+
+// Field Поле набора изменений. Позволяет отличить три состояния:
+// поле не упомянуто (Set = false), явно очищено (Null = true) и получило значение
+type Field[T any] struct {
+	Set   bool // Поле присутствует в патче
+	Null  bool // В патче передан null
+	Value T    // Новое значение (если Set и не Null)
+}
+
+// Value Конструктор поля с новым значением
+func Value[T any](v T) Field[T] {
+	return Field[T]{Set: true, Value: v}
+}
+
+// Null Конструктор явно очищенного поля
+func Null[T any]() Field[T] {
+	return Field[T]{Set: true, Null: true}
+}
+
+// FromPointer Превращает nullable-указатель из обычного JSON в поле: nil означает "не менять"
+func FromPointer[T any](p *T) Field[T] {
+	if p == nil {
+		return Field[T]{}
+	}
+	return Value(*p)
+}
+
+// IsZero Нужен для тега omitzero: неупомянутое поле не попадает в JSON
+func (f Field[T]) IsZero() bool {
+	return !f.Set
+}
+
+// UnmarshalJSON Вызывается только для упомянутых в документе полей, в том числе для null
+func (f *Field[T]) UnmarshalJSON(data []byte) error {
+	f.Set = true
+	if bytes.Equal(bytes.TrimSpace(data), []byte("null")) {
+		f.Null = true
+		var zero T
+		f.Value = zero
+		return nil
+	}
+	f.Null = false
+	return json.Unmarshal(data, &f.Value)
+}
+
+// MarshalJSON Очищенное поле сериализуется как null
+func (f Field[T]) MarshalJSON() ([]byte, error) {
+	if f.Null || !f.Set {
+		return []byte("null"), nil
+	}
+	return json.Marshal(f.Value)
+}
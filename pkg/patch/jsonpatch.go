@@ -0,0 +1,50 @@
+package patch
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	jsonpatch "github.com/evanphx/json-patch/v5"
+)
+
+// ErrInvalidPatch Патч не удалось разобрать или применить к ресурсу
+var ErrInvalidPatch = errors.New("invalid patch")
+
+// ApplyJSONPatch Применяет JSON Patch (RFC 6902) к текущему представлению ресурса
+// и возвращает эквивалентный JSON Merge Patch, содержащий только изменившиеся поля.
+// Удаленные операцией remove поля попадают в результат как null
+func ApplyJSONPatch(current any, operations any) ([]byte, error) {
+	original, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	rawOperations, err := json.Marshal(operations)
+	if err != nil {
+		return nil, err
+	}
+
+	decoded, err := jsonpatch.DecodePatch(rawOperations)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	// Ошибки операций (в т.ч. неудачный test) означают, что патч неприменим
+	modified, err := decoded.Apply(original)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+
+	return jsonpatch.CreateMergePatch(original, modified)
+}
+
+// DecodeMergePatch Разбирает merge patch в типизированную структуру.
+// Поля, которых нет в структуре (например, id), считаются ошибкой
+func DecodeMergePatch(data []byte, target any) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.DisallowUnknownFields()
+	if err := decoder.Decode(target); err != nil {
+		return fmt.Errorf("%w: %v", ErrInvalidPatch, err)
+	}
+	return nil
+}
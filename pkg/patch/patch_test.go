@@ -0,0 +1,121 @@
+package patch
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type testMergePatch struct {
+	Name   Field[string] `json:"name,omitzero"`
+	IsDone Field[bool]   `json:"is_done,omitzero"`
+}
+
+func TestFieldUnmarshal(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want testMergePatch
+	}{
+		{name: "поле отсутствует", body: `{}`, want: testMergePatch{}},
+		{name: "явный null", body: `{"name":null}`, want: testMergePatch{Name: Null[string]()}},
+		{name: "новое значение", body: `{"name":"milk","is_done":false}`,
+			want: testMergePatch{Name: Value("milk"), IsDone: Value(false)}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got testMergePatch
+			require.NoError(t, json.Unmarshal([]byte(tt.body), &got))
+			assert.Equal(t, tt.want, got)
+
+			// Обратная сериализация не теряет разницу между null и отсутствием поля
+			encoded, err := json.Marshal(got)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.body, string(encoded))
+		})
+	}
+}
+
+func TestApplyJSONPatch(t *testing.T) {
+	current := map[string]any{"id": "1", "name": "milk", "is_done": false}
+
+	tests := []struct {
+		name    string
+		ops     string
+		want    string
+		wantErr bool
+	}{
+		{name: "замена значения", ops: `[{"op":"replace","path":"/is_done","value":true}]`, want: `{"is_done":true}`},
+		{name: "удаление поля", ops: `[{"op":"remove","path":"/name"}]`, want: `{"name":null}`},
+		{name: "успешный test", ops: `[{"op":"test","path":"/name","value":"milk"},{"op":"replace","path":"/name","value":"bread"}]`,
+			want: `{"name":"bread"}`},
+		{name: "неудачный test", ops: `[{"op":"test","path":"/name","value":"bread"}]`, wantErr: true},
+		{name: "несуществующий путь", ops: `[{"op":"remove","path":"/missing"}]`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var ops []map[string]any
+			require.NoError(t, json.Unmarshal([]byte(tt.ops), &ops))
+
+			merge, err := ApplyJSONPatch(current, ops)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidPatch)
+				return
+			}
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.want, string(merge))
+		})
+	}
+}
+
+func TestDecodeMergePatchRejectsUnknownFields(t *testing.T) {
+	var target testMergePatch
+	assert.ErrorIs(t, DecodeMergePatch([]byte(`{"id":"2"}`), &target), ErrInvalidPatch)
+}
+
+func TestBinder(t *testing.T) {
+	type update struct {
+		Name *string `json:"name"`
+	}
+
+	tests := []struct {
+		name        string
+		contentType string
+		body        string
+		wantUpdate  update
+		wantOps     int
+	}{
+		{name: "merge patch", contentType: "application/merge-patch+json", body: `{"name":"milk"}`,
+			wantUpdate: update{Name: ptr("milk")}},
+		{name: "json patch не ломает привязку объекта", contentType: "application/json-patch+json",
+			body: `[{"op":"remove","path":"/name"}]`, wantOps: 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPatch, "/tasks/1", strings.NewReader(tt.body))
+			req.Header.Set(echo.HeaderContentType, tt.contentType)
+			c := echo.New().NewContext(req, httptest.NewRecorder())
+
+			// Как и сгенерированный код, привязываем одно тело в обе цели
+			var gotUpdate update
+			var gotOps []map[string]any
+			binder := NewBinder()
+			require.NoError(t, binder.Bind(&gotUpdate, c))
+			require.NoError(t, binder.Bind(&gotOps, c))
+
+			assert.Equal(t, tt.wantUpdate, gotUpdate)
+			assert.Len(t, gotOps, tt.wantOps)
+		})
+	}
+}
+
+func ptr[T any](v T) *T { return &v }